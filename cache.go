@@ -0,0 +1,459 @@
+package i18n
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"math/big"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// defaultCacheSize is the number of rendered translations CachingTranslator
+// keeps when WithCacheSize is not given.
+const defaultCacheSize = 4096
+
+// CacheOption configures CachingTranslator
+type CacheOption func(*CachingTranslator)
+
+// WithCacheSize bounds the number of cached translations; the least recently
+// used entry is evicted first. Values below one keep the default (4096).
+func WithCacheSize(entries int) CacheOption {
+	return func(t *CachingTranslator) {
+		if entries > 0 {
+			t.size = entries
+		}
+	}
+}
+
+// WithCacheTTL expires cached translations ttl after they were rendered. Zero
+// (the default) keeps them until they are evicted or purged.
+func WithCacheTTL(ttl time.Duration) CacheOption {
+	return func(t *CachingTranslator) {
+		if ttl >= 0 {
+			t.ttl = ttl
+		}
+	}
+}
+
+// WithCacheInvalidation purges the cache after every successful reload of
+// store. Reloads that change no messages purge it too, since plural rules
+// are not part of the reload diff.
+func WithCacheInvalidation(store *ReloadableStore) CacheOption {
+	return func(t *CachingTranslator) {
+		if store != nil {
+			t.stores = append(t.stores, store)
+		}
+	}
+}
+
+// CacheStats reports CachingTranslator activity. Uncacheable counts calls
+// whose arguments cannot be part of a cache key (see CachingTranslator);
+// they are neither hits nor misses. Evictions include expired entries.
+type CacheStats struct {
+	Hits        uint64
+	Misses      uint64
+	Uncacheable uint64
+	Evictions   uint64
+	Entries     int
+}
+
+// HitRatio returns Hits / (Hits + Misses), or zero before any lookup.
+func (s CacheStats) HitRatio() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+var (
+	_ Translator         = &CachingTranslator{}
+	_ ContextTranslator  = &CachingTranslator{}
+	_ metadataTranslator = &CachingTranslator{}
+
+	_ contextMetadataTranslator = &CachingTranslator{}
+)
+
+// CachingTranslator caches rendered translations keyed on locale, key and
+// arguments. Translate options are applied before the key is built, so
+// WithArgs(map) and the equivalent WithArg calls share an entry. Calls with
+// argument types other than strings, booleans, numbers, json.Number, big
+// numbers, PluralCategory or string keyed maps of those bypass the cache, as
+// do failed translations. It is safe for concurrent use.
+//
+// Cached results go stale when the wrapped translator changes underneath
+// (e.g. StaticFallbackResolver.Set); call Purge afterwards.
+type CachingTranslator struct {
+	next     Translator
+	metadata bool // next returns metadata
+	size     int
+	ttl      time.Duration
+	stores   []*ReloadableStore
+	now      func() time.Time
+
+	mu          sync.Mutex
+	entries     map[string]*list.Element
+	order       *list.List
+	generation  uint64
+	stats       CacheStats
+	unsubscribe []func()
+}
+
+// cacheEntry is immutable once stored, so readers may use it after mu is
+// released.
+type cacheEntry struct {
+	key         string
+	result      string
+	metadata    map[string]any
+	hasMetadata bool
+	expires     time.Time
+}
+
+// NewCachingTranslator wraps next with a bounded LRU cache of rendered
+// translations. TranslateWithMetadata is cached as well, so a
+// HookedTranslator wrapping the cache still receives plural and locale
+// metadata on hits.
+func NewCachingTranslator(next Translator, opts ...CacheOption) (*CachingTranslator, error) {
+	if next == nil {
+		return nil, errors.New("i18n: caching translator requires a translator")
+	}
+
+	t := &CachingTranslator{
+		next:    next,
+		size:    defaultCacheSize,
+		now:     time.Now,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+	switch next.(type) {
+	case contextMetadataTranslator, metadataTranslator:
+		t.metadata = true
+	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			opt(t)
+		}
+	}
+
+	for _, store := range t.stores {
+		t.unsubscribe = append(t.unsubscribe, store.Subscribe(func(event ReloadEvent) {
+			if event.Err == nil {
+				t.Purge()
+			}
+		}))
+	}
+
+	return t, nil
+}
+
+func (t *CachingTranslator) Translate(locale, key string, args ...any) (string, error) {
+	if t == nil || t.next == nil {
+		return "", ErrMissingTranslation
+	}
+	result, _, err := t.translate(context.Background(), locale, key, args, false)
+	return result, err
+}
+
+// TranslateWithMetadata returns the metadata of the wrapped translator, or
+// nil when it provides none. Each call receives its own metadata map.
+func (t *CachingTranslator) TranslateWithMetadata(locale, key string, args ...any) (string, map[string]any, error) {
+	if t == nil || t.next == nil {
+		return "", nil, ErrMissingTranslation
+	}
+	return t.translate(context.Background(), locale, key, args, true)
+}
+
+// TranslateContext implements ContextTranslator. ctx is not part of the
+// cache key; misses pass it on to the wrapped translator.
+func (t *CachingTranslator) TranslateContext(ctx context.Context, key string, args ...any) (string, error) {
+	if t == nil || t.next == nil {
+		return "", ErrMissingTranslation
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	result, _, err := t.translateContext(ctx, contextLocale(ctx, t.next), key, contextArgs(ctx, args), false)
+	return result, err
+}
+
+// translateContext lets an outer decorator pass its context down to the
+// translator rendering cache misses.
+func (t *CachingTranslator) translateContext(ctx context.Context, locale, key string, args []any, withMetadata bool) (string, map[string]any, error) {
+	if t == nil || t.next == nil {
+		return "", nil, ErrMissingTranslation
+	}
+	if err := ctx.Err(); err != nil {
+		return "", nil, err
+	}
+	return t.translate(ctx, locale, key, args, withMetadata)
+}
+
+func (t *CachingTranslator) DefaultLocale() string {
+	if t == nil || t.next == nil {
+		return ""
+	}
+	if provider, ok := t.next.(defaultLocaleProvider); ok {
+		return provider.DefaultLocale()
+	}
+	return ""
+}
+
+// Stats returns a snapshot of the cache counters.
+func (t *CachingTranslator) Stats() CacheStats {
+	if t == nil {
+		return CacheStats{}
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stats := t.stats
+	stats.Entries = t.order.Len()
+	return stats
+}
+
+// Purge drops every cached translation. Translations being rendered while
+// Purge runs are not cached.
+func (t *CachingTranslator) Purge() {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	clear(t.entries)
+	t.order.Init()
+	t.generation++
+	t.mu.Unlock()
+}
+
+// Close removes the reload subscriptions registered by WithCacheInvalidation.
+// The cache keeps serving translations.
+func (t *CachingTranslator) Close() {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	unsubscribe := t.unsubscribe
+	t.unsubscribe = nil
+	t.mu.Unlock()
+
+	for _, fn := range unsubscribe {
+		fn()
+	}
+}
+
+func (t *CachingTranslator) translate(ctx context.Context, locale, key string, args []any, withMetadata bool) (string, map[string]any, error) {
+	cacheKey, ok := translationCacheKey(locale, key, args)
+	if !ok {
+		t.mu.Lock()
+		t.stats.Uncacheable++
+		t.mu.Unlock()
+		return t.render(ctx, locale, key, args, withMetadata)
+	}
+
+	entry, generation := t.lookup(cacheKey, withMetadata)
+	if entry != nil {
+		return entry.result, maps.Clone(entry.metadata), nil
+	}
+
+	result, metadata, err := t.render(ctx, locale, key, args, withMetadata)
+	if err != nil {
+		return result, metadata, err
+	}
+	t.store(generation, &cacheEntry{
+		key:         cacheKey,
+		result:      result,
+		metadata:    maps.Clone(metadata),
+		hasMetadata: withMetadata || !t.metadata,
+	})
+	return result, metadata, nil
+}
+
+func (t *CachingTranslator) render(ctx context.Context, locale, key string, args []any, withMetadata bool) (string, map[string]any, error) {
+	return translateNext(ctx, t.next, locale, key, args, withMetadata && t.metadata)
+}
+
+// lookup returns the live entry for key, or nil and the generation a
+// rendered result must be stored under. An entry cached by Translate does
+// not satisfy TranslateWithMetadata; it is replaced once rendered again.
+func (t *CachingTranslator) lookup(key string, withMetadata bool) (*cacheEntry, uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	elem, ok := t.entries[key]
+	if ok {
+		entry := elem.Value.(*cacheEntry)
+		switch {
+		case !entry.expires.IsZero() && !t.now().Before(entry.expires):
+			t.remove(elem)
+			t.stats.Evictions++
+		case withMetadata && !entry.hasMetadata:
+		default:
+			t.order.MoveToFront(elem)
+			t.stats.Hits++
+			return entry, t.generation
+		}
+	}
+	t.stats.Misses++
+	return nil, t.generation
+}
+
+func (t *CachingTranslator) store(generation uint64, entry *cacheEntry) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if generation != t.generation {
+		return
+	}
+	if t.ttl > 0 {
+		entry.expires = t.now().Add(t.ttl)
+	}
+	if elem, ok := t.entries[entry.key]; ok {
+		elem.Value = entry
+		t.order.MoveToFront(elem)
+		return
+	}
+
+	t.entries[entry.key] = t.order.PushFront(entry)
+	for t.order.Len() > t.size {
+		t.remove(t.order.Back())
+		t.stats.Evictions++
+	}
+}
+
+func (t *CachingTranslator) remove(elem *list.Element) {
+	delete(t.entries, elem.Value.(*cacheEntry).key)
+	t.order.Remove(elem)
+}
+
+// translationCacheKey encodes locale, key and the normalized arguments:
+// positional arguments in order, then named arguments sorted by name, then
+// the count, ordinal and range options. ok is false when an argument has no
+// stable encoding.
+func translationCacheKey(locale, key string, args []any) (string, bool) {
+	b := make([]byte, 0, 32+len(locale)+len(key))
+	b = appendCacheString(b, locale)
+	b = appendCacheString(b, key)
+	if len(args) == 0 {
+		return string(b), true
+	}
+
+	rt := newTranslateRuntime(args)
+	ok := true
+	for _, arg := range rt.formatArgs {
+		if b, ok = appendCacheValue(append(b, 'a'), arg); !ok {
+			return "", false
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(rt.namedArgs)) {
+		b = appendCacheString(append(b, 'k'), name)
+		if b, ok = appendCacheValue(b, rt.namedArgs[name]); !ok {
+			return "", false
+		}
+	}
+	if rt.hasCount {
+		if b, ok = appendCacheValue(append(b, 'C'), rt.countOriginal); !ok {
+			return "", false
+		}
+	}
+	if rt.hasOrdinal {
+		if b, ok = appendCacheValue(append(b, 'O'), rt.ordinalOriginal); !ok {
+			return "", false
+		}
+	}
+	if rt.hasRange {
+		if b, ok = appendCacheValue(append(b, 'R'), rt.startOriginal); !ok {
+			return "", false
+		}
+		if b, ok = appendCacheValue(b, rt.endOriginal); !ok {
+			return "", false
+		}
+	}
+	return string(b), true
+}
+
+// appendCacheString appends s with its length so that adjacent strings
+// cannot run into each other.
+func appendCacheString(b []byte, s string) []byte {
+	b = strconv.AppendInt(b, int64(len(s)), 10)
+	b = append(b, ':')
+	return append(b, s...)
+}
+
+// appendCacheValue appends a type tag and the value. Distinct types get
+// distinct tags because formatters such as %T or %#v tell them apart.
+func appendCacheValue(b []byte, value any) ([]byte, bool) {
+	switch v := value.(type) {
+	case nil:
+		return append(b, 'n'), true
+	case string:
+		return appendCacheString(append(b, 's'), v), true
+	case PluralCategory:
+		return appendCacheString(append(b, 'p'), string(v)), true
+	case json.Number:
+		return appendCacheString(append(b, 'j'), string(v)), true
+	case bool:
+		return strconv.AppendBool(append(b, 'b'), v), true
+	case int:
+		return strconv.AppendInt(append(b, 'i', '0'), int64(v), 10), true
+	case int8:
+		return strconv.AppendInt(append(b, 'i', '1'), int64(v), 10), true
+	case int16:
+		return strconv.AppendInt(append(b, 'i', '2'), int64(v), 10), true
+	case int32:
+		return strconv.AppendInt(append(b, 'i', '4'), int64(v), 10), true
+	case int64:
+		return strconv.AppendInt(append(b, 'i', '8'), v, 10), true
+	case uint:
+		return strconv.AppendUint(append(b, 'u', '0'), uint64(v), 10), true
+	case uint8:
+		return strconv.AppendUint(append(b, 'u', '1'), uint64(v), 10), true
+	case uint16:
+		return strconv.AppendUint(append(b, 'u', '2'), uint64(v), 10), true
+	case uint32:
+		return strconv.AppendUint(append(b, 'u', '4'), uint64(v), 10), true
+	case uint64:
+		return strconv.AppendUint(append(b, 'u', '8'), v, 10), true
+	case float32:
+		return strconv.AppendFloat(append(b, 'f', '4'), float64(v), 'g', -1, 32), true
+	case float64:
+		return strconv.AppendFloat(append(b, 'f', '8'), v, 'g', -1, 64), true
+	case *big.Int:
+		if v == nil {
+			return append(b, 'I', '-'), true
+		}
+		return appendCacheString(append(b, 'I'), v.String()), true
+	case *big.Float:
+		if v == nil {
+			return append(b, 'F', '-'), true
+		}
+		b = strconv.AppendUint(append(b, 'F'), uint64(v.Prec()), 10)
+		return appendCacheString(b, v.Text('p', 0)), true
+	case *big.Rat:
+		if v == nil {
+			return append(b, 'Q', '-'), true
+		}
+		return appendCacheString(append(b, 'Q'), v.RatString()), true
+	case map[string]any:
+		b = strconv.AppendInt(append(b, 'm'), int64(len(v)), 10)
+		ok := true
+		for _, name := range slices.Sorted(maps.Keys(v)) {
+			if b, ok = appendCacheValue(appendCacheString(b, name), v[name]); !ok {
+				return nil, false
+			}
+		}
+		return b, true
+	case map[string]string:
+		b = strconv.AppendInt(append(b, 'M'), int64(len(v)), 10)
+		for _, name := range slices.Sorted(maps.Keys(v)) {
+			b = appendCacheString(appendCacheString(b, name), v[name])
+		}
+		return b, true
+	default:
+		return nil, false
+	}
+}
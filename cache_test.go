@@ -0,0 +1,316 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newCacheFixture(t *testing.T) (*ReloadableStore, *SimpleTranslator, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "messages.json")
+	writeTranslationFile(t, path, `{
+  "en": {
+    "home.title": "Welcome",
+    "home.greeting": "Hello {name}",
+    "cart.items": {"one": "{count} item", "other": "{count} items"}
+  },
+  "es": {"home.title": "Bienvenido"}
+}`)
+
+	store, err := NewReloadableStore(NewFileLoader(path))
+	if err != nil {
+		t.Fatalf("NewReloadableStore: %v", err)
+	}
+	base, err := NewSimpleTranslator(store, WithTranslatorDefaultLocale("en"))
+	if err != nil {
+		t.Fatalf("NewSimpleTranslator: %v", err)
+	}
+	return store, base, path
+}
+
+func TestCachingTranslatorHitsAndKeys(t *testing.T) {
+	_, base, _ := newCacheFixture(t)
+	cache, err := NewCachingTranslator(base)
+	if err != nil {
+		t.Fatalf("NewCachingTranslator: %v", err)
+	}
+
+	for range 3 {
+		if got, err := cache.Translate("en", "home.title"); err != nil || got != "Welcome" {
+			t.Fatalf("Translate = %q, %v", got, err)
+		}
+	}
+	if stats := cache.Stats(); stats.Hits != 2 || stats.Misses != 1 || stats.Entries != 1 {
+		t.Fatalf("stats after repeated lookups = %+v", stats)
+	}
+
+	// Equivalent options normalize to one entry; different values do not.
+	first, _ := cache.Translate("en", "home.greeting", WithArgs(map[string]any{"name": "Ana"}))
+	second, _ := cache.Translate("en", "home.greeting", WithArg("name", "Ana"))
+	other, _ := cache.Translate("en", "home.greeting", WithArg("name", "Bo"))
+	if first != "Hello Ana" || second != first || other != "Hello Bo" {
+		t.Fatalf("greetings = %q, %q, %q", first, second, other)
+	}
+	if got, _ := cache.Translate("en", "cart.items", WithCount(1)); got != "1 item" {
+		t.Fatalf("WithCount(1) = %q", got)
+	}
+	if got, _ := cache.Translate("en", "cart.items", WithCount(2)); got != "2 items" {
+		t.Fatalf("WithCount(2) = %q", got)
+	}
+	if got, _ := cache.Translate("es", "home.title"); got != "Bienvenido" {
+		t.Fatalf("es home.title = %q", got)
+	}
+	if stats := cache.Stats(); stats.Hits != 3 || stats.Misses != 6 || stats.Entries != 6 {
+		t.Fatalf("stats after keyed lookups = %+v", stats)
+	}
+
+	type user struct{ Name string }
+	if got, _ := cache.Translate("en", "home.greeting", WithArg("name", user{"Ana"})); got != "Hello {Ana}" {
+		t.Fatalf("struct arg = %q", got)
+	}
+	if _, err := cache.Translate("en", "missing.key"); !errors.Is(err, ErrMissingTranslation) {
+		t.Fatalf("expected ErrMissingTranslation, got %v", err)
+	}
+	stats := cache.Stats()
+	if stats.Uncacheable != 1 || stats.Entries != 6 {
+		t.Fatalf("uncacheable args and errors must not be cached, stats = %+v", stats)
+	}
+	if ratio := stats.HitRatio(); ratio != 3.0/10.0 {
+		t.Fatalf("HitRatio = %v", ratio)
+	}
+
+	cache.Purge()
+	if stats := cache.Stats(); stats.Entries != 0 {
+		t.Fatalf("Purge left %d entries", stats.Entries)
+	}
+}
+
+func TestCachingTranslatorCacheKeyNormalization(t *testing.T) {
+	key := func(args ...any) string {
+		t.Helper()
+		k, ok := translationCacheKey("en", "k", args)
+		if !ok {
+			t.Fatalf("args %v are not cacheable", args)
+		}
+		return k
+	}
+
+	if key(WithArg("a", 1), WithArg("b", "x")) != key(WithArgs(map[string]any{"b": "x", "a": 1})) {
+		t.Fatalf("named args must not depend on option order")
+	}
+	if key(map[string]any{"a": 1, "b": 2}) != key(map[string]any{"b": 2, "a": 1}) {
+		t.Fatalf("map args must not depend on iteration order")
+	}
+	distinct := [][]any{
+		{},
+		{1},
+		{int64(1)},
+		{"1"},
+		{"1", ""},
+		{WithCount(1)},
+		{WithOrdinal(1)},
+		{WithCountRange(1, 2)},
+		{WithArg("count", 1)},
+	}
+	seen := make(map[string]int)
+	for i, args := range distinct {
+		k := key(args...)
+		if j, ok := seen[k]; ok {
+			t.Fatalf("args %d and %d share cache key %q", j, i, k)
+		}
+		seen[k] = i
+	}
+	if _, ok := translationCacheKey("en", "k", []any{[]string{"a"}}); ok {
+		t.Fatalf("slices must not be cacheable")
+	}
+}
+
+func TestCachingTranslatorEviction(t *testing.T) {
+	_, base, _ := newCacheFixture(t)
+	now := time.Unix(0, 0)
+	cache, err := NewCachingTranslator(base, WithCacheSize(2), WithCacheTTL(time.Minute))
+	if err != nil {
+		t.Fatalf("NewCachingTranslator: %v", err)
+	}
+	cache.now = func() time.Time { return now }
+
+	cache.Translate("en", "home.title")
+	cache.Translate("es", "home.title")
+	cache.Translate("en", "home.title") // es is now least recently used
+	cache.Translate("en", "cart.items", WithCount(3))
+	if stats := cache.Stats(); stats.Evictions != 1 || stats.Entries != 2 {
+		t.Fatalf("stats after LRU eviction = %+v", stats)
+	}
+	cache.Translate("en", "home.title")
+	cache.Translate("es", "home.title")
+	if stats := cache.Stats(); stats.Hits != 2 || stats.Misses != 4 {
+		t.Fatalf("expected en hit and es miss, stats = %+v", stats)
+	}
+
+	now = now.Add(time.Minute)
+	cache.Translate("es", "home.title")
+	if stats := cache.Stats(); stats.Hits != 2 || stats.Misses != 5 || stats.Evictions != 3 {
+		t.Fatalf("expected expired entry to miss, stats = %+v", stats)
+	}
+}
+
+func TestCachingTranslatorReloadAndMetadata(t *testing.T) {
+	store, base, path := newCacheFixture(t)
+	cache, err := NewCachingTranslator(base, WithCacheInvalidation(store))
+	if err != nil {
+		t.Fatalf("NewCachingTranslator: %v", err)
+	}
+	collector := NewMissingTranslationCollector(WithMissingFallbacks())
+	var plural []PluralHookMetadata
+	hooked := WrapTranslatorWithHooks(cache, collector, TranslationHookFuncs{
+		After: func(ctx *TranslatorHookContext) {
+			if meta, ok := ctx.PluralMetadata(); ok {
+				plural = append(plural, meta)
+			}
+		},
+	})
+
+	// An entry cached by Translate is rendered again for metadata, which
+	// later hits carry.
+	cache.Translate("en", "cart.items", WithCount(1))
+	for range 2 {
+		if got, err := hooked.Translate("en", "cart.items", WithCount(1)); err != nil || got != "1 item" {
+			t.Fatalf("hooked Translate = %q, %v", got, err)
+		}
+	}
+	if len(plural) != 2 || plural[1].Category != PluralOne || plural[1].Count != 1 {
+		t.Fatalf("plural metadata = %+v", plural)
+	}
+	if stats := cache.Stats(); stats.Hits != 1 || stats.Misses != 2 {
+		t.Fatalf("stats after metadata lookups = %+v", stats)
+	}
+	_, meta, _ := cache.TranslateWithMetadata("en", "cart.items", WithCount(1))
+	meta[metadataPluralCategory] = PluralOther
+	if _, meta, _ := cache.TranslateWithMetadata("en", "cart.items", WithCount(1)); meta[metadataPluralCategory] != PluralOne {
+		t.Fatalf("cached metadata must not be shared with callers, got %v", meta)
+	}
+
+	hooked.Translate("es", "cart.items", WithCount(2))
+	hooked.Translate("es", "cart.items", WithCount(2))
+	if missing := collector.Missing(); len(missing) != 1 || missing[0].Key != "cart.items" || missing[0].Hits != 2 {
+		t.Fatalf("collector on cached fallbacks = %+v", missing)
+	}
+
+	writeTranslationFile(t, path, `{"en": {"home.title": "Welcome back", "cart.items": {"one": "{count} thing", "other": "{count} things"}}}`)
+	if _, err := store.Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if got, _ := hooked.Translate("en", "cart.items", WithCount(1)); got != "1 thing" {
+		t.Fatalf("cache served stale translation after reload: %q", got)
+	}
+
+	cache.Close()
+	writeTranslationFile(t, path, `{"en": {"home.title": "Hi", "cart.items": {"one": "{count} box", "other": "{count} boxes"}}}`)
+	if _, err := store.Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if got, _ := cache.Translate("en", "cart.items", WithCount(1)); got != "1 thing" {
+		t.Fatalf("closed cache must not be purged by reloads, got %q", got)
+	}
+}
+
+func TestCachingTranslatorForwardsContext(t *testing.T) {
+	type traceKey struct{}
+	_, base, _ := newCacheFixture(t)
+
+	var calls int
+	var innerCtx context.Context
+	inner := WrapTranslatorWithHooks(base, TranslationHookFuncs{
+		Before: func(ctx *TranslatorHookContext) { calls, innerCtx = calls+1, ctx.Context },
+	})
+	cache, err := NewCachingTranslator(inner)
+	if err != nil {
+		t.Fatalf("NewCachingTranslator: %v", err)
+	}
+	var plural PluralHookMetadata
+	outer := WrapTranslatorWithHooks(cache, TranslationHookFuncs{
+		After: func(ctx *TranslatorHookContext) { plural, _ = ctx.PluralMetadata() },
+	}).(ContextTranslator)
+
+	ctx := ContextWithLocale(context.WithValue(context.Background(), traceKey{}, "span-1"), "en")
+	if got, err := cache.TranslateContext(ContextWithCount(ctx, 2), "cart.items"); err != nil || got != "2 items" {
+		t.Fatalf("TranslateContext = %q, %v", got, err)
+	}
+	if calls != 1 || innerCtx.Value(traceKey{}) != "span-1" {
+		t.Fatalf("cache miss did not forward ctx, calls = %d", calls)
+	}
+	cache.TranslateContext(ContextWithCount(ctx, 2), "cart.items")
+	if calls != 1 {
+		t.Fatalf("cache hit reached the wrapped translator, calls = %d", calls)
+	}
+
+	if got, _ := outer.TranslateContext(ctx, "cart.items", WithCount(1)); got != "1 item" {
+		t.Fatalf("hooked cache = %q", got)
+	}
+	if innerCtx != ctx || plural.Category != PluralOne {
+		t.Fatalf("outer hooks lost ctx or metadata through the cache: %+v", plural)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := cache.TranslateContext(cancelled, "cart.items", WithCount(2)); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled on a cached key, got %v", err)
+	}
+}
+
+func TestConfigTranslationCache(t *testing.T) {
+	store, _, path := newCacheFixture(t)
+	cfg, err := NewConfig(WithStore(store), WithDefaultLocale("en"), WithTranslationCache(WithCacheSize(8)))
+	if err != nil {
+		t.Fatalf("NewConfig: %v", err)
+	}
+	translator, err := cfg.BuildTranslator()
+	if err != nil {
+		t.Fatalf("BuildTranslator: %v", err)
+	}
+	cache, ok := translator.(*CachingTranslator)
+	if !ok {
+		t.Fatalf("expected *CachingTranslator, got %T", translator)
+	}
+	if cache.size != 8 {
+		t.Fatalf("cache size = %d", cache.size)
+	}
+
+	translator.Translate("en", "home.title")
+	writeTranslationFile(t, path, `{"en": {"home.title": "Welcome back"}}`)
+	if _, err := store.Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+	if got, _ := translator.Translate("en", "home.title"); got != "Welcome back" {
+		t.Fatalf("config cache is not invalidated by store reloads, got %q", got)
+	}
+}
+
+func BenchmarkCachingTranslator(b *testing.B) {
+	store := NewStaticStore(Translations{
+		"en": newStringCatalog("en", map[string]string{"home.greeting": "Hello {name}, you have {count} messages"}),
+	})
+	base, err := NewSimpleTranslator(store, WithTranslatorDefaultLocale("en"))
+	if err != nil {
+		b.Fatalf("NewSimpleTranslator: %v", err)
+	}
+	cache, err := NewCachingTranslator(base)
+	if err != nil {
+		b.Fatalf("NewCachingTranslator: %v", err)
+	}
+
+	for _, bc := range []struct {
+		name       string
+		translator Translator
+	}{{"uncached", base}, {"cached", cache}} {
+		b.Run(bc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				bc.translator.Translate("en", "home.greeting", WithArg("name", "Ana"), WithCount(3))
+			}
+		})
+	}
+}
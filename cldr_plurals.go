@@ -0,0 +1,45 @@
+package i18n
+
+import "sync"
+
+// builtinRuleCache memoizes rule sets parsed and compiled from the
+// generated CLDR data, keyed by locale, one map per rule kind.
+var builtinRuleCache [2]sync.Map
+
+// builtinCardinalRules returns the bundled CLDR cardinal rules for locale.
+func builtinCardinalRules(locale string) (*PluralRuleSet, bool) {
+	rules, ok := builtinCompiledRules(cardinalRuleKind, locale)
+	return rules.ruleSet(), ok
+}
+
+// builtinOrdinalRules returns the bundled CLDR ordinal rules for locale.
+func builtinOrdinalRules(locale string) (*PluralRuleSet, bool) {
+	rules, ok := builtinCompiledRules(ordinalRuleKind, locale)
+	return rules.ruleSet(), ok
+}
+
+// builtinCompiledRules returns the bundled rules of kind for locale, parsed
+// and compiled on first use.
+func builtinCompiledRules(kind pluralRuleKind, locale string) (*compiledPluralRules, bool) {
+	cache := &builtinRuleCache[kind]
+	if cached, ok := cache.Load(locale); ok {
+		return cached.(*compiledPluralRules), true
+	}
+
+	index := cldrCardinalRuleIndex
+	if kind == ordinalRuleKind {
+		index = cldrOrdinalRuleIndex
+	}
+	normalized := normalizeLocale(locale)
+	id, ok := index[normalized]
+	if !ok || id < 0 || id >= len(cldrPluralRuleData) {
+		return nil, false
+	}
+
+	set, err := ParsePluralRuleSet(normalized, cldrPluralRuleData[id])
+	if err != nil {
+		return nil, false
+	}
+	cached, _ := cache.LoadOrStore(locale, compilePluralRules(set))
+	return cached.(*compiledPluralRules), true
+}
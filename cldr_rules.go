@@ -0,0 +1,296 @@
+package i18n
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ParsePluralRuleSet builds a PluralRuleSet from CLDR rule strings keyed by
+// category, e.g. {"one": "n % 10 = 1 and n % 100 != 11 @integer 1, 21"}.
+// Sample annotations are kept on each rule for VerifySamples. A missing
+// "other" category is added.
+func ParsePluralRuleSet(locale string, rules map[string]string) (*PluralRuleSet, error) {
+	categories := make([]string, 0, len(rules))
+	for category := range rules {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+
+	set := &PluralRuleSet{Locale: locale}
+	hasOther := false
+	for _, name := range categories {
+		category, err := parsePluralCategory(name)
+		if err != nil {
+			return nil, err
+		}
+		rule, err := ParsePluralRule(category, rules[name])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if category == PluralOther {
+			hasOther = true
+			rule.Groups = nil
+		}
+		set.Rules = append(set.Rules, rule)
+	}
+
+	sort.SliceStable(set.Rules, func(i, j int) bool {
+		return pluralCategoryOrder(set.Rules[i].Category) < pluralCategoryOrder(set.Rules[j].Category)
+	})
+	if !hasOther {
+		set.Rules = append(set.Rules, PluralRule{Category: PluralOther})
+	}
+
+	return set, nil
+}
+
+// ParsePluralRule parses a single CLDR plural rule such as
+// "v = 0 and i % 10 = 2..4 @integer 2~4, 22~24". Conditions are split into
+// or-separated groups of and-joined conditions; @integer and @decimal
+// samples are stored in PluralRule.Samples.
+func ParsePluralRule(category PluralCategory, rule string) (PluralRule, error) {
+	condition, samples, err := splitCLDRSamples(rule)
+	if err != nil {
+		return PluralRule{}, err
+	}
+
+	groups, err := parseCLDRRule(condition)
+	if err != nil {
+		return PluralRule{}, err
+	}
+	return PluralRule{Category: category, Groups: groups, Samples: samples}, nil
+}
+
+// VerifySamples evaluates every rule against its CLDR samples and reports
+// the samples that select a different category. Samples in compact
+// exponent notation (1c6) set the c/e operands; ranges of them are skipped.
+func (set *PluralRuleSet) VerifySamples() error {
+	if set == nil {
+		return nil
+	}
+
+	var failures []string
+	for _, rule := range set.Rules {
+		for _, sample := range append(append([]string(nil), rule.Samples.Integer...), rule.Samples.Decimal...) {
+			values, err := expandCLDRSample(sample)
+			if err != nil {
+				failures = append(failures, fmt.Sprintf("%s: %v", rule.Category, err))
+				continue
+			}
+			for _, value := range values {
+				operands, _, ok := buildOperandsFromLiteral(value)
+				if !ok {
+					failures = append(failures, fmt.Sprintf("%s: invalid sample %q", rule.Category, value))
+					continue
+				}
+				if got := selectPluralCategory(set, operands); got != rule.Category {
+					failures = append(failures, fmt.Sprintf("%s: sample %s selects %s", rule.Category, value, got))
+				}
+			}
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("i18n: plural rules %s fail samples: %s", set.Locale, strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// splitCLDRSamples separates the condition of a rule from its @integer and
+// @decimal sample lists.
+func splitCLDRSamples(rule string) (string, PluralSamples, error) {
+	var samples PluralSamples
+	idx := strings.Index(rule, "@")
+	if idx < 0 {
+		return strings.TrimSpace(rule), samples, nil
+	}
+
+	condition := strings.TrimSpace(rule[:idx])
+	for _, section := range strings.Split(rule[idx+1:], "@") {
+		kind, list, _ := strings.Cut(strings.TrimSpace(section), " ")
+		var values []string
+		for _, item := range strings.Split(list, ",") {
+			item = strings.TrimSpace(item)
+			if item == "" || item == "…" || item == "..." {
+				continue
+			}
+			values = append(values, item)
+		}
+		switch kind {
+		case "integer":
+			samples.Integer = append(samples.Integer, values...)
+		case "decimal":
+			samples.Decimal = append(samples.Decimal, values...)
+		default:
+			return "", PluralSamples{}, fmt.Errorf("unknown sample type %q", kind)
+		}
+	}
+	return condition, samples, nil
+}
+
+// maxSampleExpansion bounds the values produced by a single sample range.
+const maxSampleExpansion = 1000
+
+// expandCLDRSample turns "2~4" or "0.0~1.5" into the values it covers,
+// keeping the visible fraction digits of the range start.
+func expandCLDRSample(sample string) ([]string, error) {
+	lo, hi, isRange := strings.Cut(sample, "~")
+	if !isRange {
+		return []string{sample}, nil
+	}
+	if strings.ContainsAny(sample, "ce") {
+		return nil, nil
+	}
+
+	digits := 0
+	if _, frac, ok := strings.Cut(lo, "."); ok {
+		digits = len(frac)
+	}
+	start, err := strconv.ParseFloat(lo, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sample %q", sample)
+	}
+	end, err := strconv.ParseFloat(hi, 64)
+	if err != nil || end < start {
+		return nil, fmt.Errorf("invalid sample %q", sample)
+	}
+
+	scale := math.Pow10(digits)
+	first, last := int64(math.Round(start*scale)), int64(math.Round(end*scale))
+	if last-first >= maxSampleExpansion {
+		last = first + maxSampleExpansion - 1
+	}
+
+	values := make([]string, 0, last-first+1)
+	for step := first; step <= last; step++ {
+		values = append(values, strconv.FormatFloat(float64(step)/scale, 'f', digits, 64))
+	}
+	return values, nil
+}
+
+// parseCLDRRule parses the condition part of a CLDR plural rule into
+// or-separated groups of and-joined conditions.
+func parseCLDRRule(rule string) ([][]PluralCondition, error) {
+	rule = strings.TrimSpace(rule)
+	if rule == "" {
+		return nil, nil
+	}
+
+	var groups [][]PluralCondition
+	for _, orPart := range splitCLDRKeyword(rule, "or") {
+		var group []PluralCondition
+		for _, andPart := range splitCLDRKeyword(orPart, "and") {
+			condition, err := parseCLDRCondition(andPart)
+			if err != nil {
+				return nil, err
+			}
+			group = append(group, condition)
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+func splitCLDRKeyword(input, keyword string) []string {
+	fields := strings.Fields(input)
+	var parts []string
+	start := 0
+	for i, field := range fields {
+		if field == keyword {
+			parts = append(parts, strings.Join(fields[start:i], " "))
+			start = i + 1
+		}
+	}
+	return append(parts, strings.Join(fields[start:], " "))
+}
+
+// parseCLDRCondition parses "operand [% mod] operator values".
+func parseCLDRCondition(input string) (PluralCondition, error) {
+	fields := strings.Fields(input)
+	if len(fields) < 3 {
+		return PluralCondition{}, fmt.Errorf("invalid condition %q", input)
+	}
+
+	condition := PluralCondition{Operand: fields[0]}
+	switch condition.Operand {
+	case "n", "i", "v", "w", "f", "t", "c", "e":
+	default:
+		return PluralCondition{}, fmt.Errorf("unknown operand %q in %q", fields[0], input)
+	}
+
+	rest := fields[1:]
+	if rest[0] == "%" || rest[0] == "mod" {
+		if len(rest) < 3 {
+			return PluralCondition{}, fmt.Errorf("invalid modulus in %q", input)
+		}
+		mod, err := strconv.Atoi(rest[1])
+		if err != nil || mod <= 0 {
+			return PluralCondition{}, fmt.Errorf("invalid modulus %q in %q", rest[1], input)
+		}
+		condition.Mod = mod
+		rest = rest[2:]
+	}
+
+	var negate, within bool
+	switch {
+	case rest[0] == "is" && len(rest) > 1 && rest[1] == "not":
+		negate = true
+		rest = rest[2:]
+	case rest[0] == "=" || rest[0] == "in" || rest[0] == "is":
+		rest = rest[1:]
+	case rest[0] == "!=":
+		negate = true
+		rest = rest[1:]
+	case rest[0] == "within":
+		within = true
+		rest = rest[1:]
+	case rest[0] == "not" && len(rest) > 1 && (rest[1] == "in" || rest[1] == "within"):
+		negate = true
+		within = rest[1] == "within"
+		rest = rest[2:]
+	default:
+		return PluralCondition{}, fmt.Errorf("unknown operator %q in %q", rest[0], input)
+	}
+	if len(rest) == 0 {
+		return PluralCondition{}, fmt.Errorf("missing values in %q", input)
+	}
+
+	for _, item := range strings.Split(strings.Join(rest, ""), ",") {
+		if item == "" {
+			continue
+		}
+		if lo, hi, ok := strings.Cut(item, ".."); ok {
+			start, err := strconv.ParseFloat(lo, 64)
+			if err != nil {
+				return PluralCondition{}, fmt.Errorf("invalid range %q in %q", item, input)
+			}
+			end, err := strconv.ParseFloat(hi, 64)
+			if err != nil {
+				return PluralCondition{}, fmt.Errorf("invalid range %q in %q", item, input)
+			}
+			condition.Ranges = append(condition.Ranges, PluralRange{Start: start, End: end})
+			continue
+		}
+		value, err := strconv.ParseFloat(item, 64)
+		if err != nil {
+			return PluralCondition{}, fmt.Errorf("invalid value %q in %q", item, input)
+		}
+		condition.Values = append(condition.Values, value)
+	}
+
+	switch {
+	case within && negate:
+		condition.Operator = OperatorNotWithin
+	case within:
+		condition.Operator = OperatorWithin
+	case negate:
+		condition.Operator = OperatorNotIn
+	default:
+		condition.Operator = OperatorIn
+	}
+
+	return condition, nil
+}
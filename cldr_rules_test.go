@@ -0,0 +1,197 @@
+package i18n
+
+import (
+	"encoding/json"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParsePluralRuleSet(t *testing.T) {
+	rules, err := ParsePluralRuleSet("ru", map[string]string{
+		"one":   "v = 0 and i % 10 = 1 and i % 100 != 11 @integer 1, 21, 31",
+		"few":   "v = 0 and i % 10 = 2..4 and i % 100 != 12..14",
+		"many":  "v = 0 and i % 10 = 0 or v = 0 and i % 10 = 5..9 or v = 0 and i % 100 = 11..14",
+		"other": "   @decimal 0.0~1.5",
+	})
+	if err != nil {
+		t.Fatalf("ParsePluralRuleSet: %v", err)
+	}
+
+	for value, want := range map[int64]PluralCategory{1: PluralOne, 21: PluralOne, 3: PluralFew, 12: PluralMany, 25: PluralMany, 11: PluralMany} {
+		operands, _, _ := convertSignedInt(value)
+		if got := selectPluralCategory(rules, operands); got != want {
+			t.Fatalf("%d => %s want %s", value, got, want)
+		}
+	}
+	if operands, _, ok := toPluralOperands(1.5); !ok || selectPluralCategory(rules, operands) != PluralOther {
+		t.Fatal("expected decimal to select other")
+	}
+
+	for _, rule := range []string{"x = 1", "n % 0 = 1", "n ~ 1", "n = a", "n ="} {
+		if _, err := parseCLDRRule(rule); err == nil {
+			t.Fatalf("expected error for %q", rule)
+		}
+	}
+}
+
+func TestPluralOperandsArbitraryPrecision(t *testing.T) {
+	ru, err := ParsePluralRuleSet("ru", map[string]string{
+		"one":  "v = 0 and i % 10 = 1 and i % 100 != 11",
+		"few":  "v = 0 and i % 10 = 2..4 and i % 100 != 12..14",
+		"many": "v = 0 and i % 10 = 0 or v = 0 and i % 10 = 5..9 or v = 0 and i % 100 = 11..14",
+	})
+	if err != nil {
+		t.Fatalf("ParsePluralRuleSet: %v", err)
+	}
+
+	huge, _ := new(big.Int).SetString("100000000000000000000000000021", 10)
+	tests := []struct {
+		value   any
+		want    PluralCategory
+		literal string
+	}{
+		{value: huge, want: PluralOne, literal: "100000000000000000000000000021"},
+		{value: "18446744073709551623", want: PluralFew},
+		{value: "-100000000000000000000000000011", want: PluralMany},
+		{value: json.Number("22"), want: PluralFew},
+		{value: json.Number("1.2e1"), want: PluralMany, literal: "12"},
+		{value: big.NewFloat(31), want: PluralOne},
+		{value: big.NewRat(3, 2), want: PluralOther, literal: "1.5"},
+		{value: *big.NewRat(42, 1), want: PluralFew},
+		{value: "2.5e-1", want: PluralOther, literal: "0.25"},
+	}
+	for _, tc := range tests {
+		operands, literal, ok := toPluralOperands(tc.value)
+		if !ok {
+			t.Fatalf("toPluralOperands(%v) failed", tc.value)
+		}
+		if got := selectPluralCategory(ru, operands); got != tc.want {
+			t.Fatalf("%v => %s want %s", tc.value, got, tc.want)
+		}
+		if tc.literal != "" && literal != tc.literal {
+			t.Fatalf("%v literal = %q want %q", tc.value, literal, tc.literal)
+		}
+	}
+
+	for _, value := range []any{"c6", "1e", "1e99999", "1.2.3", (*big.Int)(nil)} {
+		if _, _, ok := toPluralOperands(value); ok {
+			t.Fatalf("expected %v to be rejected", value)
+		}
+	}
+
+	fr, err := ParsePluralRuleSet("fr", map[string]string{
+		"one":   "i = 0,1 @integer 0, 1 @decimal 0.0~1.5",
+		"many":  "e = 0 and i != 0 and i % 1000000 = 0 and v = 0 or e != 0..5 @integer 1000000, 1c6, 2c6 @decimal 1.0000001c6, 1.1c6, 2.1e6",
+		"other": " @integer 2~17, 100, 1000, 1c3, 2c3, 1c5 @decimal 2.0~3.5, 1.1c3",
+	})
+	if err != nil {
+		t.Fatalf("ParsePluralRuleSet: %v", err)
+	}
+	if err := fr.VerifySamples(); err != nil {
+		t.Fatalf("VerifySamples: %v", err)
+	}
+	if operands, _, _ := toPluralOperands(1000000); selectPluralCategory(fr, operands) != PluralMany {
+		t.Fatal("expected 1000000 to select many")
+	}
+}
+
+func TestParsePluralRuleSamples(t *testing.T) {
+	rule, err := ParsePluralRule(PluralOne, "i = 0,1 @integer 0, 1 @decimal 0.0~1.5, …")
+	if err != nil {
+		t.Fatalf("ParsePluralRule: %v", err)
+	}
+	if len(rule.Groups) != 1 || rule.Groups[0][0].Operator != OperatorIn || len(rule.Groups[0][0].Values) != 2 {
+		t.Fatalf("unexpected groups %#v", rule.Groups)
+	}
+	if strings.Join(rule.Samples.Integer, ",") != "0,1" || strings.Join(rule.Samples.Decimal, ",") != "0.0~1.5" {
+		t.Fatalf("unexpected samples %#v", rule.Samples)
+	}
+
+	values, err := expandCLDRSample("0.8~1.1")
+	if err != nil || strings.Join(values, ",") != "0.8,0.9,1.0,1.1" {
+		t.Fatalf("expandCLDRSample = %v, %v", values, err)
+	}
+
+	if _, err := ParsePluralRule(PluralOne, "n = 1 @float 1.0"); err == nil {
+		t.Fatal("expected error for unknown sample type")
+	}
+}
+
+func TestPluralRuleSetVerifySamples(t *testing.T) {
+	rules, err := loadPluralRuleFiles(nil, []string{
+		filepath.Join("testdata", "cldr_plurals.json"),
+		filepath.Join("testdata", "cldr_ordinals.json"),
+	})
+	if err != nil {
+		t.Fatalf("loadPluralRuleFiles: %v", err)
+	}
+	if len(rules.cardinal) != 10 || len(rules.ordinal) != 4 {
+		t.Fatalf("unexpected rule counts: %d cardinal, %d ordinal", len(rules.cardinal), len(rules.ordinal))
+	}
+
+	for _, sets := range []map[string]*PluralRuleSet{rules.cardinal, rules.ordinal} {
+		for locale, set := range sets {
+			if err := set.VerifySamples(); err != nil {
+				t.Errorf("%s: %v", locale, err)
+			}
+		}
+	}
+
+	broken, err := ParsePluralRuleSet("en", map[string]string{
+		"one":   "n = 1 @integer 1, 2",
+		"other": " @integer 0, 3~5",
+	})
+	if err != nil {
+		t.Fatalf("ParsePluralRuleSet: %v", err)
+	}
+	err = broken.VerifySamples()
+	if err == nil || !strings.Contains(err.Error(), "sample 2 selects other") {
+		t.Fatalf("expected sample failure, got %v", err)
+	}
+}
+
+func TestFileLoaderReadsCLDRJSONRules(t *testing.T) {
+	translations, err := NewFileLoader(filepath.Join("testdata", "loader_en.json")).
+		WithPluralRuleFiles(
+			filepath.Join("testdata", "cldr_plurals.json"),
+			filepath.Join("testdata", "cldr_ordinals.json"),
+		).
+		Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	catalog := translations["en"]
+	if got := catalog.CardinalRules.Categories(); len(got) != 2 {
+		t.Fatalf("en cardinal categories = %v", got)
+	}
+	if got := catalog.OrdinalRules.Categories(); len(got) != 4 {
+		t.Fatalf("en ordinal categories = %v", got)
+	}
+}
+
+func TestBuiltinPluralRulesParse(t *testing.T) {
+	for _, index := range []map[string]int{cldrCardinalRuleIndex, cldrOrdinalRuleIndex} {
+		for locale, id := range index {
+			if _, err := ParsePluralRuleSet(locale, cldrPluralRuleData[id]); err != nil {
+				t.Fatalf("%s: %v", locale, err)
+			}
+		}
+	}
+
+	rules, ok := builtinCardinalRules("pl")
+	if !ok {
+		t.Fatal("expected built-in rules for pl")
+	}
+	if got := rules.Categories(); len(got) != 4 {
+		t.Fatalf("pl categories = %v", got)
+	}
+	if again, _ := builtinCardinalRules("pl"); again != rules {
+		t.Fatal("expected cached rule set")
+	}
+	if _, ok := builtinOrdinalRules("xx"); ok {
+		t.Fatal("unexpected rules for unknown locale")
+	}
+}
@@ -0,0 +1,624 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template/parse"
+)
+
+type extractConfig struct {
+	roots        []string
+	locale       string
+	out          string
+	inventory    string
+	base         string
+	helpers      []string
+	funcs        []string
+	templateExts []string
+	includeTests bool
+}
+
+type listFlag struct {
+	items []string
+}
+
+func (f *listFlag) String() string {
+	return strings.Join(f.items, ",")
+}
+
+func (f *listFlag) Set(value string) error {
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		f.items = append(f.items, part)
+	}
+	return nil
+}
+
+// keyUsage aggregates every reference to a translation key.
+type keyUsage struct {
+	Key       string   `json:"key"`
+	Plural    bool     `json:"plural,omitempty"`
+	Args      []string `json:"args,omitempty"`
+	Positions []string `json:"positions"`
+
+	args map[string]struct{}
+}
+
+type inventory struct {
+	keys    map[string]*keyUsage
+	dynamic []string
+}
+
+func newInventory() *inventory {
+	return &inventory{keys: make(map[string]*keyUsage)}
+}
+
+func (inv *inventory) record(key, position string, plural bool, args []string) {
+	usage, ok := inv.keys[key]
+	if !ok {
+		usage = &keyUsage{Key: key, args: make(map[string]struct{})}
+		inv.keys[key] = usage
+	}
+	usage.Positions = append(usage.Positions, position)
+	usage.Plural = usage.Plural || plural
+	for _, arg := range args {
+		usage.args[arg] = struct{}{}
+	}
+}
+
+func (inv *inventory) sorted() []*keyUsage {
+	out := make([]*keyUsage, 0, len(inv.keys))
+	for _, usage := range inv.keys {
+		usage.Args = usage.Args[:0]
+		for arg := range usage.args {
+			usage.Args = append(usage.Args, arg)
+		}
+		sort.Strings(usage.Args)
+		sort.Strings(usage.Positions)
+		out = append(out, usage)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Key < out[j].Key })
+	return out
+}
+
+func main() {
+	cfg, err := parseFlags()
+	if err != nil {
+		reportError(err)
+	}
+
+	if err := run(cfg); err != nil {
+		reportError(err)
+	}
+}
+
+func reportError(err error) {
+	fmt.Fprintf(os.Stderr, "i18n-extract: %v\n", err)
+	os.Exit(1)
+}
+
+func parseFlags() (extractConfig, error) {
+	var cfg extractConfig
+	var helpers, funcs, exts listFlag
+
+	flag.StringVar(&cfg.locale, "locale", "en", "locale used for the generated catalog")
+	flag.StringVar(&cfg.out, "out", "", "path for the FileLoader-compatible catalog (defaults to stdout)")
+	flag.StringVar(&cfg.inventory, "inventory", "", "optional path for the key inventory with positions, plural usage and named args")
+	flag.StringVar(&cfg.base, "base", "", "existing catalog whose translations are kept for keys still in use")
+	flag.Var(&helpers, "helper", "template translate helper name (HelperConfig.TemplateHelperKey). Repeat flag to add more.")
+	flag.Var(&funcs, "func", "Go function taking the key as its first argument, such as T(\"key\", args...) (default T). Repeat flag to add more.")
+	flag.Var(&exts, "template-ext", "template file extensions to scan (default .html,.tmpl,.gohtml,.tpl)")
+	flag.BoolVar(&cfg.includeTests, "tests", false, "include _test.go files")
+
+	flag.Parse()
+
+	cfg.roots = flag.Args()
+	if len(cfg.roots) == 0 {
+		cfg.roots = []string{"."}
+	}
+
+	cfg.helpers = helpers.items
+	if len(cfg.helpers) == 0 {
+		cfg.helpers = []string{"translate"}
+	}
+
+	cfg.funcs = funcs.items
+	if len(cfg.funcs) == 0 {
+		cfg.funcs = []string{"T"}
+	}
+
+	cfg.templateExts = exts.items
+	if len(cfg.templateExts) == 0 {
+		cfg.templateExts = []string{".html", ".tmpl", ".gohtml", ".tpl"}
+	}
+
+	if cfg.locale == "" {
+		return extractConfig{}, errors.New("-locale must not be empty")
+	}
+
+	return cfg, nil
+}
+
+func run(cfg extractConfig) error {
+	inv := newInventory()
+
+	for _, root := range cfg.roots {
+		if err := scanRoot(cfg, inv, root); err != nil {
+			return err
+		}
+	}
+
+	for _, position := range inv.dynamic {
+		fmt.Fprintf(os.Stderr, "i18n-extract: %s: skipping non-constant key\n", position)
+	}
+
+	usages := inv.sorted()
+
+	base, err := loadBase(cfg.base, cfg.locale)
+	if err != nil {
+		return err
+	}
+
+	if err := writeJSON(cfg.out, buildCatalog(cfg.locale, usages, base)); err != nil {
+		return err
+	}
+
+	if cfg.inventory != "" {
+		if err := writeJSON(cfg.inventory, usages); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func scanRoot(cfg extractConfig, inv *inventory, root string) error {
+	packages := make(map[string]*goPackage)
+	var dirs []string
+
+	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			name := d.Name()
+			if path != root && (name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		switch {
+		case strings.HasSuffix(path, ".go"):
+			if strings.HasSuffix(path, "_test.go") && !cfg.includeTests {
+				return nil
+			}
+			dir := filepath.Dir(path)
+			pkg := packages[dir]
+			if pkg == nil {
+				pkg = &goPackage{fset: token.NewFileSet()}
+				packages[dir] = pkg
+				dirs = append(dirs, dir)
+			}
+			return pkg.parse(path)
+		case hasExtension(path, cfg.templateExts):
+			return scanTemplateFile(inv, path, cfg.helpers)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Go files are inspected once their whole directory is parsed, so keys
+	// may use constants declared in sibling files.
+	for _, dir := range dirs {
+		packages[dir].scan(inv, cfg.funcs)
+	}
+	return nil
+}
+
+func hasExtension(path string, exts []string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	for _, candidate := range exts {
+		if ext == strings.ToLower(candidate) {
+			return true
+		}
+	}
+	return false
+}
+
+// translateMethods maps translator methods to the index of their key argument.
+var translateMethods = map[string]int{
+	"Translate":             1,
+	"TranslateWithMetadata": 1,
+	"TranslateContext":      1,
+}
+
+// goPackage holds the parsed Go files of one directory.
+type goPackage struct {
+	fset   *token.FileSet
+	files  []*ast.File
+	consts map[string]ast.Expr
+}
+
+func (pkg *goPackage) parse(path string) error {
+	file, err := parser.ParseFile(pkg.fset, path, nil, parser.SkipObjectResolution)
+	if err != nil {
+		return fmt.Errorf("parse %s: %w", path, err)
+	}
+	pkg.files = append(pkg.files, file)
+	return nil
+}
+
+// scan records the translation calls of every file: translator methods
+// (Translate, TranslateWithMetadata, TranslateContext) and funcs, which take
+// the key as their first argument.
+func (pkg *goPackage) scan(inv *inventory, funcs []string) {
+	pkg.collectConsts()
+
+	funcSet := make(map[string]struct{}, len(funcs))
+	for _, name := range funcs {
+		funcSet[name] = struct{}{}
+	}
+
+	for _, file := range pkg.files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+
+			keyIndex := -1
+			switch fn := call.Fun.(type) {
+			case *ast.SelectorExpr:
+				if index, ok := translateMethods[fn.Sel.Name]; ok {
+					keyIndex = index
+				} else if _, ok := funcSet[fn.Sel.Name]; ok {
+					keyIndex = 0
+				}
+			case *ast.Ident:
+				if _, ok := funcSet[fn.Name]; ok {
+					keyIndex = 0
+				}
+			}
+			if keyIndex < 0 || len(call.Args) <= keyIndex {
+				return true
+			}
+
+			position := pkg.fset.Position(call.Pos())
+			ref := fmt.Sprintf("%s:%d", filepath.ToSlash(position.Filename), position.Line)
+
+			key, ok := pkg.stringValue(call.Args[keyIndex])
+			if !ok {
+				inv.dynamic = append(inv.dynamic, ref)
+				return true
+			}
+
+			plural, args := pkg.inspectOptions(call.Args[keyIndex+1:])
+			inv.record(key, ref, plural, args)
+			return true
+		})
+	}
+}
+
+// collectConsts indexes the package level constants of all files.
+func (pkg *goPackage) collectConsts() {
+	pkg.consts = make(map[string]ast.Expr)
+	for _, file := range pkg.files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.CONST {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				value, ok := spec.(*ast.ValueSpec)
+				if !ok {
+					continue
+				}
+				for i, name := range value.Names {
+					if i < len(value.Values) {
+						pkg.consts[name.Name] = value.Values[i]
+					}
+				}
+			}
+		}
+	}
+}
+
+// stringValue resolves string literals, package constants and
+// concatenations of those.
+func (pkg *goPackage) stringValue(expr ast.Expr) (string, bool) {
+	return pkg.resolveString(expr, 0)
+}
+
+func (pkg *goPackage) resolveString(expr ast.Expr, depth int) (string, bool) {
+	if depth > 32 {
+		return "", false
+	}
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		return stringLiteral(e)
+	case *ast.ParenExpr:
+		return pkg.resolveString(e.X, depth+1)
+	case *ast.Ident:
+		value, ok := pkg.consts[e.Name]
+		if !ok {
+			return "", false
+		}
+		return pkg.resolveString(value, depth+1)
+	case *ast.BinaryExpr:
+		if e.Op != token.ADD {
+			return "", false
+		}
+		left, ok := pkg.resolveString(e.X, depth+1)
+		if !ok {
+			return "", false
+		}
+		right, ok := pkg.resolveString(e.Y, depth+1)
+		if !ok {
+			return "", false
+		}
+		return left + right, true
+	default:
+		return "", false
+	}
+}
+
+// inspectOptions looks for WithCount, WithCountRange, WithArg and WithArgs
+// among call arguments.
+func (pkg *goPackage) inspectOptions(args []ast.Expr) (bool, []string) {
+	var (
+		plural bool
+		named  []string
+	)
+
+	for _, arg := range args {
+		call, ok := arg.(*ast.CallExpr)
+		if !ok {
+			continue
+		}
+
+		switch calleeName(call.Fun) {
+		case "WithCount", "WithCountRange":
+			plural = true
+		case "WithArg":
+			if len(call.Args) > 0 {
+				if name, ok := pkg.stringValue(call.Args[0]); ok {
+					named = append(named, name)
+				}
+			}
+		case "WithArgs":
+			if len(call.Args) == 0 {
+				continue
+			}
+			lit, ok := call.Args[0].(*ast.CompositeLit)
+			if !ok {
+				continue
+			}
+			for _, elt := range lit.Elts {
+				kv, ok := elt.(*ast.KeyValueExpr)
+				if !ok {
+					continue
+				}
+				if name, ok := pkg.stringValue(kv.Key); ok {
+					named = append(named, name)
+				}
+			}
+		}
+	}
+
+	return plural, named
+}
+
+func calleeName(expr ast.Expr) string {
+	switch fn := expr.(type) {
+	case *ast.Ident:
+		return fn.Name
+	case *ast.SelectorExpr:
+		return fn.Sel.Name
+	default:
+		return ""
+	}
+}
+
+func stringLiteral(lit *ast.BasicLit) (string, bool) {
+	if lit.Kind != token.STRING {
+		return "", false
+	}
+	value, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return "", false
+	}
+	return value, true
+}
+
+func scanTemplateFile(inv *inventory, path string, helpers []string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", path, err)
+	}
+
+	tree := parse.New(filepath.ToSlash(path))
+	tree.Mode = parse.SkipFuncCheck | parse.ParseComments
+	treeSet := make(map[string]*parse.Tree)
+	if _, err := tree.Parse(string(data), "", "", treeSet); err != nil {
+		return fmt.Errorf("parse template %s: %w", path, err)
+	}
+
+	helperSet := make(map[string]struct{}, len(helpers))
+	for _, helper := range helpers {
+		helperSet[helper] = struct{}{}
+	}
+
+	for _, t := range treeSet {
+		if t.Root == nil {
+			continue
+		}
+		walkTemplate(inv, t, t.Root, helperSet)
+	}
+
+	return nil
+}
+
+func walkTemplate(inv *inventory, tree *parse.Tree, node parse.Node, helpers map[string]struct{}) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			walkTemplate(inv, tree, child, helpers)
+		}
+	case *parse.ActionNode:
+		walkTemplate(inv, tree, n.Pipe, helpers)
+	case *parse.IfNode:
+		walkBranch(inv, tree, &n.BranchNode, helpers)
+	case *parse.RangeNode:
+		walkBranch(inv, tree, &n.BranchNode, helpers)
+	case *parse.WithNode:
+		walkBranch(inv, tree, &n.BranchNode, helpers)
+	case *parse.TemplateNode:
+		walkTemplate(inv, tree, n.Pipe, helpers)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			walkTemplate(inv, tree, cmd, helpers)
+		}
+	case *parse.CommandNode:
+		recordTemplateCall(inv, tree, n, helpers)
+		for _, arg := range n.Args {
+			walkTemplate(inv, tree, arg, helpers)
+		}
+	}
+}
+
+func walkBranch(inv *inventory, tree *parse.Tree, branch *parse.BranchNode, helpers map[string]struct{}) {
+	walkTemplate(inv, tree, branch.Pipe, helpers)
+	walkTemplate(inv, tree, branch.List, helpers)
+	if branch.ElseList != nil {
+		walkTemplate(inv, tree, branch.ElseList, helpers)
+	}
+}
+
+// recordTemplateCall handles {{translate locale "key" ...}},
+// {{translate_count locale "key" count ...}} and
+// {{translate_range locale "key" start end ...}}.
+func recordTemplateCall(inv *inventory, tree *parse.Tree, cmd *parse.CommandNode, helpers map[string]struct{}) {
+	if len(cmd.Args) < 3 {
+		return
+	}
+
+	ident, ok := cmd.Args[0].(*parse.IdentifierNode)
+	if !ok {
+		return
+	}
+
+	plural := ident.Ident == "translate_count" || ident.Ident == "translate_range"
+	if _, isHelper := helpers[ident.Ident]; !isHelper && !plural {
+		return
+	}
+
+	location, _ := tree.ErrorContext(cmd)
+	ref := templateLocation(location)
+
+	key, ok := cmd.Args[2].(*parse.StringNode)
+	if !ok {
+		inv.dynamic = append(inv.dynamic, ref)
+		return
+	}
+
+	inv.record(key.Text, ref, plural, nil)
+}
+
+// templateLocation trims "name:line:col" down to "name:line".
+func templateLocation(location string) string {
+	if idx := strings.LastIndex(location, ":"); idx > 0 {
+		return location[:idx]
+	}
+	return location
+}
+
+func loadBase(path, locale string) (map[string]json.RawMessage, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read base catalog: %w", err)
+	}
+
+	var raw map[string]map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("decode base catalog %s: %w", path, err)
+	}
+
+	return raw[locale], nil
+}
+
+// buildCatalog renders {locale: {key: template | {category: template}}}. Keys
+// present in base keep their existing translation; new keys get a stub that
+// declares the named args (and {count} for plural keys).
+func buildCatalog(locale string, usages []*keyUsage, base map[string]json.RawMessage) map[string]map[string]any {
+	messages := make(map[string]any, len(usages))
+
+	for _, usage := range usages {
+		if existing, ok := base[usage.Key]; ok {
+			messages[usage.Key] = existing
+			continue
+		}
+
+		stub := usage.Key
+		for _, arg := range usage.Args {
+			stub += " {" + arg + "}"
+		}
+
+		if usage.Plural {
+			messages[usage.Key] = map[string]string{
+				"one":   "{count} " + stub,
+				"other": "{count} " + stub,
+			}
+			continue
+		}
+
+		messages[usage.Key] = stub
+	}
+
+	return map[string]map[string]any{locale: messages}
+}
+
+func writeJSON(path string, value any) error {
+	var w io.Writer = os.Stdout
+	if path != "" {
+		file, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("create %s: %w", path, err)
+		}
+		defer file.Close()
+		w = file
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(value)
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+}
+
+func testConfig(root string) extractConfig {
+	return extractConfig{
+		roots:        []string{root},
+		locale:       "en",
+		helpers:      []string{"translate"},
+		funcs:        []string{"T"},
+		templateExts: []string{".html", ".tmpl", ".gohtml", ".tpl"},
+	}
+}
+
+func TestScanRoot(t *testing.T) {
+	type want struct {
+		plural bool
+		args   []string
+	}
+
+	tests := []struct {
+		name    string
+		files   map[string]string
+		want    map[string]want
+		dynamic int
+	}{
+		{
+			name: "T helper",
+			files: map[string]string{"app.go": `package app
+func handler() { _ = T("home.title"); _ = i18n.T("home.subtitle", WithArg("name", "Ana")) }`},
+			want: map[string]want{
+				"home.title":    {},
+				"home.subtitle": {args: []string{"name"}},
+			},
+		},
+		{
+			name: "translator methods",
+			files: map[string]string{"app.go": `package app
+func handler() {
+	tr.Translate(locale, "cart.items", i18n.WithCount(n))
+	tr.TranslateWithMetadata(locale, "cart.total", i18n.WithArgs(map[string]any{"total": 1, "currency": "EUR"}))
+	tr.TranslateContext(ctx, "cart.count", i18n.WithCount(n))
+	tr.TranslateContext(ctx, "cart.range", i18n.WithCountRange(1, 3))
+}`},
+			want: map[string]want{
+				"cart.items": {plural: true},
+				"cart.total": {args: []string{"currency", "total"}},
+				"cart.count": {plural: true},
+				"cart.range": {plural: true},
+			},
+		},
+		{
+			name: "constant keys",
+			files: map[string]string{
+				"keys.go": `package app
+const (
+	prefix   = "inbox."
+	keyTitle = prefix + "title"
+	argName  = "name"
+)`,
+				"app.go": `package app
+func handler(key string) {
+	tr.Translate(locale, keyTitle, WithArg(argName, "Ana"))
+	tr.Translate(locale, (prefix + "empty"))
+	tr.Translate(locale, key)
+}`,
+			},
+			want: map[string]want{
+				"inbox.title": {args: []string{"name"}},
+				"inbox.empty": {},
+			},
+			dynamic: 1,
+		},
+		{
+			name: "templates",
+			files: map[string]string{"views/page.html": `{{ define "page" }}
+<h1>{{ translate .Locale "page.title" }}</h1>
+{{ if .Items }}{{ translate_count .Locale "page.items" (len .Items) }}{{ end }}
+{{ translate .Locale .Key }}
+{{ end }}`},
+			want: map[string]want{
+				"page.title": {},
+				"page.items": {plural: true},
+			},
+			dynamic: 1,
+		},
+		{
+			name: "skipped files",
+			files: map[string]string{
+				"app.go":               `package app; func f() { T("kept") }`,
+				"app_test.go":          `package app; func g() { T("test.only") }`,
+				"vendor/dep/dep.go":    `package dep; func f() { T("vendor.key") }`,
+				"testdata/fixture.go":  `package fixture; func f() { T("fixture.key") }`,
+				".hidden/hidden.go":    `package hidden; func f() { T("hidden.key") }`,
+				"notes.txt":            `T("text.key")`,
+				"views/page.tmpl.orig": `{{ translate .Locale "orig.key" }}`,
+			},
+			want: map[string]want{"kept": {}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			root := t.TempDir()
+			writeFiles(t, root, tc.files)
+
+			inv := newInventory()
+			if err := scanRoot(testConfig(root), inv, root); err != nil {
+				t.Fatalf("scanRoot: %v", err)
+			}
+
+			got := make(map[string]want)
+			for _, usage := range inv.sorted() {
+				got[usage.Key] = want{plural: usage.Plural, args: usage.Args}
+				if len(usage.Positions) == 0 {
+					t.Fatalf("%s has no positions", usage.Key)
+				}
+			}
+			for key, w := range tc.want {
+				if len(w.args) == 0 {
+					w.args = nil
+				}
+				g := got[key]
+				if len(g.args) == 0 {
+					g.args = nil
+				}
+				if !reflect.DeepEqual(g, w) {
+					t.Fatalf("%s = %+v want %+v", key, g, w)
+				}
+			}
+			if len(got) != len(tc.want) {
+				keys := make([]string, 0, len(got))
+				for key := range got {
+					keys = append(keys, key)
+				}
+				sort.Strings(keys)
+				t.Fatalf("keys = %v want %d keys", keys, len(tc.want))
+			}
+			if len(inv.dynamic) != tc.dynamic {
+				t.Fatalf("dynamic keys = %v want %d", inv.dynamic, tc.dynamic)
+			}
+		})
+	}
+}
+
+func TestRunWritesCatalogAndInventory(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"app.go": `package app
+func handler() {
+	tr.Translate(locale, "home.title")
+	tr.Translate(locale, "cart.items", WithCount(n), WithArg("name", "Ana"))
+	T("home.title")
+}`,
+	})
+	out := filepath.Join(t.TempDir(), "en.json")
+	inventoryPath := filepath.Join(t.TempDir(), "keys.json")
+	base := filepath.Join(t.TempDir(), "base.json")
+	writeFiles(t, filepath.Dir(base), map[string]string{"base.json": `{"en": {"home.title": "Welcome", "unused": "Gone"}}`})
+
+	cfg := testConfig(root)
+	cfg.out = out
+	cfg.inventory = inventoryPath
+	cfg.base = base
+	if err := run(cfg); err != nil {
+		t.Fatalf("run: %v", err)
+	}
+
+	var catalog map[string]map[string]any
+	readJSON(t, out, &catalog)
+	wantCatalog := map[string]map[string]any{
+		"en": {
+			"home.title": "Welcome",
+			"cart.items": map[string]any{"one": "{count} cart.items {name}", "other": "{count} cart.items {name}"},
+		},
+	}
+	if !reflect.DeepEqual(catalog, wantCatalog) {
+		t.Fatalf("catalog = %v want %v", catalog, wantCatalog)
+	}
+
+	var usages []keyUsage
+	readJSON(t, inventoryPath, &usages)
+	if len(usages) != 2 {
+		t.Fatalf("inventory = %+v", usages)
+	}
+	cart, home := usages[0], usages[1]
+	appPath := filepath.ToSlash(filepath.Join(root, "app.go"))
+	if cart.Key != "cart.items" || !cart.Plural || !reflect.DeepEqual(cart.Args, []string{"name"}) ||
+		!reflect.DeepEqual(cart.Positions, []string{appPath + ":4"}) {
+		t.Fatalf("cart.items usage = %+v", cart)
+	}
+	if home.Key != "home.title" || home.Plural || len(home.Args) != 0 ||
+		!reflect.DeepEqual(home.Positions, []string{appPath + ":3", appPath + ":5"}) {
+		t.Fatalf("home.title usage = %+v", home)
+	}
+}
+
+func readJSON(t *testing.T, path string, v any) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		t.Fatalf("decode %s: %v", path, err)
+	}
+}
@@ -21,18 +21,20 @@ type localeSpec struct {
 }
 
 type generatorConfig struct {
-	pkg      string
-	out      string
-	cldrPath string
-	locales  []localeSpec
+	pkg        string
+	out        string
+	pluralsOut string
+	cldrPath   string
+	locales    []localeSpec
 }
 
 type bundlePayload struct {
 	Locale      string
 	List        listPatterns
-	Ordinal     string
+	Ordinal     ordinalData
 	Measurement map[string]string
 	Phone       phoneMetadata
+	Compact     map[string]string
 }
 
 var emptyRegion language.Region
@@ -44,6 +46,11 @@ type listPatterns struct {
 	End    string
 }
 
+type ordinalData struct {
+	Rules    map[string]string
+	Suffixes map[string]string
+}
+
 type phoneMetadata struct {
 	CountryCode    string
 	NationalPrefix string
@@ -58,6 +65,20 @@ var defaultMeasurementUnits = map[string]string{
 	"lb": "mass-pound",
 }
 
+// defaultOrdinalSuffixes maps a language to the suffix appended for each
+// ordinal plural category. CLDR ships the rules but not the suffixes
+// outside of RBNF, so they are kept here.
+var defaultOrdinalSuffixes = map[string]map[string]string{
+	"de": {"other": "."},
+	"en": {"one": "st", "two": "nd", "few": "rd", "other": "th"},
+	"es": {"other": "º"},
+	"fr": {"one": "er", "other": "e"},
+	"it": {"other": "º"},
+	"nl": {"other": "e"},
+	"pt": {"other": "º"},
+	"sv": {"one": ":a", "other": ":e"},
+}
+
 type localeFlag struct {
 	items []string
 }
@@ -100,13 +121,14 @@ func parseFlags() (generatorConfig, error) {
 
 	flag.StringVar(&cfg.pkg, "pkg", "i18n", "package name for generated file")
 	flag.StringVar(&cfg.out, "out", "formatters_cldr_data.go", "path to generated Go file")
+	flag.StringVar(&cfg.pluralsOut, "plurals-out", "", "path to generated plural rule data for all CLDR locales (skipped when empty)")
 	flag.StringVar(&cfg.cldrPath, "cldr", "", "path to CLDR core data directory (expects subdirectories like main/ and supplemental/)")
 	flag.Var(&localeList, "locale", "locale to generate (optionally include territory using locale:REGION). Repeat flag to add more.")
 
 	flag.Parse()
 
-	if len(localeList.items) == 0 {
-		return generatorConfig{}, errors.New("at least one -locale value is required")
+	if len(localeList.items) == 0 && cfg.pluralsOut == "" {
+		return generatorConfig{}, errors.New("at least one -locale value (or -plurals-out) is required")
 	}
 
 	for _, spec := range localeList.items {
@@ -135,6 +157,21 @@ func run(cfg generatorConfig) error {
 	}
 
 	supplemental := data.Supplemental()
+
+	if cfg.pluralsOut != "" {
+		source, err := renderPluralSource(cfg.pkg, extractPluralData(supplemental))
+		if err != nil {
+			return err
+		}
+		if err := writeSource(cfg.pluralsOut, source); err != nil {
+			return err
+		}
+	}
+
+	if len(cfg.locales) == 0 {
+		return nil
+	}
+
 	var bundles []bundlePayload
 
 	for _, spec := range cfg.locales {
@@ -158,11 +195,14 @@ func run(cfg generatorConfig) error {
 		return err
 	}
 
-	if err := ensureDir(cfg.out); err != nil {
+	return writeSource(cfg.out, source)
+}
+
+func writeSource(path string, source []byte) error {
+	if err := ensureDir(path); err != nil {
 		return err
 	}
-
-	return os.WriteFile(cfg.out, source, 0o644)
+	return os.WriteFile(path, source, 0o644)
 }
 
 func loadCLDR(path string) (*cldr.CLDR, error) {
@@ -242,9 +282,13 @@ func buildBundle(data *cldr.CLDR, supplemental *cldr.SupplementalData, spec loca
 	}
 
 	payload.List = extractListPatterns(ldml)
-	payload.Ordinal = detectOrdinalSystem(spec.Locale)
+	payload.Ordinal = ordinalData{
+		Rules:    extractOrdinalRules(supplemental, spec.Locale),
+		Suffixes: ordinalSuffixes(spec.Locale),
+	}
 	payload.Measurement = extractMeasurementUnits(ldml)
 	payload.Phone = extractPhoneMetadata(supplemental, spec)
+	payload.Compact = extractCompactPatterns(ldml)
 
 	return payload, nil
 }
@@ -306,14 +350,62 @@ func extractListPatterns(ldml *cldr.LDML) listPatterns {
 	return patterns
 }
 
-func detectOrdinalSystem(locale string) string {
-	locale = strings.ToLower(locale)
-	switch {
-	case strings.HasPrefix(locale, "es"):
-		return "spanish"
-	default:
-		return "english"
+// extractOrdinalRules returns the CLDR ordinal plural rules for locale,
+// falling back to its base language, with sample annotations stripped.
+func extractOrdinalRules(supplemental *cldr.SupplementalData, locale string) map[string]string {
+	result := make(map[string]string)
+	if supplemental == nil {
+		return result
+	}
+
+	candidates := []string{strings.ReplaceAll(locale, "-", "_")}
+	if base, _, ok := strings.Cut(candidates[0], "_"); ok {
+		candidates = append(candidates, base)
 	}
+
+	for _, candidate := range candidates {
+		for _, plurals := range supplemental.Plurals {
+			if plurals == nil || plurals.Type != "ordinal" {
+				continue
+			}
+			for _, rules := range plurals.PluralRules {
+				if rules == nil || !containsLocale(rules.Locales, candidate) {
+					continue
+				}
+				for _, rule := range rules.PluralRule {
+					if rule == nil || rule.Count == "" {
+						continue
+					}
+					data := rule.Data()
+					if idx := strings.Index(data, "@"); idx >= 0 {
+						data = data[:idx]
+					}
+					result[rule.Count] = strings.TrimSpace(data)
+				}
+				return result
+			}
+		}
+	}
+
+	return result
+}
+
+func containsLocale(list, locale string) bool {
+	for _, item := range strings.Fields(list) {
+		if strings.EqualFold(item, locale) {
+			return true
+		}
+	}
+	return false
+}
+
+func ordinalSuffixes(locale string) map[string]string {
+	base, _, _ := strings.Cut(strings.ToLower(locale), "-")
+	result := make(map[string]string)
+	for category, suffix := range defaultOrdinalSuffixes[base] {
+		result[category] = suffix
+	}
+	return result
 }
 
 func extractMeasurementUnits(ldml *cldr.LDML) map[string]string {
@@ -358,6 +450,42 @@ func extractMeasurementUnits(ldml *cldr.LDML) map[string]string {
 	return result
 }
 
+// extractCompactPatterns returns the short decimal patterns of the latn
+// numbering system keyed by magnitude ("1000": "0K"), using the "other" form.
+func extractCompactPatterns(ldml *cldr.LDML) map[string]string {
+	result := make(map[string]string)
+	if ldml == nil || ldml.Numbers == nil {
+		return result
+	}
+
+	for _, formats := range ldml.Numbers.DecimalFormats {
+		if formats == nil || (formats.NumberSystem != "" && formats.NumberSystem != "latn") {
+			continue
+		}
+		for _, length := range formats.DecimalFormatLength {
+			if length == nil || length.Type != "short" {
+				continue
+			}
+			for _, format := range length.DecimalFormat {
+				if format == nil {
+					continue
+				}
+				for _, pattern := range format.Pattern {
+					if pattern == nil || pattern.Type == "" || pattern.Alt != "" {
+						continue
+					}
+					if pattern.Count != "" && !strings.EqualFold(pattern.Count, "other") {
+						continue
+					}
+					result[pattern.Type] = strings.ReplaceAll(pattern.Data(), "'", "")
+				}
+			}
+		}
+	}
+
+	return result
+}
+
 func selectUnitDisplayName(list []*struct {
 	cldr.Common
 	Count string `xml:"count,attr"`
@@ -459,7 +587,8 @@ func renderSource(pkg string, bundles []bundlePayload) ([]byte, error) {
 	buf.WriteString("}\n\n")
 
 	buf.WriteString("type cldrOrdinalRules struct {\n")
-	buf.WriteString("\tSystem string\n")
+	buf.WriteString("\tRules    map[string]string\n")
+	buf.WriteString("\tSuffixes map[string]string\n")
 	buf.WriteString("}\n\n")
 
 	buf.WriteString("type cldrMeasurementData struct {\n")
@@ -472,11 +601,16 @@ func renderSource(pkg string, bundles []bundlePayload) ([]byte, error) {
 	buf.WriteString("\tGroups         []int\n")
 	buf.WriteString("}\n\n")
 
+	buf.WriteString("type cldrCompactPatterns struct {\n")
+	buf.WriteString("\tShort map[string]string\n")
+	buf.WriteString("}\n\n")
+
 	buf.WriteString("type cldrBundle struct {\n")
 	buf.WriteString("\tList        cldrListPatterns\n")
 	buf.WriteString("\tOrdinal     cldrOrdinalRules\n")
 	buf.WriteString("\tMeasurement cldrMeasurementData\n")
 	buf.WriteString("\tPhone       cldrPhoneMetadata\n")
+	buf.WriteString("\tCompact     cldrCompactPatterns\n")
 	buf.WriteString("}\n\n")
 
 	buf.WriteString("var cldrBundles = map[string]cldrBundle{\n")
@@ -490,7 +624,8 @@ func renderSource(pkg string, bundles []bundlePayload) ([]byte, error) {
 		buf.WriteString("\t\t},\n")
 
 		buf.WriteString("\t\tOrdinal: cldrOrdinalRules{\n")
-		fmt.Fprintf(&buf, "\t\t\tSystem: %q,\n", bundle.Ordinal)
+		writeStringMap(&buf, "Rules", bundle.Ordinal.Rules)
+		writeStringMap(&buf, "Suffixes", bundle.Ordinal.Suffixes)
 		buf.WriteString("\t\t},\n")
 
 		buf.WriteString("\t\tMeasurement: cldrMeasurementData{\n")
@@ -519,6 +654,10 @@ func renderSource(pkg string, bundles []bundlePayload) ([]byte, error) {
 		buf.WriteString("},\n")
 		buf.WriteString("\t\t},\n")
 
+		buf.WriteString("\t\tCompact: cldrCompactPatterns{\n")
+		writeStringMap(&buf, "Short", bundle.Compact)
+		buf.WriteString("\t\t},\n")
+
 		buf.WriteString("\t},\n")
 	}
 	buf.WriteString("}\n\n")
@@ -540,6 +679,145 @@ func renderSource(pkg string, bundles []bundlePayload) ([]byte, error) {
 	return format.Source(buf.Bytes())
 }
 
+func writeStringMap(buf *bytes.Buffer, field string, values map[string]string) {
+	fmt.Fprintf(buf, "\t\t\t%s: map[string]string{\n", field)
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fmt.Fprintf(buf, "\t\t\t\t%q: %q,\n", key, values[key])
+	}
+	buf.WriteString("\t\t\t},\n")
+}
+
+// pluralData holds every distinct CLDR rule set once, with cardinal and
+// ordinal locale indexes pointing into it.
+type pluralData struct {
+	sets     []map[string]string
+	cardinal map[string]int
+	ordinal  map[string]int
+}
+
+func extractPluralData(supplemental *cldr.SupplementalData) pluralData {
+	data := pluralData{
+		cardinal: make(map[string]int),
+		ordinal:  make(map[string]int),
+	}
+	if supplemental == nil {
+		return data
+	}
+
+	seen := make(map[string]int)
+	for _, plurals := range supplemental.Plurals {
+		if plurals == nil {
+			continue
+		}
+		index := data.cardinal
+		switch plurals.Type {
+		case "", "cardinal":
+		case "ordinal":
+			index = data.ordinal
+		default:
+			continue
+		}
+
+		for _, rules := range plurals.PluralRules {
+			if rules == nil {
+				continue
+			}
+			set := make(map[string]string)
+			for _, rule := range rules.PluralRule {
+				if rule == nil || rule.Count == "" || rule.Count == "other" {
+					continue
+				}
+				condition := rule.Data()
+				if idx := strings.Index(condition, "@"); idx >= 0 {
+					condition = condition[:idx]
+				}
+				set[rule.Count] = strings.TrimSpace(condition)
+			}
+
+			key := pluralSetKey(set)
+			id, ok := seen[key]
+			if !ok {
+				id = len(data.sets)
+				seen[key] = id
+				data.sets = append(data.sets, set)
+			}
+			for _, locale := range strings.Fields(rules.Locales) {
+				if locale == "root" {
+					continue
+				}
+				index[strings.ReplaceAll(locale, "_", "-")] = id
+			}
+		}
+	}
+
+	return data
+}
+
+func pluralSetKey(set map[string]string) string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	for _, key := range keys {
+		b.WriteString(key)
+		b.WriteByte('=')
+		b.WriteString(set[key])
+		b.WriteByte(';')
+	}
+	return b.String()
+}
+
+func renderPluralSource(pkg string, data pluralData) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by i18n-formatters. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+
+	buf.WriteString("// cldrPluralRuleData lists each distinct CLDR rule set once, keyed by\n")
+	buf.WriteString("// category. The \"other\" category is implicit.\n")
+	buf.WriteString("var cldrPluralRuleData = []map[string]string{\n")
+	for i, set := range data.sets {
+		keys := make([]string, 0, len(set))
+		for key := range set {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		fmt.Fprintf(&buf, "\t%d: {", i)
+		for j, key := range keys {
+			if j > 0 {
+				buf.WriteString(", ")
+			}
+			fmt.Fprintf(&buf, "%q: %q", key, set[key])
+		}
+		buf.WriteString("},\n")
+	}
+	buf.WriteString("}\n\n")
+
+	writeIndex := func(name string, index map[string]int) {
+		fmt.Fprintf(&buf, "var %s = map[string]int{\n", name)
+		locales := make([]string, 0, len(index))
+		for locale := range index {
+			locales = append(locales, locale)
+		}
+		sort.Strings(locales)
+		for _, locale := range locales {
+			fmt.Fprintf(&buf, "\t%q: %d,\n", locale, index[locale])
+		}
+		buf.WriteString("}\n")
+	}
+	writeIndex("cldrCardinalRuleIndex", data.cardinal)
+	buf.WriteString("\n")
+	writeIndex("cldrOrdinalRuleIndex", data.ordinal)
+
+	return format.Source(buf.Bytes())
+}
+
 func ensureDir(path string) error {
 	dir := filepath.Dir(path)
 	if dir == "." || dir == "" {
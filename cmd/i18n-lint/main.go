@@ -0,0 +1,171 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/goliatone/go-i18n"
+)
+
+const (
+	exitOK     = 0
+	exitIssues = 1
+	exitFatal  = 2
+)
+
+type lintConfig struct {
+	paths         []string
+	rulePaths     []string
+	defaultLocale string
+	locales       []string
+	format        string
+	failOn        string
+	singleLocale  bool
+}
+
+type listFlag struct {
+	items []string
+}
+
+func (f *listFlag) String() string {
+	return strings.Join(f.items, ",")
+}
+
+func (f *listFlag) Set(value string) error {
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		f.items = append(f.items, part)
+	}
+	return nil
+}
+
+func main() {
+	cfg, err := parseFlags()
+	if err != nil {
+		reportError(err)
+	}
+
+	code, err := run(cfg, os.Stdout)
+	if err != nil {
+		reportError(err)
+	}
+	os.Exit(code)
+}
+
+func reportError(err error) {
+	fmt.Fprintf(os.Stderr, "i18n-lint: %v\n", err)
+	os.Exit(exitFatal)
+}
+
+func parseFlags() (lintConfig, error) {
+	var cfg lintConfig
+	var rules, locales listFlag
+
+	flag.Var(&rules, "rules", "plural rule file used to check plural categories. Repeat flag to add more.")
+	flag.Var(&locales, "locale", "restrict linting to these locales. Repeat flag to add more.")
+	flag.StringVar(&cfg.defaultLocale, "default-locale", "", "reference locale other locales are compared against (defaults to en or the first locale)")
+	flag.StringVar(&cfg.format, "format", "text", "output format: text or json")
+	flag.StringVar(&cfg.failOn, "fail-on", "error", "exit non-zero when issues of this severity are found: error, warning or never")
+	flag.BoolVar(&cfg.singleLocale, "single-locale", false, "read each catalog as one locale named by its file or directory (locales/es.json)")
+
+	flag.Parse()
+
+	cfg.paths = flag.Args()
+	cfg.rulePaths = rules.items
+	cfg.locales = locales.items
+
+	if len(cfg.paths) == 0 {
+		return lintConfig{}, errors.New("at least one catalog file is required")
+	}
+
+	switch cfg.format {
+	case "text", "json":
+	default:
+		return lintConfig{}, fmt.Errorf("unsupported -format %q", cfg.format)
+	}
+
+	switch cfg.failOn {
+	case "error", "warning", "never":
+	default:
+		return lintConfig{}, fmt.Errorf("unsupported -fail-on %q", cfg.failOn)
+	}
+
+	return cfg, nil
+}
+
+func run(cfg lintConfig, w io.Writer) (int, error) {
+	loader := i18n.NewFileLoader(cfg.paths...).WithPluralRuleFiles(cfg.rulePaths...)
+	if cfg.singleLocale {
+		loader.WithSingleLocaleCatalogs()
+	}
+	translations, err := loader.Load()
+	if err != nil {
+		return exitFatal, err
+	}
+
+	opts := []i18n.ValidateOption{i18n.WithValidationDefaultLocale(cfg.defaultLocale)}
+	if len(cfg.locales) > 0 {
+		locales := cfg.locales
+		if cfg.defaultLocale != "" {
+			locales = append(locales, cfg.defaultLocale)
+		}
+		opts = append(opts, i18n.WithValidationLocales(locales...))
+	}
+
+	report := i18n.Validate(translations, opts...)
+
+	switch cfg.format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return exitFatal, err
+		}
+	default:
+		writeText(w, report)
+	}
+
+	return exitCode(cfg.failOn, report), nil
+}
+
+func writeText(w io.Writer, report i18n.ValidationReport) {
+	if len(report.Issues) > 0 {
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		for _, issue := range report.Issues {
+			key := issue.Key
+			if issue.Category != "" {
+				key = fmt.Sprintf("%s[%s]", key, issue.Category)
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", issue.Severity, issue.Locale, key, issue.Kind, issue.Message)
+		}
+		tw.Flush()
+	}
+
+	fmt.Fprintf(w, "%d error(s), %d warning(s) across %d locale(s) (reference %s)\n",
+		report.Count(i18n.SeverityError), report.Count(i18n.SeverityWarning), len(report.Locales), report.DefaultLocale)
+}
+
+func exitCode(failOn string, report i18n.ValidationReport) int {
+	switch failOn {
+	case "never":
+		return exitOK
+	case "warning":
+		if report.Count(i18n.SeverityWarning) > 0 || report.HasErrors() {
+			return exitIssues
+		}
+	default:
+		if report.HasErrors() {
+			return exitIssues
+		}
+	}
+	return exitOK
+}
@@ -1,19 +1,33 @@
 package i18n
 
-import "fmt"
+import (
+	"fmt"
+	"io/fs"
+	"slices"
+)
 
 // Config captures translator and formatter setup
 type Config struct {
-	DefaultLocale       string
-	Locales             []string
-	Loader              Loader
-	Store               Store
-	Resolver            FallbackResolver
-	Formatter           Formatter
-	Hooks               []TranslationHook
-	enablePlural        bool
-	pluralRules         []string
-	seedPluralFallbacks bool
+	DefaultLocale     string
+	Locales           []string
+	Loader            Loader
+	Store             Store
+	Resolver          FallbackResolver
+	Formatter         Formatter
+	Hooks             []TranslationHook
+	enablePlural      bool
+	pluralRules       []string
+	parentFallbacks   bool
+	seedParents       bool
+	defaultFallback   bool
+	fallbackConflicts []FallbackConflict
+	strictArgs        bool
+	noBuiltinRules    bool
+	lookupPolicy      LookupPolicy
+	pseudo            bool
+	pseudoOptions     []PseudoOption
+	cache             bool
+	cacheOptions      []CacheOption
 
 	formatterLocales   []string
 	formatterProviders map[string]FormatterProvider
@@ -21,6 +35,7 @@ type Config struct {
 
 	cultureDataPath  string
 	cultureOverrides map[string]string
+	cultureFS        fs.FS
 	cultureService   CultureService
 	cultureData      *CultureData
 	localeCatalog    *LocaleCatalog
@@ -51,7 +66,12 @@ func NewConfig(opts ...Option) (*Config, error) {
 	}
 
 	cfg.normalizeLocales()
+	// The fallback chain and catalog locales below depend on the default.
+	if cfg.DefaultLocale == "" && len(cfg.Locales) > 0 {
+		cfg.DefaultLocale = cfg.Locales[0]
+	}
 	cfg.applyPluralRuleOptions()
+	cfg.applyCatalogLocales()
 
 	if cfg.Store == nil {
 		if cfg.Loader != nil {
@@ -68,15 +88,12 @@ func NewConfig(opts ...Option) (*Config, error) {
 	if cfg.Resolver == nil {
 		cfg.Resolver = NewStaticFallbackResolver()
 	}
+	cfg.seedResolverFallbacks()
 
 	if cfg.Formatter == nil {
 		cfg.Formatter = FormatterFunc(sprintfFormatter)
 	}
 
-	if cfg.DefaultLocale == "" && len(cfg.Locales) > 0 {
-		cfg.DefaultLocale = cfg.Locales[0]
-	}
-
 	return cfg, nil
 }
 
@@ -191,10 +208,75 @@ func EnablePluralization(rulePaths ...string) Option {
 	}
 }
 
-// EnablePluralFallbackSeeding opts into automatic fallback chain seeding when pluralization is enabled.
+// EnablePluralFallbackSeeding writes locale parent chains into a static Resolver
+// for the store and configured locales, and adds locale parents to the fallback chain.
+//
+// Deprecated: use WithParentFallbacks, which does not depend on EnablePluralization.
 func EnablePluralFallbackSeeding() Option {
 	return func(c *Config) error {
-		c.seedPluralFallbacks = true
+		c.parentFallbacks = true
+		c.seedParents = true
+		return nil
+	}
+}
+
+// WithParentFallbacks adds locale parents (en-US → en) to the fallback chain after declared and catalog chains.
+func WithParentFallbacks() Option {
+	return func(c *Config) error {
+		c.parentFallbacks = true
+		return nil
+	}
+}
+
+// WithDefaultLocaleFallback ends every fallback chain with the default locale, which matters for LookupDeclared where the translator does not add it.
+func WithDefaultLocaleFallback() Option {
+	return func(c *Config) error {
+		c.defaultFallback = true
+		return nil
+	}
+}
+
+// DisableBuiltinPluralRules stops the translator from falling back to the bundled CLDR plural rules when the store has none for a locale.
+func DisableBuiltinPluralRules() Option {
+	return func(c *Config) error {
+		c.noBuiltinRules = true
+		return nil
+	}
+}
+
+// WithLookupPolicy selects which locales the translator searches for a key: LookupImplicit (default), LookupDeclared or LookupStrict.
+func WithLookupPolicy(policy LookupPolicy) Option {
+	return func(c *Config) error {
+		if !policy.valid() {
+			return fmt.Errorf("i18n: unknown lookup policy %q", policy)
+		}
+		c.lookupPolicy = policy
+		return nil
+	}
+}
+
+// WithPseudoLocalization serves the pseudo locales en-XA (accented, expanded) and ar-XB (bidi) from the default locale.
+func WithPseudoLocalization(opts ...PseudoOption) Option {
+	return func(c *Config) error {
+		c.pseudo = true
+		c.pseudoOptions = append(c.pseudoOptions, opts...)
+		return nil
+	}
+}
+
+// WithTranslationCache caches rendered translations (see CachingTranslator); the cache is purged when a ReloadableStore store reloads.
+func WithTranslationCache(opts ...CacheOption) Option {
+	return func(c *Config) error {
+		c.cache = true
+		c.cacheOptions = append(c.cacheOptions, opts...)
+		return nil
+	}
+}
+
+// WithStrictArgs makes translations fail with MissingArgsError when a declared named placeholder is not supplied.
+func WithStrictArgs() Option {
+	return func(c *Config) error {
+		c.strictArgs = true
 		return nil
 	}
 }
@@ -210,6 +292,17 @@ func WithCultureData(path string) Option {
 	}
 }
 
+// WithCultureFS reads culture data and override paths from fsys
+func WithCultureFS(fsys fs.FS) Option {
+	return func(c *Config) error {
+		c.cultureFS = fsys
+		c.cultureService = nil // Invalidate cached service
+		c.cultureData = nil
+		c.localeCatalog = nil
+		return nil
+	}
+}
+
 // WithCultureOverride adds locale-specific culture data override
 func WithCultureOverride(locale, path string) Option {
 	return func(c *Config) error {
@@ -229,23 +322,44 @@ func (cfg *Config) BuildTranslator() (Translator, error) {
 		return nil, ErrNotImplemented
 	}
 
+	// The translator formats counts with the same registry (and fallback
+	// chain) that template helpers use.
+	cfg.ensureFormatterRegistry()
+
 	base, err := NewSimpleTranslator(cfg.Store,
 		WithTranslatorDefaultLocale(cfg.DefaultLocale),
 		WithTranslatorFormatter(cfg.Formatter),
-		WithTranslatorFallbackResolver(cfg.Resolver))
+		WithTranslatorFallbackResolver(cfg.FallbackResolver()),
+		WithTranslatorStrictArgs(cfg.strictArgs),
+		WithTranslatorLookupPolicy(cfg.lookupPolicy),
+		WithTranslatorBuiltinPluralRules(!cfg.noBuiltinRules),
+		WithTranslatorFormatterRegistry(cfg.formatterRegistry))
 	if err != nil {
 		return nil, err
 	}
 
 	var translator Translator = base
 
+	if cfg.pseudo {
+		translator = WrapTranslatorWithPseudoLocales(translator, cfg.pseudoOptions...)
+	}
+
+	if cfg.cache {
+		opts := slices.Clone(cfg.cacheOptions)
+		if store, ok := cfg.Store.(*ReloadableStore); ok {
+			opts = append(opts, WithCacheInvalidation(store))
+		}
+		cached, err := NewCachingTranslator(translator, opts...)
+		if err != nil {
+			return nil, err
+		}
+		translator = cached
+	}
+
 	if len(cfg.Hooks) > 0 {
 		translator = WrapTranslatorWithHooks(translator, cfg.Hooks...)
 	}
 
-	cfg.seedResolverFallbacks()
-	cfg.ensureFormatterRegistry()
-
 	return translator, nil
 }
 
@@ -363,34 +477,57 @@ func (cfg *Config) applyPluralRuleOptions() {
 	}
 }
 
+// applyCatalogLocales lets a FileLoader infer the configured locales from
+// catalog file and directory names. It works on a copy, leaving the caller's
+// loader as it was.
+func (cfg *Config) applyCatalogLocales() {
+	if loader, ok := cfg.Loader.(*FileLoader); ok && loader != nil {
+		cfg.Loader = loader.clone().WithCatalogLocales(append(slices.Clone(cfg.Locales), cfg.DefaultLocale)...)
+	}
+}
+
+// applyCatalogFallbacks records catalog chains that disagree with declared
+// ones. Declared chains win; the catalog link of the fallback chain skips
+// locales the declared resolver already answers. A static Resolver also
+// receives the catalog chains it has no entry for.
 func (cfg *Config) applyCatalogFallbacks(catalog *LocaleCatalog) {
 	if cfg == nil || catalog == nil {
 		return
 	}
 
-	resolver, ok := cfg.Resolver.(*StaticFallbackResolver)
-	if !ok {
-		if cfg.Resolver != nil {
-			return
-		}
-		resolver = NewStaticFallbackResolver()
-		cfg.Resolver = resolver
+	cfg.fallbackConflicts = nil
+	if cfg.Resolver == nil {
+		cfg.Resolver = NewStaticFallbackResolver()
 	}
+	static, _ := cfg.Resolver.(*StaticFallbackResolver)
 
 	for _, locale := range catalog.AllLocaleCodes() {
-		if chain := resolver.Resolve(locale); len(chain) > 0 {
-			continue
-		}
 		fallbacks := catalog.Fallbacks(locale)
 		if len(fallbacks) == 0 {
 			continue
 		}
-		resolver.Set(locale, fallbacks...)
+		declared := cfg.Resolver.Resolve(locale)
+		if len(declared) == 0 {
+			if static != nil {
+				static.Set(locale, fallbacks...)
+			}
+			continue
+		}
+		if slices.Equal(fallbacks, declared) {
+			continue
+		}
+		cfg.fallbackConflicts = append(cfg.fallbackConflicts, FallbackConflict{
+			Locale:   locale,
+			Declared: declared,
+			Catalog:  fallbacks,
+		})
 	}
 }
 
+// seedResolverFallbacks keeps the EnablePluralFallbackSeeding contract: locales
+// without a declared chain get their parents set on a static Resolver.
 func (cfg *Config) seedResolverFallbacks() {
-	if !cfg.enablePlural || !cfg.seedPluralFallbacks {
+	if !cfg.seedParents {
 		return
 	}
 
@@ -399,43 +536,105 @@ func (cfg *Config) seedResolverFallbacks() {
 		return
 	}
 
-	seen := make(map[string]struct{}, len(cfg.Locales))
-	var localeCandidates []string
+	var locales []string
+	if cfg.Store != nil {
+		locales = append(locales, cfg.Store.Locales()...)
+	}
+	locales = append(locales, cfg.Locales...)
 
-	appendCandidate := func(locale string) {
+	seen := make(map[string]struct{}, len(locales))
+	for _, locale := range locales {
 		if locale == "" {
-			return
+			continue
 		}
 		if _, exists := seen[locale]; exists {
-			return
+			continue
 		}
 		seen[locale] = struct{}{}
-		localeCandidates = append(localeCandidates, locale)
+		if existing := resolver.Resolve(locale); existing != nil {
+			continue
+		}
+		if chain := localeParentChain(locale); len(chain) > 0 {
+			resolver.Set(locale, chain...)
+		}
 	}
+}
 
-	if cfg.Store != nil {
-		for _, locale := range cfg.Store.Locales() {
-			appendCandidate(locale)
-		}
+// buildFallbackChain composes the effective resolver: declared chains, then
+// catalog chains, locale parents and the default locale when enabled.
+func (cfg *Config) buildFallbackChain() *FallbackChain {
+	chain := NewFallbackChain()
+	if declared, ok := cfg.Resolver.(*FallbackChain); ok {
+		chain = declared.clone()
+	} else {
+		chain.Add(FallbackSourceDeclared, cfg.Resolver)
+	}
+
+	if catalog := cfg.localeCatalog; catalog != nil {
+		chain.Add(FallbackSourceCatalog, &catalogFallbackResolver{declared: cfg.Resolver, catalog: catalog})
 	}
+	if cfg.parentFallbacks {
+		chain.Add(FallbackSourceParent, ParentFallbackResolver())
+	}
+	if cfg.defaultFallback {
+		chain.Add(FallbackSourceDefault, DefaultLocaleFallback(cfg.DefaultLocale))
+	}
+
+	return chain
+}
 
-	for _, locale := range cfg.Locales {
-		appendCandidate(locale)
+// catalogFallbackResolver serves culture data chains for locales the declared
+// resolver has no chain for.
+type catalogFallbackResolver struct {
+	declared FallbackResolver
+	catalog  *LocaleCatalog
+}
+
+func (r *catalogFallbackResolver) Resolve(locale string) []string {
+	if r.declared != nil && len(r.declared.Resolve(locale)) > 0 {
+		return nil
 	}
+	return r.catalog.Fallbacks(locale)
+}
 
-	for _, locale := range localeCandidates {
-		if locale == "" {
-			continue
-		}
-		if existing := resolver.Resolve(locale); existing != nil {
-			continue
-		}
-		chain := localeParentChain(locale)
-		if len(chain) == 0 {
-			continue
-		}
-		resolver.Set(locale, chain...)
+func (r *catalogFallbackResolver) fallbackVersion() (uint64, bool) {
+	return resolverVersion(r.declared)
+}
+
+// FallbackResolver returns the effective fallback chain built from declared
+// chains (Resolver), culture data catalog chains, locale parents and the
+// default locale tail. The chain is composed from the current Resolver on
+// every call.
+func (cfg *Config) FallbackResolver() FallbackResolver {
+	if cfg == nil {
+		return nil
+	}
+	return cfg.buildFallbackChain()
+}
+
+// ExplainFallback lists the locales the translator searches for locale under
+// the configured lookup policy and where each hop came from.
+func (cfg *Config) ExplainFallback(locale string) []FallbackHop {
+	if cfg == nil {
+		return nil
+	}
+	if locale == "" {
+		locale = cfg.DefaultLocale
+	}
+	policy := cfg.lookupPolicy
+	if policy == "" {
+		policy = LookupImplicit
+	}
+	return explainLookup(normalizeLocale(locale), cfg.DefaultLocale, policy, cfg.FallbackResolver())
+}
+
+// FallbackConflicts reports locales whose declared fallback chain differs
+// from the one in culture data. The declared chain is used.
+func (cfg *Config) FallbackConflicts() []FallbackConflict {
+	if cfg == nil || len(cfg.fallbackConflicts) == 0 {
+		return nil
 	}
+	return append([]FallbackConflict(nil), cfg.fallbackConflicts...)
 }
 
 func (cfg *Config) ensureFormatterRegistry() {
@@ -459,10 +658,12 @@ func (cfg *Config) ensureFormatterRegistry() {
 		cultureData = &CultureData{}
 	}
 
-	rulesProvider := NewFormattingRulesProvider(cultureData, cfg.Resolver)
+	// Formatters always fall back through locale parents.
+	resolver := cfg.buildFallbackChain().Add(FallbackSourceParent, ParentFallbackResolver())
+	rulesProvider := NewFormattingRulesProvider(cultureData, resolver)
 
 	options := []FormatterRegistryOption{
-		WithFormatterRegistryResolver(cfg.Resolver),
+		WithFormatterRegistryResolver(resolver),
 		WithFormatterRegistryLocales(locales...),
 		WithFormattingRulesProvider(rulesProvider),
 	}
@@ -487,11 +688,11 @@ func (cfg *Config) ensureCultureService() {
 	data, err := cfg.loadCultureData()
 	if err != nil {
 		// Log error but don't fail - use empty service
-		cfg.cultureService = NewCultureService(&CultureData{}, cfg.Resolver)
+		cfg.cultureService = NewCultureService(&CultureData{}, cfg.FallbackResolver())
 		return
 	}
 
-	cfg.cultureService = NewCultureService(data, cfg.Resolver)
+	cfg.cultureService = NewCultureService(data, cfg.FallbackResolver())
 }
 
 func (cfg *Config) loadCultureData() (*CultureData, error) {
@@ -516,7 +717,7 @@ func (cfg *Config) loadCultureData() (*CultureData, error) {
 }
 
 func (cfg *Config) newCultureDataLoader() *CultureDataLoader {
-	loader := NewCultureDataLoader(cfg.cultureDataPath)
+	loader := NewCultureDataLoaderFS(cfg.cultureFS, cfg.cultureDataPath)
 	for locale, path := range cfg.cultureOverrides {
 		loader.AddOverride(locale, path)
 	}
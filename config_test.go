@@ -1,10 +1,14 @@
 package i18n
 
 import (
+	"errors"
+	"math/big"
 	"os"
 	"path/filepath"
 	"reflect"
+	"strconv"
 	"testing"
+	"testing/fstest"
 )
 
 func TestNewConfigDefaults(t *testing.T) {
@@ -203,6 +207,31 @@ func TestEnablePluralizationAddsRuleFiles(t *testing.T) {
 	}
 }
 
+func TestConfigCatalogLocalesLeaveLoaderUntouched(t *testing.T) {
+	fsys := fstest.MapFS{
+		"locales/tlh.json": {Data: []byte(`{"home.title": "nuqneH"}`)},
+	}
+	loader := NewFSLoader(fsys, "locales").WithSingleLocaleCatalogs()
+
+	cfg, err := NewConfig(WithLoader(loader), WithLocales("tlh"))
+	if err != nil {
+		t.Fatalf("NewConfig: %v", err)
+	}
+	if locales := cfg.Store.Locales(); !reflect.DeepEqual(locales, []string{"tlh"}) {
+		t.Fatalf("store locales = %v, want [tlh]", locales)
+	}
+
+	if cfg.Loader == Loader(loader) {
+		t.Fatal("expected NewConfig to wire a copy of the loader")
+	}
+	if loader.locales.known != nil {
+		t.Fatalf("caller loader locales = %v, want none", loader.locales.known)
+	}
+	if _, err := loader.Load(); err == nil {
+		t.Fatal("expected caller loader to still reject tlh.json")
+	}
+}
+
 func TestEnablePluralizationDoesNotSeedFallbacksByDefault(t *testing.T) {
 	rulePath := filepath.Join("testdata", "cldr_cardinal.json")
 	loader := NewFileLoader(filepath.Join("testdata", "loader_en.json"))
@@ -670,3 +699,275 @@ func TestConfig_LocaleCatalogWithLocalesOption(t *testing.T) {
 func writeTestFile(path string, data []byte) error {
 	return os.WriteFile(path, data, 0644)
 }
+
+func TestDisableBuiltinPluralRules(t *testing.T) {
+	cfg, err := NewConfig(WithDefaultLocale("en"), DisableBuiltinPluralRules())
+	if err != nil {
+		t.Fatalf("NewConfig: %v", err)
+	}
+
+	translator, err := cfg.BuildTranslator()
+	if err != nil {
+		t.Fatalf("BuildTranslator: %v", err)
+	}
+	if rules := translator.(*SimpleTranslator).ruleSetFor("fr"); rules != nil {
+		t.Fatalf("expected no plural rules, got %v", rules.Categories())
+	}
+
+	cfg, err = NewConfig(WithDefaultLocale("en"))
+	if err != nil {
+		t.Fatalf("NewConfig: %v", err)
+	}
+	translator, err = cfg.BuildTranslator()
+	if err != nil {
+		t.Fatalf("BuildTranslator: %v", err)
+	}
+	if rules := translator.(*SimpleTranslator).ruleSetFor("fr"); rules == nil || len(rules.Categories()) != 3 {
+		t.Fatal("expected built-in fr rules")
+	}
+}
+
+func TestWithLookupPolicy(t *testing.T) {
+	cfg, err := NewConfig(WithDefaultLocale("en"), WithStore(newLookupPolicyStore()), WithLookupPolicy(LookupStrict))
+	if err != nil {
+		t.Fatalf("NewConfig: %v", err)
+	}
+
+	translator, err := cfg.BuildTranslator()
+	if err != nil {
+		t.Fatalf("BuildTranslator: %v", err)
+	}
+	if _, err := translator.Translate("es-MX", "home.title"); !errors.Is(err, ErrMissingTranslation) {
+		t.Fatalf("expected ErrMissingTranslation under strict lookup, got %v", err)
+	}
+	if got, err := translator.Translate("es", "home.title"); err != nil || got != "Bienvenido" {
+		t.Fatalf("Translate(es) = %q, %v", got, err)
+	}
+
+	if _, err := NewConfig(WithLookupPolicy("loose")); err == nil {
+		t.Fatal("expected error for unknown lookup policy")
+	}
+}
+
+func TestConfigFallbackPolicy(t *testing.T) {
+	cultureFile := filepath.Join(t.TempDir(), "culture.json")
+	if err := writeTestFile(cultureFile, []byte(`{
+		"default_locale": "en",
+		"locales": {
+			"en": {"active": true},
+			"fr": {"active": true},
+			"fr-CA": {"active": true, "fallbacks": ["fr"]},
+			"pt-BR": {"active": true, "fallbacks": ["pt"]},
+			"pt": {"active": true}
+		}
+	}`)); err != nil {
+		t.Fatalf("write culture file: %v", err)
+	}
+
+	declared := FallbackResolverFunc(func(locale string) []string {
+		if locale == "pt-BR" {
+			return []string{"es"}
+		}
+		return nil
+	})
+
+	cfg, err := NewConfig(
+		WithCultureData(cultureFile),
+		WithFallbackResolver(declared),
+		WithParentFallbacks(),
+		WithDefaultLocaleFallback(),
+		WithLookupPolicy(LookupDeclared),
+	)
+	if err != nil {
+		t.Fatalf("NewConfig: %v", err)
+	}
+
+	want := []FallbackHop{
+		{Locale: "fr-CA", Source: FallbackSourceRequested},
+		{Locale: "fr", Source: FallbackSourceCatalog},
+		{Locale: "en", Source: FallbackSourceDefault},
+	}
+	if got := cfg.ExplainFallback("fr-CA"); !reflect.DeepEqual(got, want) {
+		t.Fatalf("ExplainFallback(fr-CA) = %v want %v", got, want)
+	}
+
+	want = []FallbackHop{
+		{Locale: "pt-BR", Source: FallbackSourceRequested},
+		{Locale: "es", Source: FallbackSourceDeclared},
+		{Locale: "pt", Source: FallbackSourceParent},
+		{Locale: "en", Source: FallbackSourceDefault},
+	}
+	if got := cfg.ExplainFallback("pt-BR"); !reflect.DeepEqual(got, want) {
+		t.Fatalf("ExplainFallback(pt-BR) = %v want %v", got, want)
+	}
+
+	conflicts := cfg.FallbackConflicts()
+	wantConflicts := []FallbackConflict{{Locale: "pt-BR", Declared: []string{"es"}, Catalog: []string{"pt"}}}
+	if !reflect.DeepEqual(conflicts, wantConflicts) {
+		t.Fatalf("FallbackConflicts = %#v want %#v", conflicts, wantConflicts)
+	}
+
+	translator, err := cfg.BuildTranslator()
+	if err != nil {
+		t.Fatalf("BuildTranslator: %v", err)
+	}
+	if got := translator.(*SimpleTranslator).ExplainFallback("fr-CA"); !reflect.DeepEqual(got, cfg.ExplainFallback("fr-CA")) {
+		t.Fatalf("translator ExplainFallback = %v", got)
+	}
+}
+
+func TestConfigResolverReassignedAfterNewConfig(t *testing.T) {
+	cfg, err := NewConfig(WithDefaultLocale("en"), WithStore(newLookupPolicyStore()), WithLookupPolicy(LookupDeclared))
+	if err != nil {
+		t.Fatalf("NewConfig: %v", err)
+	}
+
+	resolver := NewStaticFallbackResolver()
+	resolver.Set("es-MX", "pt")
+	cfg.Resolver = resolver
+
+	translator, err := cfg.BuildTranslator()
+	if err != nil {
+		t.Fatalf("BuildTranslator: %v", err)
+	}
+	if got, err := translator.Translate("es-MX", "cta.buy"); err != nil || got != "Comprar" {
+		t.Fatalf("Translate(es-MX) = %q, %v want Comprar", got, err)
+	}
+
+	want := []FallbackHop{
+		{Locale: "es-MX", Source: FallbackSourceRequested},
+		{Locale: "pt", Source: FallbackSourceDeclared},
+	}
+	if got := cfg.ExplainFallback("es-MX"); !reflect.DeepEqual(got, want) {
+		t.Fatalf("ExplainFallback(es-MX) = %v want %v", got, want)
+	}
+}
+
+func TestConfigDefaultLocaleFallbackFromLocales(t *testing.T) {
+	store := NewStaticStore(Translations{
+		"en": newStringCatalog("en", map[string]string{"home.title": "Welcome"}),
+		"es": newStringCatalog("es", map[string]string{}),
+	})
+	cfg, err := NewConfig(
+		WithStore(store),
+		WithLocales("en", "es"),
+		WithDefaultLocaleFallback(),
+		WithLookupPolicy(LookupDeclared),
+	)
+	if err != nil {
+		t.Fatalf("NewConfig: %v", err)
+	}
+	if cfg.DefaultLocale != "en" {
+		t.Fatalf("DefaultLocale = %q", cfg.DefaultLocale)
+	}
+
+	want := []FallbackHop{
+		{Locale: "es", Source: FallbackSourceRequested},
+		{Locale: "en", Source: FallbackSourceDefault},
+	}
+	if got := cfg.ExplainFallback("es"); !reflect.DeepEqual(got, want) {
+		t.Fatalf("ExplainFallback(es) = %v want %v", got, want)
+	}
+
+	translator, err := cfg.BuildTranslator()
+	if err != nil {
+		t.Fatalf("BuildTranslator: %v", err)
+	}
+	if got, err := translator.Translate("es", "home.title"); err != nil || got != "Welcome" {
+		t.Fatalf("Translate(es) = %q, %v", got, err)
+	}
+}
+
+func TestConfigTranslatorFormatsCount(t *testing.T) {
+	store := NewStaticStore(Translations{
+		"en":    newStringCatalog("en", map[string]string{"orders": "{count} orders"}),
+		"es-MX": newStringCatalog("es-MX", map[string]string{"orders": "{count} pedidos"}),
+	})
+
+	cfg, err := NewConfig(
+		WithStore(store),
+		WithDefaultLocale("en"),
+		WithLocales("en", "es-MX"),
+		WithFormatterProvider("es", func(string) map[string]any {
+			return map[string]any{
+				"format_number": func(_ string, value float64, decimals int) string {
+					return "es:" + strconv.FormatFloat(value, 'f', decimals, 64)
+				},
+			}
+		}),
+	)
+	if err != nil {
+		t.Fatalf("NewConfig: %v", err)
+	}
+
+	translator, err := cfg.BuildTranslator()
+	if err != nil {
+		t.Fatalf("BuildTranslator: %v", err)
+	}
+
+	if got, err := translator.Translate("en", "orders", WithCount(12500)); err != nil || got != "12,500 orders" {
+		t.Fatalf("Translate(en) = %q, %v", got, err)
+	}
+	// es-MX has no formatter of its own and resolves to es through the
+	// same fallback chain as the registry.
+	if got, err := translator.Translate("es-MX", "orders", WithCount(12500)); err != nil || got != "es:12500 pedidos" {
+		t.Fatalf("Translate(es-MX) = %q, %v", got, err)
+	}
+}
+
+func TestConfigTranslatesArbitraryPrecisionCounts(t *testing.T) {
+	path := writeTempFile(t, t.TempDir(), "en.json", []byte(`{
+  "stars": {"=12345678901234567": "exactly {count} stars", "one": "{count} star", "other": "{count} stars"},
+  "icu.stars": "{count, plural, offset:1 =12345678901234567 {exactly {count, number} stars} one {you and # other} other {you and # others}}"
+}`))
+
+	build := func(opts ...Option) Translator {
+		t.Helper()
+		cfg, err := NewConfig(append([]Option{WithLoader(NewFileLoader(path).WithSingleLocaleCatalogs()), WithDefaultLocale("en")}, opts...)...)
+		if err != nil {
+			t.Fatalf("NewConfig: %v", err)
+		}
+		translator, err := cfg.BuildTranslator()
+		if err != nil {
+			t.Fatalf("BuildTranslator: %v", err)
+		}
+		return translator
+	}
+	plain := build()
+	icu := build(WithFormatter(NewICUFormatter()))
+
+	bigInt := func(s string) *big.Int {
+		v, _ := new(big.Int).SetString(s, 10)
+		return v
+	}
+	bigFloat, _, err := big.ParseFloat("12345678901234567.25", 10, 200, big.ToNearestEven)
+	if err != nil {
+		t.Fatalf("ParseFloat: %v", err)
+	}
+
+	tests := []struct {
+		translator Translator
+		key        string
+		count      any
+		want       string
+	}{
+		{plain, "stars", bigInt("12345678901234567"), "exactly 12,345,678,901,234,567 stars"},
+		{plain, "stars", bigInt("12345678901234568"), "12,345,678,901,234,568 stars"},
+		{plain, "stars", bigFloat, "12,345,678,901,234,567.25 stars"},
+		{plain, "stars", *bigFloat, "12,345,678,901,234,567.25 stars"},
+		{icu, "icu.stars", bigInt("12345678901234567"), "exactly 12,345,678,901,234,567 stars"},
+		{icu, "icu.stars", bigInt("12345678901234568"), "you and 12,345,678,901,234,567 others"},
+		{icu, "icu.stars", bigInt("12345678901234571"), "you and 12,345,678,901,234,570 others"},
+		{icu, "icu.stars", bigFloat, "you and 12,345,678,901,234,566.25 others"},
+		{icu, "icu.stars", bigInt("2"), "you and 1 other"},
+	}
+	for _, tc := range tests {
+		got, err := tc.translator.Translate("en", tc.key, WithCount(tc.count))
+		if err != nil {
+			t.Fatalf("%s(%v): %v", tc.key, tc.count, err)
+		}
+		if got != tc.want {
+			t.Fatalf("%s(%v) = %q want %q", tc.key, tc.count, got, tc.want)
+		}
+	}
+}
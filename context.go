@@ -0,0 +1,217 @@
+package i18n
+
+import "context"
+
+// ContextTranslator resolves translations using request scoped state carried
+// on a context.Context (locale, count and translate options).
+type ContextTranslator interface {
+	TranslateContext(ctx context.Context, key string, args ...any) (string, error)
+}
+
+type contextKey int
+
+const (
+	localeContextKey contextKey = iota
+	countContextKey
+	optionsContextKey
+)
+
+// ContextWithLocale returns a copy of ctx carrying the requested locale.
+func ContextWithLocale(ctx context.Context, locale string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, localeContextKey, locale)
+}
+
+// LocaleFromContext returns the locale stored by ContextWithLocale.
+func LocaleFromContext(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	locale, ok := ctx.Value(localeContextKey).(string)
+	return locale, ok && locale != ""
+}
+
+// ContextWithCount returns a copy of ctx carrying a plural count, equivalent to
+// passing WithCount on every contextual translation.
+func ContextWithCount(ctx context.Context, count any) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return context.WithValue(ctx, countContextKey, count)
+}
+
+// CountFromContext returns the count stored by ContextWithCount.
+func CountFromContext(ctx context.Context) (any, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	count := ctx.Value(countContextKey)
+	return count, count != nil
+}
+
+// ContextWithOptions returns a copy of ctx carrying translate options (e.g.
+// WithArgs) applied before the call site arguments. Options accumulate across
+// nested contexts.
+func ContextWithOptions(ctx context.Context, opts ...TranslateOption) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if len(opts) == 0 {
+		return ctx
+	}
+	existing := OptionsFromContext(ctx)
+	merged := make([]TranslateOption, 0, len(existing)+len(opts))
+	merged = append(merged, existing...)
+	for _, opt := range opts {
+		if opt != nil {
+			merged = append(merged, opt)
+		}
+	}
+	return context.WithValue(ctx, optionsContextKey, merged)
+}
+
+// OptionsFromContext returns the translate options stored by ContextWithOptions.
+func OptionsFromContext(ctx context.Context) []TranslateOption {
+	if ctx == nil {
+		return nil
+	}
+	opts, _ := ctx.Value(optionsContextKey).([]TranslateOption)
+	return opts
+}
+
+// contextArgs prepends the context options and count to args so explicit call
+// site arguments take precedence.
+func contextArgs(ctx context.Context, args []any) []any {
+	opts := OptionsFromContext(ctx)
+	count, hasCount := CountFromContext(ctx)
+	if len(opts) == 0 && !hasCount {
+		return args
+	}
+
+	merged := make([]any, 0, len(opts)+len(args)+1)
+	for _, opt := range opts {
+		merged = append(merged, opt)
+	}
+	if hasCount {
+		merged = append(merged, WithCount(count))
+	}
+	return append(merged, args...)
+}
+
+// contextMetadataTranslator is implemented by translators that take the
+// request context and the resolved locale together, returning metadata when
+// withMetadata is set. Decorators use it so ctx and metadata both reach the
+// translator they wrap.
+type contextMetadataTranslator interface {
+	translateContext(ctx context.Context, locale, key string, args []any, withMetadata bool) (string, map[string]any, error)
+}
+
+// translateNext translates through next with ctx, preferring the path that
+// keeps both the context and the metadata. args already carry the context
+// count and options.
+func translateNext(ctx context.Context, next Translator, locale, key string, args []any, withMetadata bool) (string, map[string]any, error) {
+	if ct, ok := next.(contextMetadataTranslator); ok {
+		return ct.translateContext(ctx, locale, key, args, withMetadata)
+	}
+	if mt, ok := next.(metadataTranslator); ok && withMetadata {
+		return mt.TranslateWithMetadata(locale, key, args...)
+	}
+	if ct, ok := next.(ContextTranslator); ok {
+		result, err := ct.TranslateContext(scopedContext(ctx, locale), key, args...)
+		return result, nil, err
+	}
+	result, err := next.Translate(locale, key, args...)
+	return result, nil, err
+}
+
+// scopedContext returns ctx carrying locale and no count or options, which
+// have already been merged into the call arguments.
+func scopedContext(ctx context.Context, locale string) context.Context {
+	if current, _ := LocaleFromContext(ctx); current != locale {
+		ctx = ContextWithLocale(ctx, locale)
+	}
+	if _, ok := CountFromContext(ctx); ok {
+		ctx = context.WithValue(ctx, countContextKey, nil)
+	}
+	if len(OptionsFromContext(ctx)) > 0 {
+		ctx = context.WithValue(ctx, optionsContextKey, []TranslateOption(nil))
+	}
+	return ctx
+}
+
+func contextLocale(ctx context.Context, fallback any) string {
+	if locale, ok := LocaleFromContext(ctx); ok {
+		return locale
+	}
+	if provider, ok := fallback.(defaultLocaleProvider); ok {
+		return provider.DefaultLocale()
+	}
+	return ""
+}
+
+// AsContextTranslator adapts a Translator into a ContextTranslator. Translators
+// that already implement ContextTranslator are returned unchanged.
+func AsContextTranslator(t Translator) ContextTranslator {
+	if t == nil {
+		return nil
+	}
+	if ct, ok := t.(ContextTranslator); ok {
+		return ct
+	}
+	return contextTranslatorAdapter{next: t}
+}
+
+type contextTranslatorAdapter struct {
+	next Translator
+}
+
+func (a contextTranslatorAdapter) TranslateContext(ctx context.Context, key string, args ...any) (string, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return a.next.Translate(contextLocale(ctx, a.next), key, contextArgs(ctx, args)...)
+}
+
+func (a contextTranslatorAdapter) DefaultLocale() string {
+	if provider, ok := a.next.(defaultLocaleProvider); ok {
+		return provider.DefaultLocale()
+	}
+	return ""
+}
+
+// AsTranslator adapts a ContextTranslator into a Translator by carrying the
+// locale argument on a background context. Values that already implement
+// Translator are returned unchanged.
+func AsTranslator(ct ContextTranslator) Translator {
+	if ct == nil {
+		return nil
+	}
+	if t, ok := ct.(Translator); ok {
+		return t
+	}
+	return translatorAdapter{next: ct}
+}
+
+type translatorAdapter struct {
+	next ContextTranslator
+}
+
+func (a translatorAdapter) Translate(locale, key string, args ...any) (string, error) {
+	ctx := context.Background()
+	if locale != "" {
+		ctx = ContextWithLocale(ctx, locale)
+	}
+	return a.next.TranslateContext(ctx, key, args...)
+}
+
+func (a translatorAdapter) DefaultLocale() string {
+	if provider, ok := a.next.(defaultLocaleProvider); ok {
+		return provider.DefaultLocale()
+	}
+	return ""
+}
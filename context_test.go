@@ -0,0 +1,301 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func newContextTestTranslator(t *testing.T) *SimpleTranslator {
+	t.Helper()
+
+	catalog := newStringCatalog("en", map[string]string{
+		"home.title":    "Welcome",
+		"home.greeting": "Hello %s",
+	})
+	catalog.Messages["cart.items"] = Message{
+		MessageMetadata: MessageMetadata{ID: "cart.items", Locale: "en"},
+		Variants: map[PluralCategory]MessageVariant{
+			PluralOne:   {Template: "{count} item for {name}"},
+			PluralOther: {Template: "{count} items for {name}"},
+		},
+	}
+	catalog.CardinalRules = loadTestPluralRules(t)["en"]
+
+	store := NewStaticStore(Translations{
+		"en": catalog,
+		"es": newStringCatalog("es", map[string]string{
+			"home.title": "Bienvenido",
+		}),
+	})
+
+	translator, err := NewSimpleTranslator(store, WithTranslatorDefaultLocale("en"))
+	if err != nil {
+		t.Fatalf("NewSimpleTranslator: %v", err)
+	}
+	return translator
+}
+
+func TestSimpleTranslatorTranslateContext(t *testing.T) {
+	translator := newContextTestTranslator(t)
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		key  string
+		args []any
+		want string
+	}{
+		{
+			name: "default locale",
+			ctx:  context.Background(),
+			key:  "home.title",
+			want: "Welcome",
+		},
+		{
+			name: "locale from context",
+			ctx:  ContextWithLocale(context.Background(), "es"),
+			key:  "home.title",
+			want: "Bienvenido",
+		},
+		{
+			name: "positional args",
+			ctx:  ContextWithLocale(context.Background(), "en"),
+			key:  "home.greeting",
+			args: []any{"Ana"},
+			want: "Hello Ana",
+		},
+		{
+			name: "count and options from context",
+			ctx:  ContextWithOptions(ContextWithCount(context.Background(), 1), WithArg("name", "Ana")),
+			key:  "cart.items",
+			want: "1 item for Ana",
+		},
+		{
+			name: "explicit args override context",
+			ctx:  ContextWithOptions(ContextWithCount(context.Background(), 1), WithArg("name", "Ana")),
+			key:  "cart.items",
+			args: []any{WithCount(4), WithArg("name", "Luis")},
+			want: "4 items for Luis",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := translator.TranslateContext(tc.ctx, tc.key, tc.args...)
+			if err != nil {
+				t.Fatalf("TranslateContext: %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("TranslateContext() = %q want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTranslateContextCancelled(t *testing.T) {
+	translator := newContextTestTranslator(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := translator.TranslateContext(ctx, "home.title"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+
+	hooked := WrapTranslatorWithHooks(translator, TranslationHookFuncs{}).(ContextTranslator)
+	if _, err := hooked.TranslateContext(ctx, "home.title"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled from hooks, got %v", err)
+	}
+}
+
+func TestHookedTranslatorReceivesContext(t *testing.T) {
+	type traceKey struct{}
+
+	var (
+		seenTrace  any
+		seenLocale string
+		plainCtx   context.Context
+	)
+
+	hook := TranslationHookFuncs{
+		Before: func(ctx *TranslatorHookContext) {
+			seenTrace = ctx.Context.Value(traceKey{})
+			seenLocale = ctx.Locale
+			plainCtx = ctx.Context
+		},
+	}
+
+	translator := WrapTranslatorWithHooks(newContextTestTranslator(t), hook)
+	ct := AsContextTranslator(translator)
+	if _, ok := ct.(*HookedTranslator); !ok {
+		t.Fatalf("expected HookedTranslator to implement ContextTranslator natively")
+	}
+
+	ctx := ContextWithLocale(context.WithValue(context.Background(), traceKey{}, "span-1"), "es")
+	got, err := ct.TranslateContext(ctx, "home.title")
+	if err != nil {
+		t.Fatalf("TranslateContext: %v", err)
+	}
+	if got != "Bienvenido" {
+		t.Fatalf("TranslateContext() = %q", got)
+	}
+	if seenTrace != "span-1" || seenLocale != "es" {
+		t.Fatalf("hook saw trace=%v locale=%q", seenTrace, seenLocale)
+	}
+
+	if _, err := translator.Translate("en", "home.title"); err != nil {
+		t.Fatalf("Translate: %v", err)
+	}
+	if plainCtx == nil {
+		t.Fatalf("expected background context for plain Translate calls")
+	}
+}
+
+type contextRecorder struct {
+	ctx  context.Context
+	args []any
+}
+
+func (r *contextRecorder) Translate(locale, key string, args ...any) (string, error) {
+	return r.TranslateContext(ContextWithLocale(context.Background(), locale), key, args...)
+}
+
+func (r *contextRecorder) TranslateContext(ctx context.Context, key string, args ...any) (string, error) {
+	r.ctx = ctx
+	r.args = contextArgs(ctx, args)
+	locale, _ := LocaleFromContext(ctx)
+	return locale + ":" + key, nil
+}
+
+func TestNestedHookedTranslatorsForwardContext(t *testing.T) {
+	type traceKey struct{}
+
+	var outerCtx, innerCtx context.Context
+	var innerPlural PluralHookMetadata
+	inner := WrapTranslatorWithHooks(newContextTestTranslator(t), TranslationHookFuncs{
+		Before: func(ctx *TranslatorHookContext) { innerCtx = ctx.Context },
+	})
+	outer := WrapTranslatorWithHooks(inner, TranslationHookFuncs{
+		Before: func(ctx *TranslatorHookContext) { outerCtx = ctx.Context },
+		After:  func(ctx *TranslatorHookContext) { innerPlural, _ = ctx.PluralMetadata() },
+	}).(ContextTranslator)
+
+	ctx := ContextWithOptions(ContextWithLocale(context.WithValue(context.Background(), traceKey{}, "span-1"), "en"), WithArg("name", "Ana"))
+	got, err := outer.TranslateContext(ctx, "cart.items", WithCount(1))
+	if err != nil || got != "1 item for Ana" {
+		t.Fatalf("TranslateContext = %q, %v", got, err)
+	}
+	if outerCtx != ctx || innerCtx != ctx {
+		t.Fatalf("inner hook did not receive the request context")
+	}
+	if innerPlural.Category != PluralOne {
+		t.Fatalf("outer hook lost metadata from the inner layer: %+v", innerPlural)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := outer.TranslateContext(cancelled, "home.title"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+
+	recorder := &contextRecorder{}
+	hooked := WrapTranslatorWithHooks(recorder, TranslationHookFuncs{
+		Before: func(ctx *TranslatorHookContext) { ctx.Locale = "es" },
+	}).(ContextTranslator)
+	if got, _ := hooked.TranslateContext(ctx, "home.title", "x"); got != "es:home.title" {
+		t.Fatalf("hooked ContextTranslator = %q", got)
+	}
+	if recorder.ctx.Value(traceKey{}) != "span-1" {
+		t.Fatalf("ContextTranslator below the hooks did not receive ctx values")
+	}
+	if len(recorder.args) != 2 {
+		t.Fatalf("context options must reach the wrapped translator once, got %v", recorder.args)
+	}
+}
+
+func TestPseudoTranslatorForwardsContext(t *testing.T) {
+	type traceKey struct{}
+	ctx := ContextWithLocale(context.WithValue(context.Background(), traceKey{}, "span-1"), PseudoLocaleAccented)
+
+	var hookCtx context.Context
+	var hookLocale string
+	hooked := WrapTranslatorWithHooks(newContextTestTranslator(t), TranslationHookFuncs{
+		Before: func(ctx *TranslatorHookContext) { hookCtx, hookLocale = ctx.Context, ctx.Locale },
+	})
+	pseudo := WrapTranslatorWithPseudoLocales(hooked, WithPseudoExpansion(0)).(ContextTranslator)
+	if got, err := pseudo.TranslateContext(ctx, "home.title"); err != nil || got != "[Ŵéļçöɱé]" {
+		t.Fatalf("TranslateContext = %q, %v", got, err)
+	}
+	if hookCtx != ctx || hookLocale != "en" {
+		t.Fatalf("hooks below the pseudo translator got locale %q and ctx %v", hookLocale, hookCtx)
+	}
+
+	recorder := &contextRecorder{}
+	pseudo = WrapTranslatorWithPseudoLocales(recorder, WithPseudoSourceLocale("en"), WithPseudoExpansion(0), WithPseudoMarkers("", "")).(ContextTranslator)
+	if got, _ := pseudo.TranslateContext(ContextWithCount(ctx, 2), "home.title"); got != "éñ:ĥöɱé.ţîţļé" {
+		t.Fatalf("pseudo ContextTranslator = %q", got)
+	}
+	if recorder.ctx.Value(traceKey{}) != "span-1" {
+		t.Fatalf("ContextTranslator below the pseudo translator did not receive ctx values")
+	}
+	if len(recorder.args) != 1 {
+		t.Fatalf("context count must reach the wrapped translator once, got %v", recorder.args)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := pseudo.TranslateContext(cancelled, "home.title"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+type plainTranslator struct {
+	locale string
+	args   []any
+}
+
+func (p *plainTranslator) Translate(locale, key string, args ...any) (string, error) {
+	p.locale = locale
+	p.args = args
+	return locale + ":" + key, nil
+}
+
+func (p *plainTranslator) DefaultLocale() string { return "fr" }
+
+type contextOnlyTranslator struct{}
+
+func (contextOnlyTranslator) TranslateContext(ctx context.Context, key string, _ ...any) (string, error) {
+	locale, _ := LocaleFromContext(ctx)
+	return locale + ":" + key, nil
+}
+
+func TestContextTranslatorAdapters(t *testing.T) {
+	plain := &plainTranslator{}
+	ct := AsContextTranslator(plain)
+
+	got, err := ct.TranslateContext(context.Background(), "home.title")
+	if err != nil {
+		t.Fatalf("TranslateContext: %v", err)
+	}
+	if got != "fr:home.title" {
+		t.Fatalf("adapter should fall back to DefaultLocale, got %q", got)
+	}
+
+	ctx := ContextWithCount(ContextWithLocale(context.Background(), "es"), 2)
+	if got, _ := ct.TranslateContext(ctx, "home.title", "x"); got != "es:home.title" {
+		t.Fatalf("adapter locale = %q", got)
+	}
+	if len(plain.args) != 2 || plain.args[1] != "x" {
+		t.Fatalf("adapter should prepend context count, got %v", plain.args)
+	}
+
+	translator := AsTranslator(contextOnlyTranslator{})
+	if got, _ := translator.Translate("el", "home.title"); got != "el:home.title" {
+		t.Fatalf("AsTranslator locale = %q", got)
+	}
+
+	simple := newContextTestTranslator(t)
+	if AsTranslator(simple) != Translator(simple) {
+		t.Fatalf("AsTranslator should return translators unchanged")
+	}
+}
@@ -4,8 +4,8 @@ import (
 	_ "embed"
 	"encoding/json"
 	"fmt"
+	"io/fs"
 	"maps"
-	"os"
 )
 
 //go:embed testdata/default_formatting_rules.json
@@ -15,6 +15,7 @@ var defaultFormattingRulesJSON []byte
 type CultureDataLoader struct {
 	defaultPath string
 	overrides   map[string]string
+	fsys        fs.FS
 }
 
 // NewCultureDataLoader creates a loader
@@ -25,6 +26,14 @@ func NewCultureDataLoader(defaultPath string) *CultureDataLoader {
 	}
 }
 
+// NewCultureDataLoaderFS creates a loader that reads the culture data file and
+// any overrides from fsys
+func NewCultureDataLoaderFS(fsys fs.FS, defaultPath string) *CultureDataLoader {
+	loader := NewCultureDataLoader(defaultPath)
+	loader.fsys = fsys
+	return loader
+}
+
 // Load reads culture data from JSON files
 func (l *CultureDataLoader) Load() (*CultureData, error) {
 	// Start with embedded default formatting rules
@@ -35,7 +44,7 @@ func (l *CultureDataLoader) Load() (*CultureData, error) {
 
 	// Load user-provided culture data if path is specified
 	if l.defaultPath != "" {
-		data, err := os.ReadFile(l.defaultPath)
+		data, err := readFileFS(l.fsys, l.defaultPath)
 		if err != nil {
 			return nil, fmt.Errorf("load culture data: %w", err)
 		}
@@ -154,7 +163,7 @@ func (l *CultureDataLoader) mergeCultureData(dest, source *CultureData) {
 
 // loadOverride loads and merges a locale-specific override file
 func (l *CultureDataLoader) loadOverride(base *CultureData, locale, path string) error {
-	data, err := os.ReadFile(path)
+	data, err := readFileFS(l.fsys, path)
 	if err != nil {
 		return fmt.Errorf("load culture override for %q: %w", locale, err)
 	}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"testing/fstest"
 )
 
 func TestCultureService_GetCurrencyCode(t *testing.T) {
@@ -400,6 +401,38 @@ func TestCultureDataLoader_Override(t *testing.T) {
 	}
 }
 
+func TestCultureDataLoaderFS(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "culture", "example_culture_data.json"))
+	if err != nil {
+		t.Fatalf("read culture data: %v", err)
+	}
+
+	fsys := fstest.MapFS{
+		"culture/data.json":     {Data: data},
+		"culture/override.json": {Data: []byte(`{"currencies": {"en": {"code": "GBP", "symbol": "£"}}}`)},
+	}
+
+	cfg, err := NewConfig(
+		WithCultureFS(fsys),
+		WithCultureData("culture/data.json"),
+		WithCultureOverride("en", "culture/override.json"),
+	)
+	if err != nil {
+		t.Fatalf("NewConfig: %v", err)
+	}
+
+	loaded, err := cfg.loadCultureData()
+	if err != nil {
+		t.Fatalf("loadCultureData: %v", err)
+	}
+	if info := loaded.Currencies["en"]; info.Code != "GBP" {
+		t.Fatalf("override not applied: %+v", info)
+	}
+	if info := loaded.Currencies["es"]; info.Code != "EUR" {
+		t.Fatalf("base data not read from fsys: %+v", info)
+	}
+}
+
 func TestCultureService_FallsBackToParentLocaleWithoutResolver(t *testing.T) {
 	loader := NewCultureDataLoader(filepath.Join("testdata", "culture", "example_culture_data.json"))
 	data, err := loader.Load()
@@ -1,11 +1,16 @@
 package i18n
 
+import "context"
+
 type TranslationHook interface {
 	BeforeTranslate(ctx *TranslatorHookContext)
 	AfterTranslate(ctx *TranslatorHookContext)
 }
 
 type TranslatorHookContext struct {
+	// Context is the request context for TranslateContext calls and
+	// context.Background() for plain Translate calls.
+	Context  context.Context
 	Locale   string
 	Key      string
 	Args     []any
@@ -74,6 +79,49 @@ func (ctx *TranslatorHookContext) PluralMetadata() (PluralHookMetadata, bool) {
 	return meta, seen
 }
 
+// MissingArgs returns the declared named placeholders the translation did not
+// receive, if any.
+func (ctx *TranslatorHookContext) MissingArgs() []string {
+	if ctx == nil || len(ctx.Metadata) == 0 {
+		return nil
+	}
+	missing, _ := ctx.Metadata[metadataArgsMissing].([]string)
+	return missing
+}
+
+// SelectMetadata returns the case picked for a select message, if any.
+func (ctx *TranslatorHookContext) SelectMetadata() (SelectHookMetadata, bool) {
+	if ctx == nil || len(ctx.Metadata) == 0 {
+		return SelectHookMetadata{}, false
+	}
+
+	arg, ok := ctx.Metadata[metadataSelectArg].(string)
+	if !ok {
+		return SelectHookMetadata{}, false
+	}
+
+	meta := SelectHookMetadata{Arg: arg}
+	meta.Value, _ = ctx.Metadata[metadataSelectValue].(string)
+	meta.Case, _ = ctx.Metadata[metadataSelectCase].(string)
+	_, meta.Fallback = ctx.Metadata[metadataSelectMissing]
+	return meta, true
+}
+
+// LocaleMetadata reports the locale that served the translation and whether
+// it differs from the requested one.
+func (ctx *TranslatorHookContext) LocaleMetadata() (LocaleHookMetadata, bool) {
+	if ctx == nil || len(ctx.Metadata) == 0 {
+		return LocaleHookMetadata{}, false
+	}
+
+	resolved, ok := ctx.Metadata[metadataLocaleResolved].(string)
+	if !ok {
+		return LocaleHookMetadata{}, false
+	}
+	fallback, _ := ctx.Metadata[metadataLocaleFallback].(bool)
+	return LocaleHookMetadata{Requested: ctx.Locale, Resolved: resolved, Fallback: fallback}, true
+}
+
 func asPluralCategory(value any) (PluralCategory, bool) {
 	switch v := value.(type) {
 	case PluralCategory:
@@ -126,6 +174,22 @@ type PluralHookMetadata struct {
 	Missing  *PluralMissingEvent
 }
 
+// LocaleHookMetadata describes the locale lookup of a translation.
+type LocaleHookMetadata struct {
+	Requested string
+	Resolved  string
+	Fallback  bool
+}
+
+// SelectHookMetadata describes the select case used for a translation.
+// Fallback is set when Value had no case of its own and "other" was used.
+type SelectHookMetadata struct {
+	Arg      string
+	Value    string
+	Case     string
+	Fallback bool
+}
+
 type PluralMissingEvent struct {
 	Requested PluralCategory
 	Fallback  PluralCategory
@@ -148,7 +212,12 @@ func (h TranslationHookFuncs) AfterTranslate(ctx *TranslatorHookContext) {
 	}
 }
 
-var _ Translator = &HookedTranslator{}
+var (
+	_ Translator                = &HookedTranslator{}
+	_ ContextTranslator         = &HookedTranslator{}
+	_ contextMetadataTranslator = &HookedTranslator{}
+	_ contextMetadataTranslator = &SimpleTranslator{}
+)
 
 type HookedTranslator struct {
 	next  Translator
@@ -177,14 +246,41 @@ func WrapTranslatorWithHooks(next Translator, hooks ...TranslationHook) Translat
 }
 
 func (t *HookedTranslator) Translate(locale, key string, args ...any) (string, error) {
+	result, _, err := t.translate(context.Background(), locale, key, args, false)
+	return result, err
+}
+
+// TranslateContext implements ContextTranslator. Hooks receive ctx through
+// TranslatorHookContext.Context and the wrapped translator receives it too
+// when it accepts a context; cancellation is checked after the Before hooks.
+func (t *HookedTranslator) TranslateContext(ctx context.Context, key string, args ...any) (string, error) {
 	if t == nil || t.next == nil {
 		return "", ErrMissingTranslation
 	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	locale := contextLocale(ctx, t.next)
+	result, _, err := t.translate(ctx, locale, key, contextArgs(ctx, args), false)
+	return result, err
+}
+
+// translateContext lets an outer HookedTranslator, or another decorator,
+// pass its context down and read the metadata this layer collected.
+func (t *HookedTranslator) translateContext(ctx context.Context, locale, key string, args []any, withMetadata bool) (string, map[string]any, error) {
+	return t.translate(ctx, locale, key, args, withMetadata)
+}
+
+func (t *HookedTranslator) translate(goCtx context.Context, locale, key string, args []any, withMetadata bool) (string, map[string]any, error) {
+	if t == nil || t.next == nil {
+		return "", nil, ErrMissingTranslation
+	}
 
 	ctx := &TranslatorHookContext{
-		Locale: locale,
-		Key:    key,
-		Args:   args,
+		Context: goCtx,
+		Locale:  locale,
+		Key:     key,
+		Args:    args,
 	}
 
 	for _, hook := range t.hooks {
@@ -197,15 +293,19 @@ func (t *HookedTranslator) Translate(locale, key string, args ...any) (string, e
 		metadata map[string]any
 	)
 
-	if mt, ok := t.next.(metadataTranslator); ok {
-		result, metadata, err = mt.TranslateWithMetadata(ctx.Locale, ctx.Key, ctx.Args...)
+	if ctx.Context != nil && ctx.Context.Err() != nil {
+		err = ctx.Context.Err()
+	} else {
+		goCtx := ctx.Context
+		if goCtx == nil {
+			goCtx = context.Background()
+		}
+		result, metadata, err = translateNext(goCtx, t.next, ctx.Locale, ctx.Key, ctx.Args, true)
 		if len(metadata) > 0 {
 			for key, value := range metadata {
 				ctx.SetMetadata(key, value)
 			}
 		}
-	} else {
-		result, err = t.next.Translate(ctx.Locale, ctx.Key, ctx.Args...)
 	}
 
 	ctx.Result = result
@@ -215,10 +315,10 @@ func (t *HookedTranslator) Translate(locale, key string, args ...any) (string, e
 		hook.AfterTranslate(ctx)
 	}
 
-	result = ctx.Result
-	err = ctx.Error
-
-	return result, err
+	if !withMetadata {
+		return ctx.Result, nil, ctx.Error
+	}
+	return ctx.Result, ctx.Metadata, ctx.Error
 }
 
 func (t *HookedTranslator) DefaultLocale() string {
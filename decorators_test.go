@@ -1,6 +1,9 @@
 package i18n
 
-import "testing"
+import (
+	"errors"
+	"testing"
+)
 
 type recordingHook struct {
 	beforeCalls int
@@ -85,11 +88,11 @@ func TestWrapTranslatorWithHooksError(t *testing.T) {
 	recorder := &recordingHook{}
 	translator := WrapTranslatorWithHooks(base, recorder)
 
-	if _, err := translator.Translate("en", "missing"); err != ErrMissingTranslation {
+	if _, err := translator.Translate("en", "missing"); !errors.Is(err, ErrMissingTranslation) {
 		t.Fatalf("expected ErrMissingTranslation, got %v", err)
 	}
 
-	if recorder.lastErr != ErrMissingTranslation {
+	if !errors.Is(recorder.lastErr, ErrMissingTranslation) {
 		t.Fatalf("hook saw err %v, want %v", recorder.lastErr, ErrMissingTranslation)
 	}
 }
@@ -287,3 +290,86 @@ func TestHookedTranslatorEmitsMissingPluralEvent(t *testing.T) {
 		t.Fatalf("expected fallback plural other, got %v", plural.Missing.Fallback)
 	}
 }
+
+func TestHookedTranslatorReportsMissingArgs(t *testing.T) {
+	base, err := NewSimpleTranslator(newNamedArgsStore(), WithTranslatorDefaultLocale("en"))
+	if err != nil {
+		t.Fatalf("NewSimpleTranslator: %v", err)
+	}
+
+	var missing []string
+	translator := WrapTranslatorWithHooks(base, TranslationHookFuncs{
+		After: func(ctx *TranslatorHookContext) {
+			missing = ctx.MissingArgs()
+		},
+	})
+
+	got, err := translator.Translate("en", "inbox.summary", WithCount(1), WithArg("kind", "alert"))
+	if err != nil {
+		t.Fatalf("Translate: %v", err)
+	}
+	if got != "Hello {name}, you have 1 new alert" {
+		t.Fatalf("unexpected result: %q", got)
+	}
+	if len(missing) != 1 || missing[0] != "name" {
+		t.Fatalf("expected missing [name], got %v", missing)
+	}
+}
+
+func TestHookedTranslatorReportsSelectMetadata(t *testing.T) {
+	base, err := NewSimpleTranslator(NewStaticStore(newSelectTranslations(t)), WithTranslatorDefaultLocale("en"))
+	if err != nil {
+		t.Fatalf("NewSimpleTranslator: %v", err)
+	}
+
+	var (
+		selected SelectHookMetadata
+		found    bool
+	)
+	translator := WrapTranslatorWithHooks(base, TranslationHookFuncs{
+		After: func(ctx *TranslatorHookContext) {
+			selected, found = ctx.SelectMetadata()
+		},
+	})
+
+	got, err := translator.Translate("en", "post.liked", WithSelect("gender", "unknown"))
+	if err != nil {
+		t.Fatalf("Translate: %v", err)
+	}
+	if got != "They liked your post" {
+		t.Fatalf("unexpected result: %q", got)
+	}
+	want := SelectHookMetadata{Arg: "gender", Value: "unknown", Case: SelectOther, Fallback: true}
+	if !found || selected != want {
+		t.Fatalf("SelectMetadata = %#v (%v) want %#v", selected, found, want)
+	}
+
+	if _, err := translator.Translate("en", "post.liked", WithSelect("gender", "male")); err != nil {
+		t.Fatalf("Translate: %v", err)
+	}
+	if selected.Case != "male" || selected.Fallback {
+		t.Fatalf("unexpected select metadata %#v", selected)
+	}
+}
+
+func TestHookedTranslatorReportsResolvedLocale(t *testing.T) {
+	base, err := NewSimpleTranslator(newLookupPolicyStore(), WithTranslatorDefaultLocale("en"))
+	if err != nil {
+		t.Fatalf("NewSimpleTranslator: %v", err)
+	}
+
+	var locale LocaleHookMetadata
+	translator := WrapTranslatorWithHooks(base, TranslationHookFuncs{
+		After: func(ctx *TranslatorHookContext) {
+			locale, _ = ctx.LocaleMetadata()
+		},
+	})
+
+	if _, err := translator.Translate("es-MX", "cta.buy"); err != nil {
+		t.Fatalf("Translate: %v", err)
+	}
+	want := LocaleHookMetadata{Requested: "es-MX", Resolved: "en", Fallback: true}
+	if locale != want {
+		t.Fatalf("LocaleMetadata = %#v want %#v", locale, want)
+	}
+}
@@ -1,9 +1,58 @@
 package i18n
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 // ErrMissingTranslation indicates that no translation was found for locale/key.
 var ErrMissingTranslation = errors.New("i18n: missing translation")
 
 // ErrNotImplemented marks APIs that are intentionally stubbed during bootstrapping.
 var ErrNotImplemented = errors.New("i18n: not implemented")
+
+// ErrMissingArgs indicates that declared named placeholders were not supplied.
+var ErrMissingArgs = errors.New("i18n: missing format arguments")
+
+// MissingArgsError reports the named placeholders a translation declared but
+// did not receive. It unwraps to ErrMissingArgs.
+type MissingArgsError struct {
+	Locale string
+	Key    string
+	Args   []string
+}
+
+func (e *MissingArgsError) Error() string {
+	return fmt.Sprintf("%s: %s/%s requires %s", ErrMissingArgs, e.Locale, e.Key, strings.Join(e.Args, ", "))
+}
+
+func (e *MissingArgsError) Unwrap() error {
+	return ErrMissingArgs
+}
+
+// MissingTranslationError reports a key that none of the searched locales
+// define. Category is the plural category the count would have selected in
+// the requested locale, when one was given. It unwraps to
+// ErrMissingTranslation.
+type MissingTranslationError struct {
+	Locale   string
+	Key      string
+	Tried    []string
+	Category PluralCategory
+}
+
+func (e *MissingTranslationError) Error() string {
+	msg := fmt.Sprintf("%s: %s/%s", ErrMissingTranslation, e.Locale, e.Key)
+	if e.Category != "" {
+		msg += fmt.Sprintf(" [%s]", e.Category)
+	}
+	if len(e.Tried) > 0 {
+		msg += fmt.Sprintf(" (tried %s)", strings.Join(e.Tried, ", "))
+	}
+	return msg
+}
+
+func (e *MissingTranslationError) Unwrap() error {
+	return ErrMissingTranslation
+}
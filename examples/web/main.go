@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/goliatone/go-i18n"
+	"github.com/goliatone/go-i18n/i18nhttp"
 )
 
 var (
@@ -20,7 +21,7 @@ var (
 	helperFuncs   map[string]any
 	localeCatalog *i18n.LocaleCatalog
 	localeOptions []LocaleOption
-	defaultLocale string
+	negotiator    *i18nhttp.Negotiator
 )
 
 type LocaleOption struct {
@@ -49,7 +50,7 @@ func main() {
 		log.Fatal(err)
 	}
 
-	http.HandleFunc("/", homeHandler)
+	http.Handle("/", negotiator.Middleware(http.HandlerFunc(homeHandler)))
 	http.Handle("/static/", http.StripPrefix("/static/", http.FileServer(http.Dir("static"))))
 
 	fmt.Println("Server starting on http://localhost:8080")
@@ -89,12 +90,7 @@ func setup() error {
 
 	registry = cfg.FormatterRegistry()
 	localeCatalog = cfg.LocaleCatalog()
-	defaultLocale = cfg.DefaultLocale
-	if defaultLocale == "" && localeCatalog != nil {
-		if codes := localeCatalog.ActiveLocaleCodes(); len(codes) > 0 {
-			defaultLocale = codes[0]
-		}
-	}
+	negotiator = i18nhttp.FromConfig(cfg)
 	localeOptions = buildLocaleOptions(localeCatalog)
 
 	helperFuncs = cfg.TemplateHelpers(translator, i18n.HelperConfig{
@@ -116,7 +112,7 @@ func setup() error {
 }
 
 func homeHandler(w http.ResponseWriter, r *http.Request) {
-	locale := resolveLocale(r.URL.Query().Get("lang"))
+	locale := i18nhttp.LocaleFromRequest(r)
 	meta := i18n.LocaleMetadata{Code: locale}
 	if localeCatalog != nil {
 		if m, ok := localeCatalog.Locale(locale); ok {
@@ -159,30 +155,6 @@ func homeHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func resolveLocale(requested string) string {
-	if localeCatalog == nil {
-		if requested == "" {
-			return defaultLocale
-		}
-		return requested
-	}
-
-	code := normalizeLocaleCode(requested)
-	if code != "" && localeCatalog.IsActive(code) {
-		return code
-	}
-
-	if defaultLocale != "" {
-		return defaultLocale
-	}
-
-	if codes := localeCatalog.ActiveLocaleCodes(); len(codes) > 0 {
-		return codes[0]
-	}
-
-	return code
-}
-
 func buildLocaleOptions(catalog *i18n.LocaleCatalog) []LocaleOption {
 	if catalog == nil {
 		return nil
@@ -9,10 +9,31 @@ type FallbackResolver interface {
 
 var _ FallbackResolver = &StaticFallbackResolver{}
 
+// fallbackVersioner is implemented by resolvers whose answers only change
+// together with their version, which lets translators cache lookup chains.
+// ok is false when the resolver cannot tell.
+type fallbackVersioner interface {
+	fallbackVersion() (version uint64, ok bool)
+}
+
+// resolverVersion reports the version of resolver; nil resolvers never
+// change.
+func resolverVersion(resolver FallbackResolver) (uint64, bool) {
+	switch r := resolver.(type) {
+	case nil:
+		return 0, true
+	case fallbackVersioner:
+		return r.fallbackVersion()
+	default:
+		return 0, false
+	}
+}
+
 // StaticFallbackResolver initial imp
 type StaticFallbackResolver struct {
-	chains map[string][]string
-	mu     sync.RWMutex
+	chains  map[string][]string
+	version uint64
+	mu      sync.RWMutex
 }
 
 func NewStaticFallbackResolver() *StaticFallbackResolver {
@@ -47,6 +68,16 @@ func (r *StaticFallbackResolver) Set(locale string, fallbacks ...string) {
 		chain = append(chain, fb)
 	}
 	r.chains[locale] = chain
+	r.version++
+}
+
+func (r *StaticFallbackResolver) fallbackVersion() (uint64, bool) {
+	if r == nil {
+		return 0, true
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.version, true
 }
 
 // Resolve returns a copy of the fallback chain for a locale
@@ -66,3 +97,236 @@ func (r *StaticFallbackResolver) Resolve(locale string) []string {
 	copy(out, chain)
 	return out
 }
+
+// LookupPolicy controls which locales SimpleTranslator searches for a key.
+type LookupPolicy string
+
+const (
+	// LookupImplicit searches the requested locale, its parents (en-US → en),
+	// the resolver chain and finally the default locale. It is the default.
+	LookupImplicit LookupPolicy = "implicit"
+	// LookupDeclared searches the requested locale and the locales returned
+	// by the FallbackResolver, nothing else.
+	LookupDeclared LookupPolicy = "declared"
+	// LookupStrict only searches the requested locale.
+	LookupStrict LookupPolicy = "strict"
+)
+
+func (p LookupPolicy) valid() bool {
+	switch p {
+	case LookupImplicit, LookupDeclared, LookupStrict:
+		return true
+	default:
+		return false
+	}
+}
+
+// FallbackResolverFunc adapts a function into a FallbackResolver.
+type FallbackResolverFunc func(locale string) []string
+
+// Resolve implements FallbackResolver.
+func (fn FallbackResolverFunc) Resolve(locale string) []string {
+	if fn == nil {
+		return nil
+	}
+	return fn(locale)
+}
+
+// stableFallbackResolver is a resolver function whose answers never change.
+type stableFallbackResolver func(locale string) []string
+
+func (fn stableFallbackResolver) Resolve(locale string) []string {
+	return fn(locale)
+}
+
+func (stableFallbackResolver) fallbackVersion() (uint64, bool) {
+	return 0, true
+}
+
+// ParentFallbackResolver derives fallbacks from locale parents
+// (zh-Hant-TW → zh-Hant → zh).
+func ParentFallbackResolver() FallbackResolver {
+	return stableFallbackResolver(localeParentChain)
+}
+
+// DefaultLocaleFallback resolves every other locale to locale, for use as the
+// tail of a FallbackChain.
+func DefaultLocaleFallback(locale string) FallbackResolver {
+	return stableFallbackResolver(func(requested string) []string {
+		if locale == "" || requested == locale {
+			return nil
+		}
+		return []string{locale}
+	})
+}
+
+// FallbackSource names where a fallback hop came from.
+type FallbackSource string
+
+const (
+	FallbackSourceRequested FallbackSource = "requested"
+	FallbackSourceDeclared  FallbackSource = "declared"
+	FallbackSourceCatalog   FallbackSource = "catalog"
+	FallbackSourceParent    FallbackSource = "parent"
+	FallbackSourceDefault   FallbackSource = "default"
+)
+
+// FallbackHop is one locale of an explained lookup chain.
+type FallbackHop struct {
+	Locale string
+	Source FallbackSource
+}
+
+type fallbackLink struct {
+	source   FallbackSource
+	resolver FallbackResolver
+}
+
+var _ FallbackResolver = &FallbackChain{}
+
+// FallbackChain composes resolvers into one. Resolve concatenates the hops
+// of every link in order, dropping duplicates and the requested locale, and
+// Explain reports which link contributed each hop.
+type FallbackChain struct {
+	links []fallbackLink
+}
+
+// NewFallbackChain returns an empty chain.
+func NewFallbackChain() *FallbackChain {
+	return &FallbackChain{}
+}
+
+// Add appends a resolver tagged with source and returns the chain.
+func (c *FallbackChain) Add(source FallbackSource, resolver FallbackResolver) *FallbackChain {
+	if c == nil || resolver == nil {
+		return c
+	}
+	c.links = append(c.links, fallbackLink{source: source, resolver: resolver})
+	return c
+}
+
+// With returns a copy of the chain with resolver appended.
+func (c *FallbackChain) With(source FallbackSource, resolver FallbackResolver) *FallbackChain {
+	return c.clone().Add(source, resolver)
+}
+
+func (c *FallbackChain) clone() *FallbackChain {
+	out := &FallbackChain{}
+	if c != nil {
+		out.links = append(out.links, c.links...)
+	}
+	return out
+}
+
+// fallbackVersion combines the versions of the links; any link that cannot
+// report one makes the chain unversioned.
+func (c *FallbackChain) fallbackVersion() (uint64, bool) {
+	if c == nil {
+		return 0, true
+	}
+	version := uint64(len(c.links))
+	for _, link := range c.links {
+		linkVersion, ok := resolverVersion(link.resolver)
+		if !ok {
+			return 0, false
+		}
+		version += linkVersion
+	}
+	return version, true
+}
+
+// Resolve implements FallbackResolver.
+func (c *FallbackChain) Resolve(locale string) []string {
+	hops := c.Explain(locale)
+	if len(hops) == 0 {
+		return nil
+	}
+	out := make([]string, len(hops))
+	for i, hop := range hops {
+		out[i] = hop.Locale
+	}
+	return out
+}
+
+// Explain returns the fallback hops for locale with their sources.
+func (c *FallbackChain) Explain(locale string) []FallbackHop {
+	if c == nil {
+		return nil
+	}
+
+	var hops []FallbackHop
+	seen := map[string]struct{}{locale: {}}
+	for _, link := range c.links {
+		for _, fallback := range link.resolver.Resolve(locale) {
+			if fallback == "" {
+				continue
+			}
+			if _, ok := seen[fallback]; ok {
+				continue
+			}
+			seen[fallback] = struct{}{}
+			hops = append(hops, FallbackHop{Locale: fallback, Source: link.source})
+		}
+	}
+	return hops
+}
+
+// explainLookup lists the locales searched for primary under policy, in
+// order, with the source of each hop. Resolvers that are not a FallbackChain
+// are reported as declared.
+func explainLookup(primary, defaultLocale string, policy LookupPolicy, resolver FallbackResolver) []FallbackHop {
+	if primary == "" {
+		return nil
+	}
+
+	hops := make([]FallbackHop, 0, 4)
+	appendHop := func(locale string, source FallbackSource) {
+		if locale == "" {
+			return
+		}
+		for _, hop := range hops {
+			if hop.Locale == locale {
+				return
+			}
+		}
+		hops = append(hops, FallbackHop{Locale: locale, Source: source})
+	}
+
+	appendHop(primary, FallbackSourceRequested)
+	if policy == LookupStrict {
+		return hops
+	}
+
+	implicit := policy != LookupDeclared
+	if implicit {
+		for parent := localeParentTag(primary); parent != ""; parent = localeParentTag(parent) {
+			appendHop(parent, FallbackSourceParent)
+		}
+	}
+
+	switch r := resolver.(type) {
+	case nil:
+	case *FallbackChain:
+		for _, hop := range r.Explain(primary) {
+			appendHop(hop.Locale, hop.Source)
+		}
+	default:
+		for _, fallback := range r.Resolve(primary) {
+			appendHop(fallback, FallbackSourceDeclared)
+		}
+	}
+
+	if implicit {
+		appendHop(defaultLocale, FallbackSourceDefault)
+	}
+
+	return hops
+}
+
+// FallbackConflict records a locale whose declared fallback chain differs
+// from the chain defined in culture data.
+type FallbackConflict struct {
+	Locale   string
+	Declared []string
+	Catalog  []string
+}
@@ -0,0 +1,82 @@
+package i18n
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFallbackChainResolveAndExplain(t *testing.T) {
+	declared := NewStaticFallbackResolver()
+	declared.Set("es-MX", "es-419", "en")
+
+	chain := NewFallbackChain().
+		Add(FallbackSourceDeclared, declared).
+		Add(FallbackSourceParent, ParentFallbackResolver()).
+		Add(FallbackSourceDefault, DefaultLocaleFallback("en"))
+
+	if got := chain.Resolve("es-MX"); !reflect.DeepEqual(got, []string{"es-419", "en", "es"}) {
+		t.Fatalf("Resolve(es-MX) = %v", got)
+	}
+
+	want := []FallbackHop{
+		{Locale: "es-419", Source: FallbackSourceDeclared},
+		{Locale: "en", Source: FallbackSourceDeclared},
+		{Locale: "es", Source: FallbackSourceParent},
+	}
+	if got := chain.Explain("es-MX"); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Explain(es-MX) = %v want %v", got, want)
+	}
+
+	if got := chain.Explain("en"); got != nil {
+		t.Fatalf("Explain(en) = %v, the requested locale is never a hop", got)
+	}
+
+	extended := chain.With(FallbackSourceCatalog, FallbackResolverFunc(func(string) []string { return []string{"pt"} }))
+	if got := extended.Resolve("fr"); !reflect.DeepEqual(got, []string{"en", "pt"}) {
+		t.Fatalf("extended Resolve(fr) = %v", got)
+	}
+	if got := chain.Resolve("fr"); !reflect.DeepEqual(got, []string{"en"}) {
+		t.Fatalf("With must not modify the original chain, got %v", got)
+	}
+}
+
+func TestSimpleTranslatorExplainFallback(t *testing.T) {
+	resolver := NewFallbackChain().Add(FallbackSourceCatalog, FallbackResolverFunc(func(locale string) []string {
+		if locale == "es-MX" {
+			return []string{"pt"}
+		}
+		return nil
+	}))
+
+	tests := []struct {
+		policy LookupPolicy
+		want   []FallbackHop
+	}{
+		{policy: LookupImplicit, want: []FallbackHop{
+			{Locale: "es-MX", Source: FallbackSourceRequested},
+			{Locale: "es-419", Source: FallbackSourceParent},
+			{Locale: "es", Source: FallbackSourceParent},
+			{Locale: "pt", Source: FallbackSourceCatalog},
+			{Locale: "en", Source: FallbackSourceDefault},
+		}},
+		{policy: LookupDeclared, want: []FallbackHop{
+			{Locale: "es-MX", Source: FallbackSourceRequested},
+			{Locale: "pt", Source: FallbackSourceCatalog},
+		}},
+		{policy: LookupStrict, want: []FallbackHop{
+			{Locale: "es-MX", Source: FallbackSourceRequested},
+		}},
+	}
+	for _, tc := range tests {
+		translator, err := NewSimpleTranslator(nil,
+			WithTranslatorDefaultLocale("en"),
+			WithTranslatorFallbackResolver(resolver),
+			WithTranslatorLookupPolicy(tc.policy))
+		if err != nil {
+			t.Fatalf("NewSimpleTranslator: %v", err)
+		}
+		if got := translator.ExplainFallback("es-MX"); !reflect.DeepEqual(got, tc.want) {
+			t.Fatalf("%s: ExplainFallback = %v want %v", tc.policy, got, tc.want)
+		}
+	}
+}
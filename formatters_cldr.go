@@ -39,6 +39,7 @@ type cldrProvider struct {
 	bundle  cldrBundle
 	tag     language.Tag
 	printer *message.Printer
+	ordinal *PluralRuleSet
 	funcs   map[string]any
 }
 
@@ -50,6 +51,11 @@ func newCLDRProvider(locale string, bundle cldrBundle) *cldrProvider {
 		tag:     tag,
 		printer: message.NewPrinter(tag),
 	}
+	if len(bundle.Ordinal.Rules) > 0 {
+		if rules, err := ParsePluralRuleSet(locale, bundle.Ordinal.Rules); err == nil {
+			p.ordinal = rules
+		}
+	}
 
 	p.funcs = map[string]any{
 		"format_list":        p.formatList,
@@ -116,12 +122,21 @@ func (p *cldrProvider) formatList(_ string, items []string) string {
 }
 
 func (p *cldrProvider) formatOrdinal(_ string, value int) string {
-	switch p.bundle.Ordinal.System {
-	case "spanish":
-		return formatOrdinalWithSuffix(value, "º")
-	default:
-		return formatOrdinalISO(p.locale, value)
+	suffixes := p.bundle.Ordinal.Suffixes
+	if len(suffixes) == 0 {
+		return strconv.Itoa(value)
 	}
+
+	category := PluralOther
+	if p.ordinal != nil {
+		operands, _, _ := convertSignedInt(int64(value))
+		category = selectPluralCategory(p.ordinal, operands)
+	}
+	suffix, ok := suffixes[string(category)]
+	if !ok {
+		suffix = suffixes[string(PluralOther)]
+	}
+	return formatOrdinalWithSuffix(value, suffix)
 }
 
 func (p *cldrProvider) formatMeasurement(_ string, value float64, unit string) string {
@@ -230,3 +245,23 @@ func formatPhoneWithMetadata(raw string, meta cldrPhoneMetadata) string {
 
 	return builder.String()
 }
+
+// compactPatterns returns the short decimal patterns bundled for locale or
+// its base language, keyed by power of ten (3 for "1000").
+func compactPatterns(locale string) map[int]string {
+	bundle, ok := cldrBundles[locale]
+	if !ok {
+		base, _, _ := strings.Cut(locale, "-")
+		if bundle, ok = cldrBundles[base]; !ok {
+			return nil
+		}
+	}
+	if len(bundle.Compact.Short) == 0 {
+		return nil
+	}
+	patterns := make(map[int]string, len(bundle.Compact.Short))
+	for magnitude, pattern := range bundle.Compact.Short {
+		patterns[len(magnitude)-1] = pattern
+	}
+	return patterns
+}
@@ -10,7 +10,8 @@ type cldrListPatterns struct {
 }
 
 type cldrOrdinalRules struct {
-	System string
+	Rules    map[string]string
+	Suffixes map[string]string
 }
 
 type cldrMeasurementData struct {
@@ -23,11 +24,16 @@ type cldrPhoneMetadata struct {
 	Groups         []int
 }
 
+type cldrCompactPatterns struct {
+	Short map[string]string
+}
+
 type cldrBundle struct {
 	List        cldrListPatterns
 	Ordinal     cldrOrdinalRules
 	Measurement cldrMeasurementData
 	Phone       cldrPhoneMetadata
+	Compact     cldrCompactPatterns
 }
 
 var cldrBundles = map[string]cldrBundle{
@@ -39,7 +45,17 @@ var cldrBundles = map[string]cldrBundle{
 			End:    "{0}, and {1}",
 		},
 		Ordinal: cldrOrdinalRules{
-			System: "english",
+			Rules: map[string]string{
+				"few": "n % 10 = 3 and n % 100 != 13",
+				"one": "n % 10 = 1 and n % 100 != 11",
+				"two": "n % 10 = 2 and n % 100 != 12",
+			},
+			Suffixes: map[string]string{
+				"few":   "rd",
+				"one":   "st",
+				"other": "th",
+				"two":   "nd",
+			},
 		},
 		Measurement: cldrMeasurementData{
 			Units: map[string]string{
@@ -55,6 +71,22 @@ var cldrBundles = map[string]cldrBundle{
 			NationalPrefix: "1",
 			Groups:         []int{3, 3, 4},
 		},
+		Compact: cldrCompactPatterns{
+			Short: map[string]string{
+				"1000":            "0K",
+				"10000":           "00K",
+				"100000":          "000K",
+				"1000000":         "0M",
+				"10000000":        "00M",
+				"100000000":       "000M",
+				"1000000000":      "0B",
+				"10000000000":     "00B",
+				"100000000000":    "000B",
+				"1000000000000":   "0T",
+				"10000000000000":  "00T",
+				"100000000000000": "000T",
+			},
+		},
 	},
 	"es": {
 		List: cldrListPatterns{
@@ -64,7 +96,12 @@ var cldrBundles = map[string]cldrBundle{
 			End:    "{0} y {1}",
 		},
 		Ordinal: cldrOrdinalRules{
-			System: "spanish",
+			Rules: map[string]string{
+				"other": "",
+			},
+			Suffixes: map[string]string{
+				"other": "º",
+			},
 		},
 		Measurement: cldrMeasurementData{
 			Units: map[string]string{
@@ -80,6 +117,22 @@ var cldrBundles = map[string]cldrBundle{
 			NationalPrefix: "",
 			Groups:         []int{3, 3, 3},
 		},
+		Compact: cldrCompactPatterns{
+			Short: map[string]string{
+				"1000":            "0\u00a0mil",
+				"10000":           "00\u00a0mil",
+				"100000":          "000\u00a0mil",
+				"1000000":         "0\u00a0M",
+				"10000000":        "00\u00a0M",
+				"100000000":       "000\u00a0M",
+				"1000000000":      "0000\u00a0M",
+				"10000000000":     "00\u00a0mil\u00a0M",
+				"100000000000":    "000\u00a0mil\u00a0M",
+				"1000000000000":   "0\u00a0B",
+				"10000000000000":  "00\u00a0B",
+				"100000000000000": "000\u00a0B",
+			},
+		},
 	},
 }
 
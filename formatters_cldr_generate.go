@@ -1,3 +1,3 @@
 package i18n
 
-//go:generate go run ./cmd/i18n-formatters -locale=en -locale=es -cldr ${CLDR_CORE_DIR} -out formatters_cldr_data.go
+//go:generate go run ./cmd/i18n-formatters -locale=en -locale=es -cldr ${CLDR_CORE_DIR} -out formatters_cldr_data.go -plurals-out cldr_plural_data.go
@@ -254,6 +254,7 @@ func NewFormatterRegistry(opts ...FormatterRegistryOption) *FormatterRegistry {
 		"format_time":        FormatTime,
 		"format_currency":    FormatCurrency,
 		"format_number":      FormatNumber,
+		"number_symbols":     numberSymbolsISO,
 		"format_percent":     formatPercentISO,
 		"format_ordinal":     formatOrdinalISO,
 		"format_list":        formatListISO,
@@ -561,3 +561,24 @@ func (p *testTypedProvider) FuncMap() map[string]any {
 func (p *testTypedProvider) Capabilities() FormatterCapabilities {
 	return FormatterCapabilities{}
 }
+
+func TestFormatterRegistryCLDROrdinalRules(t *testing.T) {
+	registry := NewFormatterRegistry()
+
+	ordinalEn := registry.FuncMap("en")["format_ordinal"].(func(string, int) string)
+	for value, want := range map[int]string{1: "1st", 2: "2nd", 3: "3rd", 4: "4th", 11: "11th", 12: "12th", 13: "13th", 22: "22nd", 103: "103rd"} {
+		if got := ordinalEn("en", value); got != want {
+			t.Fatalf("format_ordinal en %d = %q want %q", value, got, want)
+		}
+	}
+
+	ordinalEs := registry.FuncMap("es")["format_ordinal"].(func(string, int) string)
+	if got := ordinalEs("es", 2); got != "2º" {
+		t.Fatalf("format_ordinal es = %q", got)
+	}
+
+	provider := newCLDRProvider("xx", cldrBundle{})
+	if got := provider.formatOrdinal("xx", 3); got != "3" {
+		t.Fatalf("format_ordinal without suffix data = %q", got)
+	}
+}
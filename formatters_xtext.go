@@ -33,6 +33,7 @@ type xtextProvider struct {
 	tag          language.Tag
 	printer      *message.Printer
 	rules        *FormattingRules
+	compact      map[int]string
 	funcs        map[string]any
 	capabilities FormatterCapabilities
 }
@@ -53,6 +54,7 @@ func newXTextProvider(locale string, rulesProvider *FormattingRulesProvider) *xt
 		tag:     tag,
 		printer: message.NewPrinter(tag),
 		rules:   rules,
+		compact: compactPatterns(locale),
 	}
 
 	provider.capabilities = FormatterCapabilities{
@@ -71,6 +73,10 @@ func newXTextProvider(locale string, rulesProvider *FormattingRulesProvider) *xt
 		"format_time":     provider.formatTime,
 	}
 
+	if rules != nil {
+		provider.funcs["number_symbols"] = provider.numberSymbols
+	}
+
 	return provider
 }
 
@@ -132,6 +138,20 @@ func (p *xtextProvider) formatNumberWithRules(value float64, decimals int) strin
 	return p.applySeparators(formatted)
 }
 
+// numberSymbols describes the layout formatNumberWithRules produces.
+func (p *xtextProvider) numberSymbols(_ string) NumberSymbols {
+	symbols := NumberSymbols{Decimal: p.rules.CurrencyRules.DecimalSep, CompactPatterns: p.compact}
+	if symbols.Decimal == "" {
+		symbols.Decimal = "."
+	}
+	if group := p.rules.CurrencyRules.ThousandSep; group != "" {
+		symbols.Group = group
+		symbols.PrimaryGroup = 3
+		symbols.SecondaryGroup = 3
+	}
+	return symbols
+}
+
 func (p *xtextProvider) applySeparators(formatted string) string {
 	if p.rules == nil {
 		return formatted
@@ -0,0 +1,447 @@
+package i18n
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// poContextSeparator joins msgctxt and msgid into a message key.
+const poContextSeparator = "."
+
+// poEntry is a single PO/MO catalog entry.
+type poEntry struct {
+	context     string
+	id          string
+	idPlural    string
+	strs        map[int]string
+	comments    []string
+	extracted   []string
+	fuzzy       bool
+	hasContext  bool
+	hasIDPlural bool
+}
+
+func (e *poEntry) key() string {
+	if e.hasContext && e.context != "" {
+		return e.context + poContextSeparator + e.id
+	}
+	return e.id
+}
+
+func (e *poEntry) description() string {
+	if len(e.comments) > 0 {
+		return strings.Join(e.comments, "\n")
+	}
+	return strings.Join(e.extracted, "\n")
+}
+
+// poFile is the decoded form of a .po or .mo file.
+type poFile struct {
+	headers map[string]string
+	entries []*poEntry
+}
+
+func (f *poFile) locale() string {
+	return normalizeLocale(f.headers["Language"])
+}
+
+// PoLoader reads GNU gettext .po and binary .mo catalogs. msgctxt values
+// become key namespaces ("ctx.msgid"), msgstr[n] forms are mapped to plural
+// categories using the Plural-Forms header together with the locale's
+// PluralRuleSet when available, and translator comments populate
+// MessageMetadata.Description. Fuzzy and untranslated entries are skipped.
+type PoLoader struct {
+	paths     []string
+	rulePaths []string
+	locale    string
+}
+
+var _ Loader = &PoLoader{}
+
+func NewPoLoader(paths ...string) *PoLoader {
+	return &PoLoader{paths: append([]string(nil), paths...)}
+}
+
+// WithLocale forces the locale for every file instead of reading the
+// Language header or inferring it from the file path.
+func (l *PoLoader) WithLocale(locale string) *PoLoader {
+	if l == nil {
+		return l
+	}
+	l.locale = locale
+	return l
+}
+
+func (l *PoLoader) WithPluralRuleFiles(paths ...string) *PoLoader {
+	if l == nil || len(paths) == 0 {
+		return l
+	}
+	l.rulePaths = append(l.rulePaths, paths...)
+	return l
+}
+
+// WithPluralRules satisfies the pluralRuleLoader contract used by config wiring.
+func (l *PoLoader) WithPluralRules(paths ...string) Loader {
+	return l.WithPluralRuleFiles(paths...)
+}
+
+func (l *PoLoader) watchedPaths() []string {
+	if l == nil {
+		return nil
+	}
+	paths := make([]string, 0, len(l.paths)+len(l.rulePaths))
+	paths = append(paths, l.paths...)
+	return append(paths, l.rulePaths...)
+}
+
+func (l *PoLoader) Load() (Translations, error) {
+	if l == nil || len(l.paths) == 0 {
+		return nil, errors.New("i18n: no loader paths configured")
+	}
+
+	rules, err := loadPluralRuleFiles(nil, l.rulePaths)
+	if err != nil {
+		return nil, err
+	}
+
+	buckets := make(map[string]map[string]Message)
+	for _, path := range l.paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("i18n: read %s: %w", path, err)
+		}
+
+		file, err := decodeGettextFile(path, data)
+		if err != nil {
+			return nil, fmt.Errorf("i18n: decode %s: %w", path, err)
+		}
+
+		locale := normalizeLocale(l.locale)
+		if locale == "" {
+			locale = file.locale()
+		}
+		if locale == "" {
+			locale = inferGettextLocale(path)
+		}
+		if locale == "" {
+			return nil, fmt.Errorf("i18n: cannot determine locale for %s", path)
+		}
+
+		messages, err := buildGettextMessages(locale, path, file, lookupRuleSet(rules.cardinal, locale))
+		if err != nil {
+			return nil, fmt.Errorf("i18n: %s: %w", path, err)
+		}
+		mergeMessageBuckets(buckets, map[string]map[string]Message{locale: messages})
+	}
+
+	return buildCatalogs(buckets, rules), nil
+}
+
+func decodeGettextFile(path string, data []byte) (*poFile, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".po", ".pot":
+		return parsePO(data)
+	case ".mo":
+		return parseMO(data)
+	default:
+		return nil, fmt.Errorf("unsupported extension %s", filepath.Ext(path))
+	}
+}
+
+// inferGettextLocale reads the locale from paths such as es.po or
+// es_MX/LC_MESSAGES/app.mo.
+func inferGettextLocale(path string) string {
+	dir := filepath.Dir(path)
+	if filepath.Base(dir) == "LC_MESSAGES" {
+		return normalizeLocale(filepath.Base(filepath.Dir(dir)))
+	}
+	base := filepath.Base(path)
+	return normalizeLocale(strings.TrimSuffix(base, filepath.Ext(base)))
+}
+
+// lookupRuleSet returns the rule set for locale or its closest parent.
+func lookupRuleSet(rules map[string]*PluralRuleSet, locale string) *PluralRuleSet {
+	for _, candidate := range append([]string{locale}, localeParentChain(locale)...) {
+		if set, ok := rules[candidate]; ok {
+			return set
+		}
+	}
+	return nil
+}
+
+func buildGettextMessages(locale, source string, file *poFile, rules *PluralRuleSet) (map[string]Message, error) {
+	var forms *pluralForms
+	if header, ok := file.headers["Plural-Forms"]; ok {
+		parsed, err := parsePluralForms(header)
+		if err != nil {
+			return nil, err
+		}
+		forms = parsed
+	}
+
+	var categories []PluralCategory
+	switch {
+	case forms != nil:
+		categories = forms.categories(rules)
+	case rules != nil:
+		categories = rules.Categories()
+	default:
+		categories = []PluralCategory{PluralOne, PluralOther}
+	}
+
+	messages := make(map[string]Message, len(file.entries))
+	for _, entry := range file.entries {
+		if entry.id == "" || entry.fuzzy {
+			continue
+		}
+
+		key := entry.key()
+		variants := make(map[PluralCategory]string)
+
+		if entry.hasIDPlural {
+			for idx, str := range entry.strs {
+				if str == "" || idx >= len(categories) || categories[idx] == "" {
+					continue
+				}
+				variants[categories[idx]] = str
+			}
+			if _, ok := variants[PluralOther]; !ok {
+				if last, ok := entry.strs[len(categories)-1]; ok && last != "" {
+					variants[PluralOther] = last
+				}
+			}
+		} else if str := entry.strs[0]; str != "" {
+			variants[PluralOther] = str
+		}
+
+		if len(variants) == 0 {
+			continue
+		}
+
+		message, err := buildMessageFromVariants(locale, key, variants, source)
+		if err != nil {
+			return nil, err
+		}
+		message.Description = entry.description()
+		messages[key] = message
+	}
+
+	return messages, nil
+}
+
+// parsePO decodes the textual PO format.
+func parsePO(data []byte) (*poFile, error) {
+	file := &poFile{headers: make(map[string]string)}
+
+	var (
+		entry    = &poEntry{strs: make(map[int]string)}
+		appendTo func(string)
+		started  bool
+		hasStr   bool
+		lineNo   int
+	)
+
+	flush := func() {
+		if started {
+			if entry.id == "" && !entry.hasContext {
+				file.headers = parsePOHeaders(entry.strs[0])
+			} else {
+				file.entries = append(file.entries, entry)
+			}
+		}
+		entry = &poEntry{strs: make(map[int]string)}
+		appendTo = nil
+		started, hasStr = false, false
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case line == "":
+			flush()
+			continue
+		case strings.HasPrefix(line, "#~"):
+			continue
+		case strings.HasPrefix(line, "#"):
+			if hasStr {
+				flush()
+			}
+			switch {
+			case strings.HasPrefix(line, "#."):
+				entry.extracted = append(entry.extracted, strings.TrimSpace(line[2:]))
+			case strings.HasPrefix(line, "#,"):
+				for _, flag := range strings.Split(line[2:], ",") {
+					if strings.TrimSpace(flag) == "fuzzy" {
+						entry.fuzzy = true
+					}
+				}
+			case strings.HasPrefix(line, "#:"), strings.HasPrefix(line, "#|"):
+			default:
+				entry.comments = append(entry.comments, strings.TrimSpace(line[1:]))
+			}
+			continue
+		case strings.HasPrefix(line, `"`):
+			if appendTo == nil {
+				return nil, fmt.Errorf("line %d: unexpected continuation string", lineNo)
+			}
+			value, err := unquotePO(line)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNo, err)
+			}
+			appendTo(value)
+			continue
+		}
+
+		keyword, rest, _ := strings.Cut(line, " ")
+		value, err := unquotePO(strings.TrimSpace(rest))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo, err)
+		}
+
+		if hasStr && (keyword == "msgctxt" || keyword == "msgid") {
+			flush()
+		}
+
+		current := entry
+		switch {
+		case keyword == "msgctxt":
+			current.context, current.hasContext = value, true
+			appendTo = func(s string) { current.context += s }
+		case keyword == "msgid":
+			current.id = value
+			appendTo = func(s string) { current.id += s }
+		case keyword == "msgid_plural":
+			current.idPlural, current.hasIDPlural = value, true
+			appendTo = func(s string) { current.idPlural += s }
+		case keyword == "msgstr" || (strings.HasPrefix(keyword, "msgstr[") && strings.HasSuffix(keyword, "]")):
+			idx := 0
+			if keyword != "msgstr" {
+				idx, err = strconv.Atoi(keyword[len("msgstr[") : len(keyword)-1])
+				if err != nil || idx < 0 {
+					return nil, fmt.Errorf("line %d: invalid plural index %q", lineNo, keyword)
+				}
+			}
+			current.strs[idx] = value
+			appendTo = func(s string) { current.strs[idx] += s }
+			hasStr = true
+		default:
+			return nil, fmt.Errorf("line %d: unknown keyword %q", lineNo, keyword)
+		}
+		started = true
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+
+	return file, nil
+}
+
+func unquotePO(value string) (string, error) {
+	if value == "" {
+		return "", errors.New("missing string literal")
+	}
+	unquoted, err := strconv.Unquote(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid string %s", value)
+	}
+	return unquoted, nil
+}
+
+func parsePOHeaders(raw string) map[string]string {
+	headers := make(map[string]string)
+	for _, line := range strings.Split(raw, "\n") {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		headers[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+	return headers
+}
+
+const (
+	moMagicLittleEndian = 0x950412de
+	moMagicBigEndian    = 0xde120495
+)
+
+// parseMO decodes the binary gettext MO format.
+func parseMO(data []byte) (*poFile, error) {
+	if len(data) < 28 {
+		return nil, errors.New("mo file too short")
+	}
+
+	var order binary.ByteOrder
+	switch binary.LittleEndian.Uint32(data[0:4]) {
+	case moMagicLittleEndian:
+		order = binary.LittleEndian
+	case moMagicBigEndian:
+		order = binary.BigEndian
+	default:
+		return nil, errors.New("invalid mo magic number")
+	}
+
+	count := int(order.Uint32(data[8:12]))
+	originals := int(order.Uint32(data[12:16]))
+	translations := int(order.Uint32(data[16:20]))
+
+	readString := func(table, idx int) (string, error) {
+		offset := table + idx*8
+		if offset+8 > len(data) {
+			return "", errors.New("mo string table out of range")
+		}
+		length := int(order.Uint32(data[offset : offset+4]))
+		start := int(order.Uint32(data[offset+4 : offset+8]))
+		if start < 0 || start+length > len(data) {
+			return "", errors.New("mo string out of range")
+		}
+		return string(data[start : start+length]), nil
+	}
+
+	file := &poFile{headers: make(map[string]string)}
+	for i := 0; i < count; i++ {
+		original, err := readString(originals, i)
+		if err != nil {
+			return nil, err
+		}
+		translation, err := readString(translations, i)
+		if err != nil {
+			return nil, err
+		}
+
+		if original == "" {
+			file.headers = parsePOHeaders(translation)
+			continue
+		}
+
+		entry := &poEntry{strs: make(map[int]string)}
+		if ctx, rest, ok := strings.Cut(original, "\x04"); ok {
+			entry.context, entry.hasContext = ctx, true
+			original = rest
+		}
+		if id, plural, ok := strings.Cut(original, "\x00"); ok {
+			entry.id, entry.idPlural, entry.hasIDPlural = id, plural, true
+		} else {
+			entry.id = original
+		}
+		for idx, str := range strings.Split(translation, "\x00") {
+			entry.strs[idx] = str
+		}
+		file.entries = append(file.entries, entry)
+	}
+
+	return file, nil
+}
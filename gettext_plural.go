@@ -0,0 +1,445 @@
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// pluralFormsSampleLimit bounds the integers used to map gettext plural
+// indexes onto CLDR categories.
+const pluralFormsSampleLimit = 1000
+
+// pluralForms is a compiled gettext Plural-Forms header.
+type pluralForms struct {
+	nplurals int
+	expr     string
+	eval     func(n int64) int64
+}
+
+// parsePluralForms parses headers such as
+// "nplurals=2; plural=(n != 1);".
+func parsePluralForms(header string) (*pluralForms, error) {
+	forms := &pluralForms{}
+	for _, part := range strings.Split(header, ";") {
+		name, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			continue
+		}
+		switch strings.TrimSpace(name) {
+		case "nplurals":
+			n, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid nplurals %q", value)
+			}
+			forms.nplurals = n
+		case "plural":
+			forms.expr = strings.TrimSpace(value)
+		}
+	}
+
+	if forms.nplurals == 0 {
+		return nil, fmt.Errorf("missing nplurals in %q", header)
+	}
+	if forms.expr == "" {
+		forms.expr = "0"
+	}
+
+	eval, err := compilePluralExpression(forms.expr)
+	if err != nil {
+		return nil, fmt.Errorf("plural expression %q: %w", forms.expr, err)
+	}
+	forms.eval = eval
+
+	return forms, nil
+}
+
+// index returns the msgstr index selected for n, clamped to nplurals.
+func (p *pluralForms) index(n int64) int {
+	idx := p.eval(n)
+	if idx < 0 || idx >= int64(p.nplurals) {
+		return p.nplurals - 1
+	}
+	return int(idx)
+}
+
+// categories maps each msgstr index to a PluralCategory. With rules, each index
+// takes the category CLDR selects for most of its sample integers; without
+// rules a heuristic based on the samples is used. The last index doubles as
+// "other" when no index maps to it.
+func (p *pluralForms) categories(rules *PluralRuleSet) []PluralCategory {
+	samples := make([][]int64, p.nplurals)
+	for n := int64(0); n <= pluralFormsSampleLimit; n++ {
+		idx := p.index(n)
+		samples[idx] = append(samples[idx], n)
+	}
+
+	out := make([]PluralCategory, p.nplurals)
+	used := make(map[PluralCategory]bool, p.nplurals)
+	ruleCategories := rules.Categories()
+
+	for idx, values := range samples {
+		var category PluralCategory
+		switch {
+		case len(values) == 0:
+			// unreachable for integers: use the rule set's category at this position
+			if idx < len(ruleCategories) && !used[ruleCategories[idx]] {
+				category = ruleCategories[idx]
+			}
+		case rules != nil:
+			category = majorityCategory(rules, values)
+		default:
+			category = guessCategory(values, idx == p.nplurals-1)
+		}
+		if category == "" || used[category] {
+			category = PluralOther
+			if used[category] {
+				category = ""
+			}
+		}
+		out[idx] = category
+		if category != "" {
+			used[category] = true
+		}
+	}
+
+	return out
+}
+
+func majorityCategory(rules *PluralRuleSet, values []int64) PluralCategory {
+	counts := make(map[PluralCategory]int)
+	for _, n := range values {
+		operands, _, _ := convertSignedInt(n)
+		counts[selectPluralCategory(rules, operands)]++
+	}
+
+	best, bestCount := PluralOther, -1
+	keys := make([]string, 0, len(counts))
+	for category := range counts {
+		keys = append(keys, string(category))
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if counts[PluralCategory(key)] > bestCount {
+			best, bestCount = PluralCategory(key), counts[PluralCategory(key)]
+		}
+	}
+	return best
+}
+
+func guessCategory(values []int64, last bool) PluralCategory {
+	has := func(n int64) bool {
+		for _, v := range values {
+			if v == n {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch {
+	case len(values) == 1 && values[0] == 0:
+		return PluralZero
+	case has(1):
+		return PluralOne
+	case last:
+		return PluralOther
+	case has(2) && !has(3):
+		return PluralTwo
+	case has(3):
+		return PluralFew
+	default:
+		return PluralMany
+	}
+}
+
+// pluralFormsFromRules renders a gettext Plural-Forms header from the integer
+// behaviour of a CLDR rule set. Index order follows Categories().
+func pluralFormsFromRules(rules *PluralRuleSet) (string, []PluralCategory, error) {
+	categories := rules.Categories()
+	if len(categories) == 0 {
+		return "nplurals=2; plural=(n != 1);", []PluralCategory{PluralOne, PluralOther}, nil
+	}
+
+	var expr strings.Builder
+	closing := 0
+	idx := 0
+	for _, rule := range rules.Rules {
+		if rule.Category == PluralOther {
+			continue
+		}
+		cond, err := pluralRuleExpression(rule)
+		if err != nil {
+			return "", nil, err
+		}
+		fmt.Fprintf(&expr, "%s ? %d : (", cond, idx)
+		closing++
+		idx++
+	}
+
+	expr.WriteString(strconv.Itoa(idx))
+	expr.WriteString(strings.Repeat(")", closing))
+
+	ordered := make([]PluralCategory, 0, idx+1)
+	for _, rule := range rules.Rules {
+		if rule.Category != PluralOther {
+			ordered = append(ordered, rule.Category)
+		}
+	}
+	ordered = append(ordered, PluralOther)
+
+	return fmt.Sprintf("nplurals=%d; plural=(%s);", len(ordered), expr.String()), ordered, nil
+}
+
+func pluralRuleExpression(rule PluralRule) (string, error) {
+	if len(rule.Groups) == 0 {
+		return "1", nil
+	}
+
+	groups := make([]string, 0, len(rule.Groups))
+	for _, group := range rule.Groups {
+		conds := make([]string, 0, len(group))
+		for _, condition := range group {
+			cond, err := pluralConditionExpression(condition)
+			if err != nil {
+				return "", err
+			}
+			conds = append(conds, cond)
+		}
+		groups = append(groups, "("+strings.Join(conds, " && ")+")")
+	}
+	return "(" + strings.Join(groups, " || ") + ")", nil
+}
+
+// pluralConditionExpression maps a CLDR condition onto gettext's integer-only
+// n. Fraction operands (v, w, f, t) are always zero for integers.
+func pluralConditionExpression(condition PluralCondition) (string, error) {
+	operand := "n"
+	switch strings.ToLower(condition.Operand) {
+	case "n", "i":
+	case "v", "w", "f", "t":
+		operand = "0"
+	default:
+		return "", fmt.Errorf("unsupported operand %q", condition.Operand)
+	}
+	if condition.Mod > 0 && operand == "n" {
+		operand = fmt.Sprintf("n %% %d", condition.Mod)
+	}
+
+	var terms []string
+	for _, value := range condition.Values {
+		terms = append(terms, fmt.Sprintf("%s == %s", operand, strconv.FormatFloat(value, 'f', -1, 64)))
+	}
+	for _, r := range condition.Ranges {
+		terms = append(terms, fmt.Sprintf("%s >= %s && %s <= %s", operand, strconv.FormatFloat(r.Start, 'f', -1, 64), operand, strconv.FormatFloat(r.End, 'f', -1, 64)))
+	}
+	if len(terms) == 0 {
+		terms = []string{"0"}
+	}
+	membership := "(" + strings.Join(terms, " || ") + ")"
+
+	switch condition.Operator {
+	case OperatorEquals, OperatorIn, OperatorWithin:
+		return membership, nil
+	case OperatorNotEquals, OperatorNotIn, OperatorNotWithin:
+		return "!" + membership, nil
+	default:
+		return "", fmt.Errorf("unsupported operator %q", condition.Operator)
+	}
+}
+
+// compilePluralExpression compiles the C subset used by Plural-Forms:
+// n, integer literals, ?:, ||, &&, comparisons, + - * / % and unary !/-.
+func compilePluralExpression(expr string) (func(int64) int64, error) {
+	p := &pluralExprParser{input: expr}
+	p.next()
+	fn, err := p.ternary()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok != "" {
+		return nil, fmt.Errorf("unexpected %q", p.tok)
+	}
+	return fn, nil
+}
+
+type pluralExprParser struct {
+	input string
+	pos   int
+	tok   string
+}
+
+func (p *pluralExprParser) next() {
+	for p.pos < len(p.input) && (p.input[p.pos] == ' ' || p.input[p.pos] == '\t' || p.input[p.pos] == '\n') {
+		p.pos++
+	}
+	if p.pos >= len(p.input) {
+		p.tok = ""
+		return
+	}
+
+	c := p.input[p.pos]
+	if c >= '0' && c <= '9' {
+		start := p.pos
+		for p.pos < len(p.input) && p.input[p.pos] >= '0' && p.input[p.pos] <= '9' {
+			p.pos++
+		}
+		p.tok = p.input[start:p.pos]
+		return
+	}
+
+	for _, op := range []string{"||", "&&", "==", "!=", "<=", ">="} {
+		if strings.HasPrefix(p.input[p.pos:], op) {
+			p.tok = op
+			p.pos += len(op)
+			return
+		}
+	}
+
+	p.tok = string(c)
+	p.pos++
+}
+
+func (p *pluralExprParser) expect(tok string) error {
+	if p.tok != tok {
+		return fmt.Errorf("expected %q, got %q", tok, p.tok)
+	}
+	p.next()
+	return nil
+}
+
+func (p *pluralExprParser) ternary() (func(int64) int64, error) {
+	cond, err := p.binary(0)
+	if err != nil {
+		return nil, err
+	}
+	if p.tok != "?" {
+		return cond, nil
+	}
+	p.next()
+	then, err := p.ternary()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(":"); err != nil {
+		return nil, err
+	}
+	otherwise, err := p.ternary()
+	if err != nil {
+		return nil, err
+	}
+	return func(n int64) int64 {
+		if cond(n) != 0 {
+			return then(n)
+		}
+		return otherwise(n)
+	}, nil
+}
+
+var pluralExprPrecedence = []map[string]func(a, b int64) int64{
+	{"||": func(a, b int64) int64 { return boolInt(a != 0 || b != 0) }},
+	{"&&": func(a, b int64) int64 { return boolInt(a != 0 && b != 0) }},
+	{
+		"==": func(a, b int64) int64 { return boolInt(a == b) },
+		"!=": func(a, b int64) int64 { return boolInt(a != b) },
+	},
+	{
+		"<":  func(a, b int64) int64 { return boolInt(a < b) },
+		">":  func(a, b int64) int64 { return boolInt(a > b) },
+		"<=": func(a, b int64) int64 { return boolInt(a <= b) },
+		">=": func(a, b int64) int64 { return boolInt(a >= b) },
+	},
+	{
+		"+": func(a, b int64) int64 { return a + b },
+		"-": func(a, b int64) int64 { return a - b },
+	},
+	{
+		"*": func(a, b int64) int64 { return a * b },
+		"/": func(a, b int64) int64 {
+			if b == 0 {
+				return 0
+			}
+			return a / b
+		},
+		"%": func(a, b int64) int64 {
+			if b == 0 {
+				return 0
+			}
+			return a % b
+		},
+	},
+}
+
+func (p *pluralExprParser) binary(level int) (func(int64) int64, error) {
+	if level == len(pluralExprPrecedence) {
+		return p.unary()
+	}
+
+	left, err := p.binary(level + 1)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		op, ok := pluralExprPrecedence[level][p.tok]
+		if !ok {
+			return left, nil
+		}
+		p.next()
+		right, err := p.binary(level + 1)
+		if err != nil {
+			return nil, err
+		}
+		l, r := left, right
+		left = func(n int64) int64 { return op(l(n), r(n)) }
+	}
+}
+
+func (p *pluralExprParser) unary() (func(int64) int64, error) {
+	switch p.tok {
+	case "!":
+		p.next()
+		operand, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return func(n int64) int64 { return boolInt(operand(n) == 0) }, nil
+	case "-":
+		p.next()
+		operand, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return func(n int64) int64 { return -operand(n) }, nil
+	case "(":
+		p.next()
+		inner, err := p.ternary()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return inner, nil
+	case "n":
+		p.next()
+		return func(n int64) int64 { return n }, nil
+	case "":
+		return nil, fmt.Errorf("unexpected end of expression")
+	}
+
+	value, err := strconv.ParseInt(p.tok, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("unexpected %q", p.tok)
+	}
+	p.next()
+	return func(int64) int64 { return value }, nil
+}
+
+func boolInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,235 @@
+package i18n
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+const russianPO = `# Russian catalog
+msgid ""
+msgstr ""
+"Language: ru\n"
+"Plural-Forms: nplurals=3; plural=(n%10==1 && n%100!=11 ? 0 : n%10>=2 && "
+"n%10<=4 && (n%100<10 || n%100>=20) ? 1 : 2);\n"
+
+# Shown on the landing page
+#. extracted note
+#: views/home.html:3
+msgid "home.title"
+msgstr "Добро пожаловать"
+
+msgctxt "menu"
+msgid "open"
+msgstr "Открыть"
+
+#, fuzzy
+msgid "draft"
+msgstr "Черновик"
+
+msgid "untranslated"
+msgstr ""
+
+msgid "cart.items"
+msgid_plural "cart.items"
+msgstr[0] "{count} товар"
+msgstr[1] "{count} товара"
+msgstr[2] "{count} "
+"товаров"
+`
+
+func writeTempFile(t *testing.T, dir, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+	return path
+}
+
+func TestPoLoaderLoadsPOWithPluralRules(t *testing.T) {
+	path := writeTempFile(t, t.TempDir(), "messages.po", []byte(russianPO))
+
+	translations, err := NewPoLoader(path).
+		WithPluralRuleFiles(filepath.Join("testdata", "cldr_cardinal.json")).
+		Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	catalog := translations["ru"]
+	if catalog == nil {
+		t.Fatalf("expected ru catalog from Language header, got %v", translations)
+	}
+
+	keys := make([]string, 0, len(catalog.Messages))
+	for key := range catalog.Messages {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	if strings.Join(keys, ",") != "cart.items,home.title,menu.open" {
+		t.Fatalf("unexpected keys %v", keys)
+	}
+
+	if got := catalog.Messages["home.title"].Description; got != "Shown on the landing page" {
+		t.Fatalf("Description = %q", got)
+	}
+
+	variants := catalog.Messages["cart.items"].Variants
+	want := map[PluralCategory]string{
+		PluralOne:   "{count} товар",
+		PluralFew:   "{count} товара",
+		PluralMany:  "{count} товаров",
+		PluralOther: "{count} товаров",
+	}
+	for category, template := range want {
+		if variants[category].Template != template {
+			t.Fatalf("variant %s = %q want %q", category, variants[category].Template, template)
+		}
+	}
+
+	translator, err := NewSimpleTranslator(NewStaticStore(translations), WithTranslatorDefaultLocale("ru"))
+	if err != nil {
+		t.Fatalf("NewSimpleTranslator: %v", err)
+	}
+	for count, want := range map[int]string{1: "1 товар", 3: "3 товара", 11: "11 товаров", 22: "22 товара"} {
+		got, err := translator.Translate("ru", "cart.items", WithCount(count))
+		if err != nil {
+			t.Fatalf("Translate(%d): %v", count, err)
+		}
+		if got != want {
+			t.Fatalf("Translate(%d) = %q want %q", count, got, want)
+		}
+	}
+}
+
+func TestPoLoaderHeuristicWithoutRules(t *testing.T) {
+	dir := t.TempDir()
+	path := writeTempFile(t, dir, filepath.Join("es_MX", "LC_MESSAGES", "app.po"), []byte(`msgid ""
+msgstr "Plural-Forms: nplurals=2; plural=(n != 1);\n"
+
+msgid "files"
+msgid_plural "files"
+msgstr[0] "%d archivo"
+msgstr[1] "%d archivos"
+`))
+
+	translations, err := NewPoLoader(path).Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	message := translations["es-MX"].Messages["files"]
+	if message.Variants[PluralOne].Template != "%d archivo" || message.Variants[PluralOther].Template != "%d archivos" {
+		t.Fatalf("unexpected variants %#v", message.Variants)
+	}
+}
+
+func TestPoLoaderLoadsMO(t *testing.T) {
+	entries := map[string]string{
+		"":                         "Language: fr\nPlural-Forms: nplurals=2; plural=(n > 1);\n",
+		"home.title":               "Bienvenue",
+		"nav\x04home":              "Accueil",
+		"cart.items\x00cart.items": "{count} article\x00{count} articles",
+	}
+	path := writeTempFile(t, t.TempDir(), "fr.mo", buildMO(entries))
+
+	translations, err := NewPoLoader(path).Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	catalog := translations["fr"]
+	if catalog.Messages["home.title"].Content() != "Bienvenue" {
+		t.Fatalf("home.title = %q", catalog.Messages["home.title"].Content())
+	}
+	if catalog.Messages["nav.home"].Content() != "Accueil" {
+		t.Fatalf("nav.home = %q", catalog.Messages["nav.home"].Content())
+	}
+	variants := catalog.Messages["cart.items"].Variants
+	if variants[PluralOne].Template != "{count} article" || variants[PluralOther].Template != "{count} articles" {
+		t.Fatalf("unexpected plural variants %#v", variants)
+	}
+}
+
+// buildMO encodes entries in the little-endian MO layout.
+func buildMO(entries map[string]string) []byte {
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	n := len(keys)
+	headerSize := 28
+	originals := headerSize
+	translations := originals + n*8
+	dataStart := translations + n*8
+
+	var table, blob bytes.Buffer
+	offsets := make([][2]uint32, 0, 2*n)
+	for _, text := range append(append([]string(nil), keys...), valuesOf(entries, keys)...) {
+		offsets = append(offsets, [2]uint32{uint32(len(text)), uint32(dataStart + blob.Len())})
+		blob.WriteString(text)
+		blob.WriteByte(0)
+	}
+	for _, entry := range offsets {
+		binary.Write(&table, binary.LittleEndian, entry[0])
+		binary.Write(&table, binary.LittleEndian, entry[1])
+	}
+
+	var out bytes.Buffer
+	for _, value := range []uint32{moMagicLittleEndian, 0, uint32(n), uint32(originals), uint32(translations), 0, 0} {
+		binary.Write(&out, binary.LittleEndian, value)
+	}
+	out.Write(table.Bytes())
+	out.Write(blob.Bytes())
+	return out.Bytes()
+}
+
+func valuesOf(entries map[string]string, keys []string) []string {
+	values := make([]string, len(keys))
+	for i, key := range keys {
+		values[i] = entries[key]
+	}
+	return values
+}
+
+func TestCompilePluralExpression(t *testing.T) {
+	tests := []struct {
+		expr string
+		n    int64
+		want int64
+	}{
+		{expr: "n != 1", n: 1, want: 0},
+		{expr: "n != 1", n: 0, want: 1},
+		{expr: "n > 1", n: 1, want: 0},
+		{expr: "n==1 ? 0 : n==2 ? 1 : (n>2 && n<7) ? 2 : 3", n: 5, want: 2},
+		{expr: "n==1 ? 0 : n==2 ? 1 : (n>2 && n<7) ? 2 : 3", n: 9, want: 3},
+		{expr: "!(n % 10 == 1) * 2 + -1", n: 21, want: -1},
+		{expr: "0", n: 42, want: 0},
+	}
+
+	for _, tc := range tests {
+		fn, err := compilePluralExpression(tc.expr)
+		if err != nil {
+			t.Fatalf("compile %q: %v", tc.expr, err)
+		}
+		if got := fn(tc.n); got != tc.want {
+			t.Fatalf("%q with n=%d = %d want %d", tc.expr, tc.n, got, tc.want)
+		}
+	}
+
+	for _, expr := range []string{"n ==", "(n", "x > 1", "n ? 1"} {
+		if _, err := compilePluralExpression(expr); err == nil {
+			t.Fatalf("expected error for %q", expr)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package i18n
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// POWriterOption configures WritePO
+type POWriterOption func(*poWriter)
+
+type poWriter struct {
+	template bool
+	headers  map[string]string
+}
+
+// WithPOTemplate writes a .pot template: msgstr values are left empty and
+// the source text is emitted as an extracted comment.
+func WithPOTemplate() POWriterOption {
+	return func(w *poWriter) {
+		w.template = true
+	}
+}
+
+// WithPOHeader adds or overrides a header in the PO header entry.
+func WithPOHeader(name, value string) POWriterOption {
+	return func(w *poWriter) {
+		if name != "" {
+			w.headers[name] = value
+		}
+	}
+}
+
+// WritePOT exports catalog as a PO template (.pot).
+func WritePOT(w io.Writer, catalog *TranslationCatalog, opts ...POWriterOption) error {
+	return WritePO(w, catalog, append([]POWriterOption{WithPOTemplate()}, opts...)...)
+}
+
+// WritePO exports catalog in GNU gettext PO format. Message keys are used as
+// msgid, descriptions become translator comments and plural variants are
+// written as msgstr[n] following a Plural-Forms header derived from the
+// catalog's cardinal rules.
+func WritePO(w io.Writer, catalog *TranslationCatalog, opts ...POWriterOption) error {
+	if catalog == nil {
+		return errors.New("i18n: nil catalog")
+	}
+
+	writer := &poWriter{headers: make(map[string]string)}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(writer)
+		}
+	}
+
+	header, categories, err := writer.pluralForms(catalog)
+	if err != nil {
+		return err
+	}
+
+	out := bufio.NewWriter(w)
+
+	headers := map[string]string{
+		"MIME-Version":              "1.0",
+		"Content-Type":              "text/plain; charset=UTF-8",
+		"Content-Transfer-Encoding": "8bit",
+		"Plural-Forms":              header,
+	}
+	if !writer.template {
+		headers["Language"] = strings.ReplaceAll(catalog.Locale.Code, "-", "_")
+	}
+	for name, value := range writer.headers {
+		headers[name] = value
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var headerText strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&headerText, "%s: %s\n", name, headers[name])
+	}
+	writePOString(out, "msgid", "")
+	writePOString(out, "msgstr", headerText.String())
+
+	keys := make([]string, 0, len(catalog.Messages))
+	for key := range catalog.Messages {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		message := catalog.Messages[key]
+		out.WriteString("\n")
+
+		if message.Description != "" {
+			for _, line := range strings.Split(message.Description, "\n") {
+				fmt.Fprintf(out, "# %s\n", line)
+			}
+		}
+		if writer.template {
+			for _, line := range strings.Split(message.Content(), "\n") {
+				fmt.Fprintf(out, "#. %s\n", line)
+			}
+		}
+
+		writePOString(out, "msgid", key)
+
+		if len(message.Variants) < 2 {
+			writePOString(out, "msgstr", writer.value(message.Content()))
+			continue
+		}
+
+		writePOString(out, "msgid_plural", key)
+		for idx, category := range categories {
+			variant, ok := message.Variants[category]
+			if !ok {
+				variant, _ = message.Variant(PluralOther)
+			}
+			writePOString(out, "msgstr["+strconv.Itoa(idx)+"]", writer.value(variant.Template))
+		}
+	}
+
+	return out.Flush()
+}
+
+func (w *poWriter) value(text string) string {
+	if w.template {
+		return ""
+	}
+	return text
+}
+
+// pluralForms returns the Plural-Forms header and msgstr index order. Without
+// cardinal rules only one/other plurals can be expressed.
+func (w *poWriter) pluralForms(catalog *TranslationCatalog) (string, []PluralCategory, error) {
+	if catalog.CardinalRules != nil && len(catalog.CardinalRules.Rules) > 0 {
+		return pluralFormsFromRules(catalog.CardinalRules)
+	}
+
+	for key, message := range catalog.Messages {
+		for category := range message.Variants {
+			if category.IsExact() {
+				continue
+			}
+			if category != PluralOne && category != PluralOther {
+				return "", nil, fmt.Errorf("i18n: %s/%s uses plural category %q but the catalog has no plural rules", catalog.Locale.Code, key, category)
+			}
+		}
+	}
+
+	return pluralFormsFromRules(nil)
+}
+
+// writePOString writes keyword "value", splitting multi-line values the way
+// gettext tools do.
+func writePOString(out *bufio.Writer, keyword, value string) {
+	lines := strings.SplitAfter(value, "\n")
+	if len(lines) > 1 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) <= 1 {
+		fmt.Fprintf(out, "%s %s\n", keyword, strconv.Quote(value))
+		return
+	}
+
+	fmt.Fprintf(out, "%s \"\"\n", keyword)
+	for _, line := range lines {
+		fmt.Fprintf(out, "%s\n", strconv.Quote(line))
+	}
+}
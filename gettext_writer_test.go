@@ -0,0 +1,97 @@
+package i18n
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWritePORoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	rulesPath := filepath.Join("testdata", "cldr_cardinal.json")
+	source := writeTempFile(t, dir, "ru.po", []byte(russianPO))
+
+	translations, err := NewPoLoader(source).WithPluralRuleFiles(rulesPath).Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := WritePO(&buf, translations["ru"]); err != nil {
+		t.Fatalf("WritePO: %v", err)
+	}
+
+	output := buf.String()
+	for _, want := range []string{
+		`"Language: ru\n"`,
+		`"Plural-Forms: nplurals=4; plural=`,
+		"# Shown on the landing page\nmsgid \"home.title\"",
+		`msgid_plural "cart.items"`,
+		`msgstr[2] "{count} товаров"`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("expected %q in output:\n%s", want, output)
+		}
+	}
+
+	path := writeTempFile(t, dir, filepath.Join("out", "messages.po"), buf.Bytes())
+	reloaded, err := NewPoLoader(path).WithPluralRuleFiles(rulesPath).Load()
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+
+	for key, message := range translations["ru"].Messages {
+		got, ok := reloaded["ru"].Messages[key]
+		if !ok {
+			t.Fatalf("missing %s after round trip", key)
+		}
+		for category, variant := range message.Variants {
+			if got.Variants[category].Template != variant.Template {
+				t.Fatalf("%s[%s] = %q want %q", key, category, got.Variants[category].Template, variant.Template)
+			}
+		}
+	}
+}
+
+func TestWritePOT(t *testing.T) {
+	catalog := &TranslationCatalog{
+		Locale: Locale{Code: "en"},
+		Messages: map[string]Message{
+			"home.title": {MessageMetadata: MessageMetadata{ID: "home.title", Locale: "en"}, Variants: map[PluralCategory]MessageVariant{
+				PluralOther: {Template: "Welcome\nhome"},
+			}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := WritePOT(&buf, catalog); err != nil {
+		t.Fatalf("WritePOT: %v", err)
+	}
+
+	output := buf.String()
+	if strings.Contains(output, "Language:") {
+		t.Fatalf("template should not declare a language:\n%s", output)
+	}
+	want := "#. Welcome\n#. home\nmsgid \"home.title\"\nmsgstr \"\"\n"
+	if !strings.Contains(output, want) {
+		t.Fatalf("expected %q in output:\n%s", want, output)
+	}
+}
+
+func TestWritePORequiresRulesForExtraCategories(t *testing.T) {
+	catalog := &TranslationCatalog{
+		Locale: Locale{Code: "ru"},
+		Messages: map[string]Message{
+			"items": {Variants: map[PluralCategory]MessageVariant{
+				PluralOne:   {Template: "one"},
+				PluralFew:   {Template: "few"},
+				PluralOther: {Template: "other"},
+			}},
+		},
+	}
+
+	if err := WritePO(&bytes.Buffer{}, catalog); err == nil {
+		t.Fatalf("expected error without plural rules")
+	}
+}
@@ -6,4 +6,4 @@ toolchain go1.24.7
 
 require golang.org/x/text v0.29.0
 
-require gopkg.in/yaml.v3 v3.0.1 // indirect
+require gopkg.in/yaml.v3 v3.0.1
@@ -0,0 +1,367 @@
+// Package i18nhttp provides net/http middleware that negotiates the request
+// locale and stores it on the request context for i18n.ContextTranslator.
+package i18nhttp
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"golang.org/x/text/language"
+
+	"github.com/goliatone/go-i18n"
+)
+
+// Source identifies which part of the request selected the locale.
+type Source string
+
+const (
+	SourcePath    Source = "path"
+	SourceQuery   Source = "query"
+	SourceCookie  Source = "cookie"
+	SourceHeader  Source = "header"
+	SourceDefault Source = "default"
+)
+
+const (
+	defaultQueryParam = "lang"
+	defaultCookieName = "lang"
+)
+
+// Negotiator resolves request locales against a fixed set of supported locales.
+type Negotiator struct {
+	locales       []string
+	index         map[string]string
+	matcher       language.Matcher
+	defaultLocale string
+
+	queryParam   string
+	cookieName   string
+	acceptHeader bool
+	pathPrefix   bool
+	redirect     bool
+	redirectCode int
+}
+
+// Option configures a Negotiator.
+type Option func(*Negotiator)
+
+// WithDefaultLocale sets the locale used when nothing in the request matches.
+// It defaults to the first supported locale.
+func WithDefaultLocale(locale string) Option {
+	return func(n *Negotiator) {
+		n.defaultLocale = locale
+	}
+}
+
+// WithQueryParam sets the query parameter inspected for a locale ("lang" by
+// default). An empty name disables query negotiation.
+func WithQueryParam(name string) Option {
+	return func(n *Negotiator) {
+		n.queryParam = name
+	}
+}
+
+// WithCookie sets the cookie inspected for a locale ("lang" by default). An
+// empty name disables cookie negotiation.
+func WithCookie(name string) Option {
+	return func(n *Negotiator) {
+		n.cookieName = name
+	}
+}
+
+// WithAcceptLanguage toggles Accept-Language negotiation (enabled by default).
+func WithAcceptLanguage(enabled bool) Option {
+	return func(n *Negotiator) {
+		n.acceptHeader = enabled
+	}
+}
+
+// WithPathPrefix enables locale detection from the first path segment
+// (e.g. /es/products). The prefix is stripped before calling the next handler.
+func WithPathPrefix() Option {
+	return func(n *Negotiator) {
+		n.pathPrefix = true
+	}
+}
+
+// WithRedirect redirects GET and HEAD requests without a canonical locale
+// prefix to /{locale}/... using the given status code (302 when zero). It
+// implies WithPathPrefix.
+func WithRedirect(code int) Option {
+	return func(n *Negotiator) {
+		if code == 0 {
+			code = http.StatusFound
+		}
+		n.pathPrefix = true
+		n.redirect = true
+		n.redirectCode = code
+	}
+}
+
+// New builds a Negotiator for the supported locales.
+func New(locales []string, opts ...Option) *Negotiator {
+	n := &Negotiator{
+		index:        make(map[string]string, len(locales)),
+		queryParam:   defaultQueryParam,
+		cookieName:   defaultCookieName,
+		acceptHeader: true,
+	}
+
+	for _, locale := range locales {
+		code := strings.ReplaceAll(strings.TrimSpace(locale), "_", "-")
+		if code == "" {
+			continue
+		}
+		if _, exists := n.index[strings.ToLower(code)]; exists {
+			continue
+		}
+		n.index[strings.ToLower(code)] = code
+		n.locales = append(n.locales, code)
+	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			opt(n)
+		}
+	}
+
+	if n.defaultLocale == "" && len(n.locales) > 0 {
+		n.defaultLocale = n.locales[0]
+	}
+	if code, ok := n.index[strings.ToLower(n.defaultLocale)]; ok {
+		n.defaultLocale = code
+	}
+
+	n.buildMatcher()
+
+	return n
+}
+
+// FromConfig builds a Negotiator from the active locales of cfg's
+// LocaleCatalog, falling back to cfg.Locales, and cfg.DefaultLocale.
+func FromConfig(cfg *i18n.Config, opts ...Option) *Negotiator {
+	if cfg == nil {
+		return New(nil, opts...)
+	}
+
+	locales := cfg.Locales
+	if catalog := cfg.LocaleCatalog(); catalog != nil {
+		if codes := catalog.ActiveLocaleCodes(); len(codes) > 0 {
+			locales = codes
+		}
+	}
+
+	base := []Option{WithDefaultLocale(cfg.DefaultLocale)}
+	return New(locales, append(base, opts...)...)
+}
+
+// buildMatcher orders the default locale first so the matcher falls back to it.
+func (n *Negotiator) buildMatcher() {
+	codes := make([]string, 0, len(n.locales))
+	if n.defaultLocale != "" {
+		codes = append(codes, n.defaultLocale)
+	}
+	for _, code := range n.locales {
+		if code != n.defaultLocale {
+			codes = append(codes, code)
+		}
+	}
+
+	tags := make([]language.Tag, 0, len(codes))
+	supported := make([]string, 0, len(codes))
+	for _, code := range codes {
+		tag, err := language.Parse(code)
+		if err != nil {
+			continue
+		}
+		tags = append(tags, tag)
+		supported = append(supported, code)
+	}
+
+	n.locales = supported
+	if len(tags) > 0 {
+		n.matcher = language.NewMatcher(tags)
+	}
+}
+
+// Locales returns the supported locales, default first.
+func (n *Negotiator) Locales() []string {
+	if n == nil {
+		return nil
+	}
+	return append([]string(nil), n.locales...)
+}
+
+// DefaultLocale returns the locale used when negotiation finds no match.
+func (n *Negotiator) DefaultLocale() string {
+	if n == nil {
+		return ""
+	}
+	return n.defaultLocale
+}
+
+// Negotiate resolves the locale for r, checking the path prefix, query
+// parameter, cookie and Accept-Language header in that order.
+func (n *Negotiator) Negotiate(r *http.Request) (string, Source) {
+	if n == nil || r == nil {
+		return "", SourceDefault
+	}
+
+	if n.pathPrefix {
+		if locale, _, ok := n.pathLocale(r.URL.Path); ok {
+			return locale, SourcePath
+		}
+	}
+
+	if n.queryParam != "" {
+		if locale, ok := n.match(r.URL.Query().Get(n.queryParam)); ok {
+			return locale, SourceQuery
+		}
+	}
+
+	if n.cookieName != "" {
+		if cookie, err := r.Cookie(n.cookieName); err == nil {
+			if locale, ok := n.match(cookie.Value); ok {
+				return locale, SourceCookie
+			}
+		}
+	}
+
+	if n.acceptHeader && n.matcher != nil {
+		if header := r.Header.Get("Accept-Language"); header != "" {
+			tags, _, err := language.ParseAcceptLanguage(header)
+			if err == nil && len(tags) > 0 {
+				if _, idx, confidence := n.matcher.Match(tags...); confidence != language.No {
+					return n.locales[idx], SourceHeader
+				}
+			}
+		}
+	}
+
+	return n.defaultLocale, SourceDefault
+}
+
+// match resolves a single locale value, preferring exact matches.
+func (n *Negotiator) match(value string) (string, bool) {
+	value = strings.ReplaceAll(strings.TrimSpace(value), "_", "-")
+	if value == "" {
+		return "", false
+	}
+	if code, ok := n.index[strings.ToLower(value)]; ok {
+		return code, true
+	}
+	if n.matcher == nil {
+		return "", false
+	}
+	tag, err := language.Parse(value)
+	if err != nil {
+		return "", false
+	}
+	if _, idx, confidence := n.matcher.Match(tag); confidence != language.No {
+		return n.locales[idx], true
+	}
+	return "", false
+}
+
+// pathLocale reports the supported locale in the first path segment and the
+// remaining path. Only exact (case-insensitive) matches are accepted.
+func (n *Negotiator) pathLocale(path string) (string, string, bool) {
+	trimmed := strings.TrimPrefix(path, "/")
+	segment, rest, found := strings.Cut(trimmed, "/")
+	code, ok := n.index[strings.ToLower(strings.ReplaceAll(segment, "_", "-"))]
+	if !ok {
+		return "", "", false
+	}
+	if found {
+		rest = "/" + rest
+	} else {
+		rest = "/"
+	}
+	return code, rest, true
+}
+
+// Middleware stores the negotiated locale on the request context (see
+// i18n.LocaleFromContext), sets Content-Language and Vary, and applies the
+// configured path prefix handling.
+func (n *Negotiator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		locale, source := n.Negotiate(r)
+
+		if n.pathPrefix {
+			_, rest, hasPrefix := n.pathLocale(r.URL.Path)
+			canonical := hasPrefix && strings.HasPrefix(r.URL.Path, "/"+locale)
+			if n.redirect && locale != "" && !canonical && (r.Method == http.MethodGet || r.Method == http.MethodHead) {
+				if !hasPrefix {
+					rest = r.URL.Path
+				}
+				http.Redirect(w, r, n.localizedURL(r, locale, rest), n.redirectCode)
+				return
+			}
+			if hasPrefix {
+				r = stripPath(r, rest)
+			}
+		}
+
+		header := w.Header()
+		if locale != "" {
+			header.Set("Content-Language", locale)
+		}
+		if n.acceptHeader {
+			header.Add("Vary", "Accept-Language")
+		}
+		if n.cookieName != "" {
+			header.Add("Vary", "Cookie")
+		}
+
+		ctx := i18n.ContextWithLocale(r.Context(), locale)
+		ctx = context.WithValue(ctx, sourceContextKey{}, source)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// localizedURL builds the canonical /{locale}/... URL, dropping the locale
+// query parameter since the path now carries it.
+func (n *Negotiator) localizedURL(r *http.Request, locale, path string) string {
+	u := *r.URL
+	u.Path = "/" + locale + path
+	u.RawPath = ""
+	if n.queryParam != "" {
+		query := u.Query()
+		if query.Has(n.queryParam) {
+			query.Del(n.queryParam)
+			u.RawQuery = query.Encode()
+		}
+	}
+	return u.RequestURI()
+}
+
+func stripPath(r *http.Request, path string) *http.Request {
+	r2 := new(http.Request)
+	*r2 = *r
+	u := *r.URL
+	u.Path = path
+	u.RawPath = ""
+	r2.URL = &u
+	return r2
+}
+
+type sourceContextKey struct{}
+
+// LocaleFromRequest returns the locale negotiated by the middleware.
+func LocaleFromRequest(r *http.Request) string {
+	if r == nil {
+		return ""
+	}
+	locale, _ := i18n.LocaleFromContext(r.Context())
+	return locale
+}
+
+// SourceFromContext reports which part of the request selected the locale.
+func SourceFromContext(ctx context.Context) (Source, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	source, ok := ctx.Value(sourceContextKey{}).(Source)
+	return source, ok
+}
@@ -0,0 +1,172 @@
+package i18nhttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/goliatone/go-i18n"
+)
+
+func TestNegotiatorNegotiate(t *testing.T) {
+	negotiator := New([]string{"en", "es", "pt-BR"}, WithPathPrefix())
+
+	tests := []struct {
+		name       string
+		target     string
+		cookie     string
+		accept     string
+		wantLocale string
+		wantSource Source
+	}{
+		{name: "default", target: "/", wantLocale: "en", wantSource: SourceDefault},
+		{name: "path prefix", target: "/es/products", wantLocale: "es", wantSource: SourcePath},
+		{name: "path prefix case insensitive", target: "/PT-br", wantLocale: "pt-BR", wantSource: SourcePath},
+		{name: "unknown path segment", target: "/about?lang=es", wantLocale: "es", wantSource: SourceQuery},
+		{name: "query regional match", target: "/?lang=es-MX", wantLocale: "es", wantSource: SourceQuery},
+		{name: "query beats cookie", target: "/?lang=es", cookie: "pt-BR", wantLocale: "es", wantSource: SourceQuery},
+		{name: "cookie", target: "/", cookie: "pt_BR", wantLocale: "pt-BR", wantSource: SourceCookie},
+		{name: "invalid query falls through", target: "/?lang=zz-invalid-", accept: "es", wantLocale: "es", wantSource: SourceHeader},
+		{name: "accept language q values", target: "/", accept: "fr;q=0.9, pt-BR;q=0.8, es;q=0.7", wantLocale: "pt-BR", wantSource: SourceHeader},
+		{name: "accept language no match", target: "/", accept: "ja", wantLocale: "en", wantSource: SourceDefault},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tc.target, nil)
+			if tc.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: "lang", Value: tc.cookie})
+			}
+			if tc.accept != "" {
+				req.Header.Set("Accept-Language", tc.accept)
+			}
+
+			locale, source := negotiator.Negotiate(req)
+			if locale != tc.wantLocale || source != tc.wantSource {
+				t.Fatalf("Negotiate() = %q/%s want %q/%s", locale, source, tc.wantLocale, tc.wantSource)
+			}
+		})
+	}
+}
+
+func TestMiddlewareStoresLocaleAndHeaders(t *testing.T) {
+	negotiator := New([]string{"en", "es"}, WithPathPrefix())
+
+	var (
+		gotLocale string
+		gotPath   string
+		gotSource Source
+	)
+	handler := negotiator.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotLocale = LocaleFromRequest(r)
+		gotPath = r.URL.Path
+		gotSource, _ = SourceFromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/es/cart", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if gotLocale != "es" || gotPath != "/cart" || gotSource != SourcePath {
+		t.Fatalf("handler saw locale=%q path=%q source=%s", gotLocale, gotPath, gotSource)
+	}
+	if got := rec.Header().Get("Content-Language"); got != "es" {
+		t.Fatalf("Content-Language = %q", got)
+	}
+	if vary := rec.Header().Values("Vary"); len(vary) != 2 || vary[0] != "Accept-Language" || vary[1] != "Cookie" {
+		t.Fatalf("Vary = %v", vary)
+	}
+}
+
+func TestMiddlewareRedirectsToCanonicalURL(t *testing.T) {
+	negotiator := New([]string{"en", "es"}, WithRedirect(http.StatusTemporaryRedirect))
+	handler := negotiator.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	tests := []struct {
+		name     string
+		method   string
+		target   string
+		accept   string
+		wantCode int
+		wantLoc  string
+	}{
+		{name: "missing prefix uses header", method: http.MethodGet, target: "/cart?id=1", accept: "es", wantCode: http.StatusTemporaryRedirect, wantLoc: "/es/cart?id=1"},
+		{name: "query moves into path", method: http.MethodGet, target: "/cart?lang=es&id=1", wantCode: http.StatusTemporaryRedirect, wantLoc: "/es/cart?id=1"},
+		{name: "non canonical case", method: http.MethodGet, target: "/ES/cart", wantCode: http.StatusTemporaryRedirect, wantLoc: "/es/cart"},
+		{name: "canonical passes through", method: http.MethodGet, target: "/en/cart", wantCode: http.StatusNoContent},
+		{name: "post is not redirected", method: http.MethodPost, target: "/cart", wantCode: http.StatusNoContent},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.target, nil)
+			if tc.accept != "" {
+				req.Header.Set("Accept-Language", tc.accept)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tc.wantCode {
+				t.Fatalf("status = %d want %d", rec.Code, tc.wantCode)
+			}
+			if got := rec.Header().Get("Location"); got != tc.wantLoc {
+				t.Fatalf("Location = %q want %q", got, tc.wantLoc)
+			}
+		})
+	}
+}
+
+func TestFromConfigUsesCatalogAndDefault(t *testing.T) {
+	cfg, err := i18n.NewConfig(
+		i18n.WithLocales("en", "es", "el"),
+		i18n.WithDefaultLocale("es"),
+	)
+	if err != nil {
+		t.Fatalf("NewConfig: %v", err)
+	}
+
+	negotiator := FromConfig(cfg, WithCookie(""))
+	if negotiator.DefaultLocale() != "es" {
+		t.Fatalf("DefaultLocale() = %q", negotiator.DefaultLocale())
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(&http.Cookie{Name: "lang", Value: "el"})
+	if locale, source := negotiator.Negotiate(req); locale != "es" || source != SourceDefault {
+		t.Fatalf("disabled cookie should be ignored, got %q/%s", locale, source)
+	}
+
+	req.Header.Set("Accept-Language", "el-GR")
+	if locale, _ := negotiator.Negotiate(req); locale != "el" {
+		t.Fatalf("Negotiate() = %q want el", locale)
+	}
+}
+
+func TestMiddlewareFeedsContextTranslator(t *testing.T) {
+	store := i18n.NewStaticStore(i18n.Translations{
+		"en": {Locale: i18n.Locale{Code: "en"}, Messages: map[string]i18n.Message{
+			"home.title": {Variants: map[i18n.PluralCategory]i18n.MessageVariant{i18n.PluralOther: {Template: "Welcome"}}},
+		}},
+		"es": {Locale: i18n.Locale{Code: "es"}, Messages: map[string]i18n.Message{
+			"home.title": {Variants: map[i18n.PluralCategory]i18n.MessageVariant{i18n.PluralOther: {Template: "Bienvenido"}}},
+		}},
+	})
+	translator, err := i18n.NewSimpleTranslator(store, i18n.WithTranslatorDefaultLocale("en"))
+	if err != nil {
+		t.Fatalf("NewSimpleTranslator: %v", err)
+	}
+
+	var got string
+	handler := New([]string{"en", "es"}).Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = translator.TranslateContext(r.Context(), "home.title")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/?lang=es", nil)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if got != "Bienvenido" {
+		t.Fatalf("TranslateContext() = %q", got)
+	}
+}
@@ -1,17 +1,23 @@
 package i18n
 
 import (
+	"bytes"
 	"crypto/sha1"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/fs"
+	"maps"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
 
+	"golang.org/x/text/language"
 	"gopkg.in/yaml.v3"
 )
 
@@ -20,12 +26,22 @@ var placeholderPattern = regexp.MustCompile(`\{([a-zA-Z0-9_]+)\}`)
 type FileLoader struct {
 	paths     []string
 	rulePaths []string
+	fsys      fs.FS
+	locales   catalogLocales
 }
 
 func NewFileLoader(paths ...string) *FileLoader {
 	return &FileLoader{paths: append([]string(nil), paths...)}
 }
 
+// NewFSLoader reads catalogs from fsys, e.g. an embed.FS. Each pattern is a
+// file, a directory searched recursively for .json/.yaml/.yml files, or a
+// fs.Glob pattern. Plural rule files added with WithPluralRuleFiles are read
+// from the same filesystem.
+func NewFSLoader(fsys fs.FS, patterns ...string) *FileLoader {
+	return &FileLoader{fsys: fsys, paths: append([]string(nil), patterns...)}
+}
+
 func (l *FileLoader) WithPluralRuleFiles(paths ...string) *FileLoader {
 	if l == nil {
 		return l
@@ -37,25 +53,102 @@ func (l *FileLoader) WithPluralRuleFiles(paths ...string) *FileLoader {
 	return l
 }
 
+// WithCatalogLocales adds locales that may be inferred from file and
+// directory names on top of the CLDR locale list, e.g. private use locales.
+func (l *FileLoader) WithCatalogLocales(locales ...string) *FileLoader {
+	if l == nil {
+		return l
+	}
+	for _, locale := range locales {
+		if locale = normalizeLocale(locale); locale != "" {
+			if l.locales.known == nil {
+				l.locales.known = make(map[string]string)
+			}
+			l.locales.known[strings.ToLower(locale)] = locale
+		}
+	}
+	return l
+}
+
+// WithSingleLocaleCatalogs reads every file as the catalog of one locale,
+// keyed by message, instead of mapping locales to messages at the top level.
+// The locale comes from WithCatalogLocale or the file or directory name.
+func (l *FileLoader) WithSingleLocaleCatalogs() *FileLoader {
+	if l == nil {
+		return l
+	}
+	l.locales.single = true
+	return l
+}
+
+// WithCatalogLocale reads a file as the catalog of locale, keyed by message,
+// when its path, file name, file name without extension or parent directory
+// equals name ("locales/app.json", "app.json", "app", "spanish"). Explicit
+// names take precedence over inference.
+func (l *FileLoader) WithCatalogLocale(name, locale string) *FileLoader {
+	if l == nil || name == "" {
+		return l
+	}
+	if l.locales.names == nil {
+		l.locales.names = make(map[string]string)
+	}
+	l.locales.names[filepath.ToSlash(name)] = normalizeLocale(locale)
+	return l
+}
+
+// clone copies the loader so config wiring can add locales without touching
+// the caller's value.
+func (l *FileLoader) clone() *FileLoader {
+	if l == nil {
+		return nil
+	}
+	return &FileLoader{
+		paths:     slices.Clone(l.paths),
+		rulePaths: slices.Clone(l.rulePaths),
+		fsys:      l.fsys,
+		locales: catalogLocales{
+			known:  maps.Clone(l.locales.known),
+			names:  maps.Clone(l.locales.names),
+			single: l.locales.single,
+		},
+	}
+}
+
 // WithPluralRules satisfies the pluralRuleLoader contract used by config wiring.
 func (l *FileLoader) WithPluralRules(paths ...string) Loader {
 	return l.WithPluralRuleFiles(paths...)
 }
 
+// watchedPaths lists the translation and plural rule files read by Load.
+// Filesystem-backed loaders are not watched.
+func (l *FileLoader) watchedPaths() []string {
+	if l == nil || l.fsys != nil {
+		return nil
+	}
+	paths := make([]string, 0, len(l.paths)+len(l.rulePaths))
+	paths = append(paths, l.paths...)
+	return append(paths, l.rulePaths...)
+}
+
 func (l *FileLoader) Load() (Translations, error) {
 	if l == nil || len(l.paths) == 0 {
 		return nil, errors.New("i18n: no loader paths configured")
 	}
 
+	paths, err := l.resolvePaths()
+	if err != nil {
+		return nil, err
+	}
+
 	buckets := make(map[string]map[string]Message)
 
-	for _, path := range l.paths {
-		data, err := os.ReadFile(path)
+	for _, path := range paths {
+		data, err := readFileFS(l.fsys, path)
 		if err != nil {
 			return nil, fmt.Errorf("i18n: read %s: %w", path, err)
 		}
 
-		src, err := decodeTranslationFile(path, data)
+		src, err := decodeTranslationFile(path, data, &l.locales)
 		if err != nil {
 			return nil, fmt.Errorf("i18n: decode %s: %w", path, err)
 		}
@@ -67,6 +160,12 @@ func (l *FileLoader) Load() (Translations, error) {
 		return nil, err
 	}
 
+	return buildCatalogs(buckets, rules), nil
+}
+
+// buildCatalogs assembles per-locale catalogs from merged message buckets and
+// attaches the matching cardinal and ordinal rule sets.
+func buildCatalogs(buckets map[string]map[string]Message, rules pluralRuleSets) Translations {
 	catalogs := make(Translations, len(buckets))
 	for locale, messages := range buckets {
 		catalog := &TranslationCatalog{
@@ -75,7 +174,10 @@ func (l *FileLoader) Load() (Translations, error) {
 		if len(messages) > 0 {
 			catalog.Messages = messages
 		}
-		if ruleSet, ok := rules[locale]; ok {
+		if ruleSet, ok := rules.ordinal[locale]; ok {
+			catalog.OrdinalRules = ruleSet
+		}
+		if ruleSet, ok := rules.cardinal[locale]; ok {
 			catalog.CardinalRules = ruleSet
 			if ruleSet.DisplayName != "" {
 				catalog.Locale.Name = ruleSet.DisplayName
@@ -87,28 +189,45 @@ func (l *FileLoader) Load() (Translations, error) {
 		catalogs[locale] = catalog
 	}
 
-	return catalogs, nil
+	return catalogs
 }
 
-func decodeTranslationFile(path string, data []byte) (map[string]map[string]Message, error) {
+func decodeTranslationFile(path string, data []byte, locales *catalogLocales) (map[string]map[string]Message, error) {
 	ext := strings.ToLower(filepath.Ext(path))
 
 	switch ext {
 	case ".json":
-		return decodeTranslationsJSON(path, data)
+		return decodeTranslationsJSON(path, data, locales)
 	case ".yaml", ".yml":
-		return decodeTranslationsYAML(path, string(data))
+		return decodeTranslationsYAML(path, string(data), locales)
 	default:
 		return nil, fmt.Errorf("unsupported extension %s", ext)
 	}
 }
 
-func decodeTranslationsJSON(path string, data []byte) (map[string]map[string]Message, error) {
-	var raw map[string]map[string]json.RawMessage
-	if err := json.Unmarshal(data, &raw); err != nil {
+func decodeTranslationsJSON(path string, data []byte, locales *catalogLocales) (map[string]map[string]Message, error) {
+	var top map[string]json.RawMessage
+	if err := json.Unmarshal(data, &top); err != nil {
 		return nil, err
 	}
 
+	catalogLocale, single, err := locales.fileLocale(path)
+	if err != nil {
+		return nil, err
+	}
+	raw := make(map[string]map[string]json.RawMessage, len(top))
+	if single {
+		raw[catalogLocale] = top
+	} else {
+		for locale, value := range top {
+			var catalog map[string]json.RawMessage
+			if err := json.Unmarshal(value, &catalog); err != nil {
+				return nil, fmt.Errorf("%s: %w", locale, err)
+			}
+			raw[locale] = catalog
+		}
+	}
+
 	result := make(map[string]map[string]Message, len(raw))
 	for locale, catalog := range raw {
 		if locale == "" {
@@ -120,6 +239,9 @@ func decodeTranslationsJSON(path string, data []byte) (map[string]map[string]Mes
 				return nil, fmt.Errorf("i18n: empty key in %s/%s", locale, path)
 			}
 			message, err := buildMessageFromJSON(locale, key, rawMessage, path)
+			if errors.Is(err, errUntranslatedStub) {
+				continue
+			}
 			if err != nil {
 				return nil, fmt.Errorf("%s/%s: %w", locale, key, err)
 			}
@@ -130,21 +252,51 @@ func decodeTranslationsJSON(path string, data []byte) (map[string]map[string]Mes
 	return result, nil
 }
 
+// errUntranslatedStub marks a message with a null template, as written by
+// MissingTranslationCollector.WriteJSON. Loaders skip such messages instead
+// of loading empty translations that would hide fallbacks.
+var errUntranslatedStub = errors.New("untranslated stub")
+
 func buildMessageFromJSON(locale, key string, raw json.RawMessage, source string) (Message, error) {
+	if bytes.Equal(bytes.TrimSpace(raw), []byte("null")) {
+		return Message{}, errUntranslatedStub
+	}
+
 	var singular string
 	if err := json.Unmarshal(raw, &singular); err == nil {
 		return buildMessageFromVariants(locale, key, map[PluralCategory]string{PluralOther: singular}, source)
 	}
 
-	var plural map[string]string
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err == nil {
+		if arg, ok := selectArgJSON(fields); ok {
+			cases := make(map[string]Message, len(fields)-1)
+			for name, value := range fields {
+				if name == selectKey {
+					continue
+				}
+				message, err := buildMessageFromJSON(locale, key, value, source)
+				if err != nil {
+					return Message{}, fmt.Errorf("select case %s: %w", name, err)
+				}
+				cases[name] = message
+			}
+			return buildSelectMessage(key, arg, cases)
+		}
+	}
+
+	var plural map[string]*string
 	if err := json.Unmarshal(raw, &plural); err == nil {
 		variants := make(map[PluralCategory]string, len(plural))
 		for category, template := range plural {
-			cat, err := parsePluralCategory(category)
+			cat, err := parseVariantSelector(category)
 			if err != nil {
 				return Message{}, err
 			}
-			variants[cat] = template
+			if template == nil {
+				return Message{}, errUntranslatedStub
+			}
+			variants[cat] = *template
 		}
 		return buildMessageFromVariants(locale, key, variants, source)
 	}
@@ -152,16 +304,33 @@ func buildMessageFromJSON(locale, key string, raw json.RawMessage, source string
 	return Message{}, fmt.Errorf("unsupported message payload")
 }
 
-func decodeTranslationsYAML(path, input string) (map[string]map[string]Message, error) {
-	var raw map[string]map[string]interface{}
-	if err := yaml.Unmarshal([]byte(input), &raw); err != nil {
+func decodeTranslationsYAML(path, input string, locales *catalogLocales) (map[string]map[string]Message, error) {
+	var top map[string]interface{}
+	if err := yaml.Unmarshal([]byte(input), &top); err != nil {
 		return nil, fmt.Errorf("yaml parse error: %w", err)
 	}
 
-	if len(raw) == 0 {
+	if len(top) == 0 {
 		return nil, errors.New("empty translations yaml")
 	}
 
+	catalogLocale, single, err := locales.fileLocale(path)
+	if err != nil {
+		return nil, err
+	}
+	raw := make(map[string]map[string]interface{}, len(top))
+	if single {
+		raw[catalogLocale] = top
+	} else {
+		for locale, value := range top {
+			messages, ok := value.(map[string]interface{})
+			if !ok && value != nil {
+				return nil, fmt.Errorf("%s: expected a map of messages, got %T", locale, value)
+			}
+			raw[locale] = messages
+		}
+	}
+
 	catalogs := make(map[string]map[string]Message, len(raw))
 	for locale, messages := range raw {
 		if locale == "" {
@@ -175,6 +344,9 @@ func decodeTranslationsYAML(path, input string) (map[string]map[string]Message,
 			}
 
 			message, err := buildMessageFromYAMLValue(locale, key, value, path)
+			if errors.Is(err, errUntranslatedStub) {
+				continue
+			}
 			if err != nil {
 				return nil, fmt.Errorf("%s/%s: %w", locale, key, err)
 			}
@@ -188,15 +360,35 @@ func decodeTranslationsYAML(path, input string) (map[string]map[string]Message,
 
 func buildMessageFromYAMLValue(locale, key string, value interface{}, source string) (Message, error) {
 	switch v := value.(type) {
+	case nil:
+		return Message{}, errUntranslatedStub
 	case string:
 		return buildMessageFromVariants(locale, key, map[PluralCategory]string{PluralOther: v}, source)
 	case map[string]interface{}:
+		if arg, ok := selectArgYAML(v); ok {
+			cases := make(map[string]Message, len(v)-1)
+			for name, value := range v {
+				if name == selectKey {
+					continue
+				}
+				message, err := buildMessageFromYAMLValue(locale, key, value, source)
+				if err != nil {
+					return Message{}, fmt.Errorf("select case %s: %w", name, err)
+				}
+				cases[name] = message
+			}
+			return buildSelectMessage(key, arg, cases)
+		}
+
 		variants := make(map[PluralCategory]string, len(v))
 		for category, template := range v {
-			cat, err := parsePluralCategory(category)
+			cat, err := parseVariantSelector(category)
 			if err != nil {
 				return Message{}, err
 			}
+			if template == nil {
+				return Message{}, errUntranslatedStub
+			}
 			templateStr, ok := template.(string)
 			if !ok {
 				return Message{}, fmt.Errorf("plural variant %s must be a string, got %T", category, template)
@@ -209,6 +401,133 @@ func buildMessageFromYAMLValue(locale, key string, value interface{}, source str
 	}
 }
 
+// selectKey names the argument of a select message payload:
+// {"select": "gender", "female": "...", "other": "..."}.
+const selectKey = "select"
+
+// selectArgJSON reports whether fields describe a select message, which needs
+// a string "select" entry and an "other" case.
+func selectArgJSON(fields map[string]json.RawMessage) (string, bool) {
+	if _, ok := fields[SelectOther]; !ok {
+		return "", false
+	}
+	var arg string
+	if err := json.Unmarshal(fields[selectKey], &arg); err != nil || arg == "" {
+		return "", false
+	}
+	return arg, true
+}
+
+func selectArgYAML(fields map[string]interface{}) (string, bool) {
+	if _, ok := fields[SelectOther]; !ok {
+		return "", false
+	}
+	arg, ok := fields[selectKey].(string)
+	return arg, ok && arg != ""
+}
+
+// buildSelectMessage combines the per-case messages of a select payload. The
+// "other" case supplies the message variants seen by plural-only tooling.
+func buildSelectMessage(key, arg string, cases map[string]Message) (Message, error) {
+	other, ok := cases[SelectOther]
+	if !ok {
+		return Message{}, fmt.Errorf("missing 'other' select case for %s", key)
+	}
+
+	message := Message{
+		MessageMetadata: other.MessageMetadata,
+		Variants:        other.Variants,
+		Select: &MessageSelect{
+			Arg:   arg,
+			Cases: make(map[string]map[PluralCategory]MessageVariant, len(cases)),
+		},
+	}
+	for name, variant := range cases {
+		if variant.Select != nil {
+			return Message{}, fmt.Errorf("nested select in case %s of %s", name, key)
+		}
+		message.Select.Cases[name] = variant.Variants
+	}
+	return message, nil
+}
+
+// catalogLocales decides the layout and locale of catalog files. known holds
+// extra inferable locales by lower case name; names maps explicit file or
+// directory names to locales; single reads every file as one locale.
+type catalogLocales struct {
+	known  map[string]string
+	names  map[string]string
+	single bool
+}
+
+// fileLocale reports whether name holds the catalog of a single locale, and
+// which. Files are keyed by locale unless mapped with WithCatalogLocale or
+// the loader reads single-locale catalogs.
+func (c *catalogLocales) fileLocale(name string) (string, bool, error) {
+	if c == nil {
+		return "", false, nil
+	}
+	if locale, ok := c.named(name); ok {
+		return locale, true, nil
+	}
+	if !c.single {
+		return "", false, nil
+	}
+	locale, err := c.infer(name)
+	return locale, err == nil, err
+}
+
+// named returns the locale WithCatalogLocale maps name to.
+func (c *catalogLocales) named(name string) (string, bool) {
+	name = filepath.ToSlash(name)
+	base := path.Base(name)
+	stem := strings.TrimSuffix(base, path.Ext(base))
+	dir := path.Base(path.Dir(name))
+	for _, candidate := range []string{name, base, stem, dir} {
+		if locale, ok := c.names[candidate]; ok && locale != "" {
+			return locale, true
+		}
+	}
+	return "", false
+}
+
+// infer derives the locale of a single-locale catalog from its file name
+// (es-MX.json) or parent directory (es/messages.yaml). Names only count when
+// they are a configured locale or their language is in the CLDR locale list,
+// so locales/app.json does not load as locale "app".
+func (c *catalogLocales) infer(name string) (string, error) {
+	name = filepath.ToSlash(name)
+	base := path.Base(name)
+	stem := strings.TrimSuffix(base, path.Ext(base))
+	dir := path.Base(path.Dir(name))
+
+	for _, candidate := range []string{stem, dir} {
+		locale := normalizeLocale(candidate)
+		if locale == "" || locale == "." || locale == "und" {
+			continue
+		}
+		if known, ok := c.known[strings.ToLower(locale)]; ok {
+			return known, nil
+		}
+		if knownCLDRLocale(locale) {
+			return locale, nil
+		}
+	}
+	return "", fmt.Errorf("cannot infer locale for %s: name the file or directory after a known locale, or map it with WithCatalogLocale", name)
+}
+
+// knownCLDRLocale reports whether locale parses and its language has CLDR
+// plural data, which covers every CLDR locale.
+func knownCLDRLocale(locale string) bool {
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return false
+	}
+	base, _ := tag.Base()
+	_, ok := cldrCardinalRuleIndex[base.String()]
+	return ok
+}
+
 func buildMessageFromVariants(locale, key string, variants map[PluralCategory]string, source string) (Message, error) {
 	if len(variants) == 0 {
 		return Message{}, fmt.Errorf("no variants defined for %s", key)
@@ -249,7 +568,7 @@ func buildVariant(template, source string) MessageVariant {
 		Checksum: checksum(template),
 	}
 
-	if strings.Contains(template, "{count}") {
+	if usesCountPlaceholder(template) {
 		variant.UsesCount = true
 	}
 
@@ -260,20 +579,30 @@ func buildVariant(template, source string) MessageVariant {
 	return variant
 }
 
+// extractFormatArgs returns the named arguments a template references, other
+// than count. ICU messages with typed, select or plural arguments contribute
+// argument names only ({name} and the first token of {name, type, ...}),
+// never the text of their branches.
 func extractFormatArgs(template string) []string {
-	matches := placeholderPattern.FindAllStringSubmatch(template, -1)
-	if len(matches) == 0 {
+	var names []string
+	if nodes, err := parseICUMessage(template); err == nil && hasComplexICUArgument(nodes) {
+		names = icuArgumentNames(nodes, nil)
+	} else {
+		for _, match := range placeholderPattern.FindAllStringSubmatch(template, -1) {
+			if len(match) > 1 {
+				names = append(names, match[1])
+			}
+		}
+	}
+	if len(names) == 0 {
 		return nil
 	}
 
-	seen := make(map[string]struct{}, len(matches))
-	args := make([]string, 0, len(matches))
+	seen := make(map[string]struct{}, len(names))
+	args := make([]string, 0, len(names))
 
-	for _, match := range matches {
-		if len(match) < 2 {
-			continue
-		}
-		name := strings.TrimSpace(match[1])
+	for _, name := range names {
+		name = strings.TrimSpace(name)
 		if name == "" {
 			continue
 		}
@@ -311,6 +640,7 @@ func mergeMessageBuckets(dst, src map[string]map[string]Message) {
 					existing.Variants[category] = variant
 				}
 				existing.MessageMetadata = message.MessageMetadata
+				existing.Select = message.Select
 				target[key] = existing
 			} else {
 				target[key] = message
@@ -319,22 +649,107 @@ func mergeMessageBuckets(dst, src map[string]map[string]Message) {
 	}
 }
 
-func (l *FileLoader) loadPluralRules() (map[string]*PluralRuleSet, error) {
-	if len(l.rulePaths) == 0 {
-		return nil, nil
+// resolvePaths expands directories and glob patterns when reading from an
+// fs.FS. Plain file loaders use their paths as given.
+func (l *FileLoader) resolvePaths() ([]string, error) {
+	if l.fsys == nil {
+		return l.paths, nil
+	}
+
+	var resolved []string
+	seen := make(map[string]struct{})
+	add := func(name string) {
+		if _, ok := seen[name]; ok {
+			return
+		}
+		seen[name] = struct{}{}
+		resolved = append(resolved, name)
+	}
+
+	for _, pattern := range l.paths {
+		if info, err := fs.Stat(l.fsys, pattern); err == nil && info.IsDir() {
+			var found []string
+			err := fs.WalkDir(l.fsys, pattern, func(name string, entry fs.DirEntry, err error) error {
+				if err != nil {
+					return err
+				}
+				if !entry.IsDir() && isTranslationFile(name) {
+					found = append(found, name)
+				}
+				return nil
+			})
+			if err != nil {
+				return nil, fmt.Errorf("i18n: walk %s: %w", pattern, err)
+			}
+			sort.Strings(found)
+			for _, name := range found {
+				add(name)
+			}
+			continue
+		}
+
+		matches, err := fs.Glob(l.fsys, pattern)
+		if err != nil {
+			return nil, fmt.Errorf("i18n: pattern %s: %w", pattern, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("i18n: pattern %s matched no files", pattern)
+		}
+		for _, name := range matches {
+			add(name)
+		}
+	}
+
+	return resolved, nil
+}
+
+func isTranslationFile(name string) bool {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".json", ".yaml", ".yml":
+		return true
+	default:
+		return false
+	}
+}
+
+// readFileFS reads path from fsys, or from disk when fsys is nil.
+func readFileFS(fsys fs.FS, path string) ([]byte, error) {
+	if fsys != nil {
+		return fs.ReadFile(fsys, path)
+	}
+	return os.ReadFile(path)
+}
+
+func (l *FileLoader) loadPluralRules() (pluralRuleSets, error) {
+	return loadPluralRuleFiles(l.fsys, l.rulePaths)
+}
+
+// pluralRuleSets holds the cardinal and ordinal rule sets read from plural
+// rule files, keyed by locale.
+type pluralRuleSets struct {
+	cardinal map[string]*PluralRuleSet
+	ordinal  map[string]*PluralRuleSet
+}
+
+func loadPluralRuleFiles(fsys fs.FS, paths []string) (pluralRuleSets, error) {
+	var rules pluralRuleSets
+	if len(paths) == 0 {
+		return rules, nil
 	}
 
-	rules := make(map[string]*PluralRuleSet)
-	for _, path := range l.rulePaths {
-		data, err := os.ReadFile(path)
+	rules.cardinal = make(map[string]*PluralRuleSet)
+	rules.ordinal = make(map[string]*PluralRuleSet)
+	for _, path := range paths {
+		data, err := readFileFS(fsys, path)
 		if err != nil {
-			return nil, fmt.Errorf("i18n: read plural rules %s: %w", path, err)
+			return pluralRuleSets{}, fmt.Errorf("i18n: read plural rules %s: %w", path, err)
 		}
 		parsed, err := decodePluralRules(path, data)
 		if err != nil {
-			return nil, fmt.Errorf("i18n: decode plural rules %s: %w", path, err)
+			return pluralRuleSets{}, fmt.Errorf("i18n: decode plural rules %s: %w", path, err)
 		}
-		mergeRuleSets(rules, parsed)
+		mergeRuleSets(rules.cardinal, parsed.cardinal)
+		mergeRuleSets(rules.ordinal, parsed.ordinal)
 	}
 
 	return rules, nil
@@ -348,6 +763,14 @@ type rawLocaleRules struct {
 	Name     string                         `json:"name"`
 	Parent   string                         `json:"parent"`
 	Cardinal map[string][]rawConditionGroup `json:"cardinal"`
+	Ordinal  map[string][]rawConditionGroup `json:"ordinal,omitempty"`
+	Ranges   []rawRangeRule                 `json:"ranges,omitempty"`
+}
+
+type rawRangeRule struct {
+	Start  string `json:"start"`
+	End    string `json:"end"`
+	Result string `json:"result"`
 }
 
 type rawConditionGroup []rawCondition
@@ -365,40 +788,132 @@ type rawRange struct {
 	End   float64 `json:"end"`
 }
 
-func decodePluralRules(path string, data []byte) (map[string]*PluralRuleSet, error) {
+// cldrPluralRulesFile mirrors the supplemental plurals.json and
+// ordinals.json files published by cldr-json.
+type cldrPluralRulesFile struct {
+	Supplemental struct {
+		Cardinal map[string]map[string]string `json:"plurals-type-cardinal"`
+		Ordinal  map[string]map[string]string `json:"plurals-type-ordinal"`
+	} `json:"supplemental"`
+}
+
+const cldrPluralRulePrefix = "pluralRule-count-"
+
+func decodePluralRules(path string, data []byte) (pluralRuleSets, error) {
+	var cldrFile cldrPluralRulesFile
+	if err := json.Unmarshal(data, &cldrFile); err == nil {
+		if len(cldrFile.Supplemental.Cardinal) > 0 || len(cldrFile.Supplemental.Ordinal) > 0 {
+			return decodeCLDRPluralRules(cldrFile)
+		}
+	}
+
 	wrapper := rawPluralRulesFile{}
 	if err := json.Unmarshal(data, &wrapper); err != nil {
 		var direct map[string]rawLocaleRules
 		if errDirect := json.Unmarshal(data, &direct); errDirect != nil {
-			return nil, err
+			return pluralRuleSets{}, err
 		}
 		wrapper.Locales = direct
 	}
 
 	if len(wrapper.Locales) == 0 {
-		return nil, fmt.Errorf("i18n: plural rule file %s has no locales", path)
+		return pluralRuleSets{}, fmt.Errorf("i18n: plural rule file %s has no locales", path)
 	}
 
-	result := make(map[string]*PluralRuleSet, len(wrapper.Locales))
+	result := pluralRuleSets{
+		cardinal: make(map[string]*PluralRuleSet, len(wrapper.Locales)),
+		ordinal:  make(map[string]*PluralRuleSet),
+	}
 	for locale, rawRules := range wrapper.Locales {
-		ruleSet, err := buildRuleSet(locale, rawRules)
-		if err != nil {
-			return nil, fmt.Errorf("%s: %w", locale, err)
+		if len(rawRules.Cardinal) == 0 && len(rawRules.Ordinal) == 0 {
+			return pluralRuleSets{}, fmt.Errorf("%s: missing cardinal rules", locale)
+		}
+		if len(rawRules.Cardinal) > 0 {
+			ruleSet, err := buildRuleSet(locale, rawRules, rawRules.Cardinal)
+			if err != nil {
+				return pluralRuleSets{}, fmt.Errorf("%s: %w", locale, err)
+			}
+			if ruleSet.Ranges, err = buildRangeRules(rawRules.Ranges); err != nil {
+				return pluralRuleSets{}, fmt.Errorf("%s: ranges: %w", locale, err)
+			}
+			result.cardinal[locale] = ruleSet
+		} else if len(rawRules.Ranges) > 0 {
+			return pluralRuleSets{}, fmt.Errorf("%s: ranges require cardinal rules", locale)
+		}
+		if len(rawRules.Ordinal) > 0 {
+			ruleSet, err := buildRuleSet(locale, rawRules, rawRules.Ordinal)
+			if err != nil {
+				return pluralRuleSets{}, fmt.Errorf("%s: ordinal: %w", locale, err)
+			}
+			result.ordinal[locale] = ruleSet
 		}
-		result[locale] = ruleSet
 	}
 
 	return result, nil
 }
 
-func buildRuleSet(locale string, raw rawLocaleRules) (*PluralRuleSet, error) {
-	if len(raw.Cardinal) == 0 {
-		return nil, fmt.Errorf("missing cardinal rules")
+// decodeCLDRPluralRules parses the native rule syntax of cldr-json files.
+// Locale identifiers are normalized and the "root" entry is skipped.
+func decodeCLDRPluralRules(file cldrPluralRulesFile) (pluralRuleSets, error) {
+	result := pluralRuleSets{
+		cardinal: make(map[string]*PluralRuleSet),
+		ordinal:  make(map[string]*PluralRuleSet),
+	}
+	for _, section := range []struct {
+		name  string
+		rules map[string]map[string]string
+		dst   map[string]*PluralRuleSet
+	}{
+		{name: "cardinal", rules: file.Supplemental.Cardinal, dst: result.cardinal},
+		{name: "ordinal", rules: file.Supplemental.Ordinal, dst: result.ordinal},
+	} {
+		for locale, entries := range section.rules {
+			if locale == "root" {
+				continue
+			}
+			rules := make(map[string]string, len(entries))
+			for key, rule := range entries {
+				rules[strings.TrimPrefix(key, cldrPluralRulePrefix)] = rule
+			}
+			locale = normalizeLocale(locale)
+			set, err := ParsePluralRuleSet(locale, rules)
+			if err != nil {
+				return pluralRuleSets{}, fmt.Errorf("%s: %s: %w", locale, section.name, err)
+			}
+			section.dst[locale] = set
+		}
+	}
+	return result, nil
+}
+
+func buildRangeRules(raw []rawRangeRule) ([]PluralRangeRule, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	ranges := make([]PluralRangeRule, 0, len(raw))
+	for _, entry := range raw {
+		start, err := parsePluralCategory(entry.Start)
+		if err != nil {
+			return nil, err
+		}
+		end, err := parsePluralCategory(entry.End)
+		if err != nil {
+			return nil, err
+		}
+		result, err := parsePluralCategory(entry.Result)
+		if err != nil {
+			return nil, err
+		}
+		ranges = append(ranges, PluralRangeRule{Start: start, End: end, Result: result})
 	}
+	return ranges, nil
+}
 
-	entries := make([]PluralRule, 0, len(raw.Cardinal))
-	categories := make([]string, 0, len(raw.Cardinal))
-	for category := range raw.Cardinal {
+func buildRuleSet(locale string, raw rawLocaleRules, rules map[string][]rawConditionGroup) (*PluralRuleSet, error) {
+	entries := make([]PluralRule, 0, len(rules))
+	categories := make([]string, 0, len(rules))
+	for category := range rules {
 		categories = append(categories, category)
 	}
 	sort.Strings(categories)
@@ -409,7 +924,7 @@ func buildRuleSet(locale string, raw rawLocaleRules) (*PluralRuleSet, error) {
 			return nil, err
 		}
 
-		rawGroups := raw.Cardinal[category]
+		rawGroups := rules[category]
 		groups := make([][]PluralCondition, 0, len(rawGroups))
 		for _, rawGroup := range rawGroups {
 			if len(rawGroup) == 0 {
@@ -495,6 +1010,20 @@ func parsePluralCategory(raw string) (PluralCategory, error) {
 	}
 }
 
+// parseVariantSelector accepts a CLDR category or an explicit-value selector
+// such as "=0" or "=1.5".
+func parseVariantSelector(raw string) (PluralCategory, error) {
+	trimmed := strings.TrimSpace(raw)
+	if !strings.HasPrefix(trimmed, "=") {
+		return parsePluralCategory(raw)
+	}
+	value := strings.TrimSpace(trimmed[1:])
+	if _, _, ok := buildOperandsFromLiteral(value); !ok {
+		return "", fmt.Errorf("invalid explicit plural selector %q", raw)
+	}
+	return ExactPluralCategory(value), nil
+}
+
 func parseConditionOperator(raw string) (PluralConditionOperator, error) {
 	switch strings.ToLower(strings.TrimSpace(raw)) {
 	case string(OperatorEquals), "=":
@@ -1,8 +1,10 @@
 package i18n
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
+	"testing/fstest"
 )
 
 func TestFileLoaderJSONAndYAML(t *testing.T) {
@@ -87,3 +89,174 @@ func TestFileLoaderIntegration(t *testing.T) {
 		t.Fatalf("Translate fallback = %q,%v", got, err)
 	}
 }
+
+func TestFSLoaderDiscoversAndInfersLocales(t *testing.T) {
+	rules, err := os.ReadFile(filepath.Join("testdata", "cldr_cardinal.json"))
+	if err != nil {
+		t.Fatalf("read rules: %v", err)
+	}
+
+	fsys := fstest.MapFS{
+		"locales/en.json":          {Data: []byte(`{"home.title": "Welcome", "cart.items": {"one": "{count} item", "other": "{count} items"}}`)},
+		"locales/es-MX.json":       {Data: []byte(`{"home.title": "Bienvenido"}`)},
+		"locales/fr/messages.yaml": {Data: []byte("home.title: Bienvenue\n")},
+		"locales/README.md":        {Data: []byte("ignored")},
+		"rules/plurals.json":       {Data: rules},
+	}
+
+	tests := []struct {
+		name     string
+		patterns []string
+	}{
+		{name: "directory", patterns: []string{"locales"}},
+		{name: "glob", patterns: []string{"locales/*.json", "locales/*/*.yaml"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			translations, err := NewFSLoader(fsys, tc.patterns...).
+				WithSingleLocaleCatalogs().
+				WithPluralRuleFiles("rules/plurals.json").
+				Load()
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+
+			want := map[string]string{
+				"en":    "Welcome",
+				"es-MX": "Bienvenido",
+				"fr":    "Bienvenue",
+			}
+			if len(translations) != len(want) {
+				t.Fatalf("expected %d locales, got %d", len(want), len(translations))
+			}
+			for locale, title := range want {
+				catalog := translations[locale]
+				if catalog == nil {
+					t.Fatalf("missing %s catalog", locale)
+				}
+				if got := catalog.Messages["home.title"].Content(); got != title {
+					t.Fatalf("%s home.title = %q want %q", locale, got, title)
+				}
+			}
+
+			en := translations["en"]
+			if en.CardinalRules == nil {
+				t.Fatalf("expected plural rules read from fsys")
+			}
+			if variant, ok := en.Messages["cart.items"].Variant(PluralOne); !ok || variant.Template != "{count} item" {
+				t.Fatalf("flat plural message not decoded: %+v", en.Messages["cart.items"])
+			}
+		})
+	}
+}
+
+func TestFSLoaderErrors(t *testing.T) {
+	fsys := fstest.MapFS{
+		"catalog/messages.json": {Data: []byte(`{"home.title": "Welcome"}`)},
+	}
+
+	if _, err := NewFSLoader(fsys, "missing/*.json").Load(); err == nil {
+		t.Fatal("expected error for pattern without matches")
+	}
+	if _, err := NewFSLoader(fsys, "catalog/messages.json").WithSingleLocaleCatalogs().Load(); err == nil {
+		t.Fatal("expected error when the locale cannot be inferred")
+	}
+	if paths := NewFSLoader(fsys, "catalog").watchedPaths(); paths != nil {
+		t.Fatalf("fs loaders should not be watched, got %v", paths)
+	}
+}
+
+func TestFileLoaderInfersOnlyKnownLocales(t *testing.T) {
+	fsys := fstest.MapFS{
+		"locales/app.json":     {Data: []byte(`{"home.title": "Welcome"}`)},
+		"locales/fr/app.json":  {Data: []byte(`{"home.title": "Bienvenue"}`)},
+		"locales/pt_BR.yaml":   {Data: []byte("home.title: Bem-vindo\n")},
+		"locales/tlh/app.json": {Data: []byte(`{"home.title": "nuqneH"}`)},
+		"locales/spanish.json": {Data: []byte(`{"home.title": "Bienvenido"}`)},
+	}
+
+	for _, name := range []string{"locales/app.json", "locales/tlh/app.json", "locales/spanish.json"} {
+		if _, err := NewFSLoader(fsys, name).WithSingleLocaleCatalogs().Load(); err == nil {
+			t.Fatalf("expected %s to be rejected without a known locale name", name)
+		}
+	}
+
+	translations, err := NewFSLoader(fsys, "locales").
+		WithSingleLocaleCatalogs().
+		WithCatalogLocale("locales/app.json", "en").
+		WithCatalogLocale("spanish", "es").
+		WithCatalogLocales("tlh").
+		Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	want := map[string]string{
+		"en":    "Welcome",
+		"fr":    "Bienvenue",
+		"pt-BR": "Bem-vindo",
+		"tlh":   "nuqneH",
+		"es":    "Bienvenido",
+	}
+	if len(translations) != len(want) {
+		t.Fatalf("locales = %v", NewStaticStore(translations).Locales())
+	}
+	for locale, title := range want {
+		if got := translations[locale].Messages["home.title"].Content(); got != title {
+			t.Fatalf("%s home.title = %q want %q", locale, got, title)
+		}
+	}
+}
+
+func TestConfigInfersConfiguredCatalogLocales(t *testing.T) {
+	fsys := fstest.MapFS{"tlh.json": {Data: []byte(`{"home.title": "nuqneH"}`)}}
+	cfg, err := NewConfig(WithLocales("en", "tlh"), WithLoader(NewFSLoader(fsys, "tlh.json").WithSingleLocaleCatalogs()))
+	if err != nil {
+		t.Fatalf("NewConfig: %v", err)
+	}
+	if got, _ := cfg.Store.Get("tlh", "home.title"); got != "nuqneH" {
+		t.Fatalf("tlh home.title = %q", got)
+	}
+}
+
+func TestFileLoaderCatalogLayoutIsExplicit(t *testing.T) {
+	fsys := fstest.MapFS{
+		"keyed/en.json":    {Data: []byte(`{"en": {"one": "One", "other": "Other"}, "zh": {"id": "Identifier"}}`)},
+		"keyed/empty.yaml": {Data: []byte("en:\n  home.title: Welcome\nes:\n")},
+		"flat/es.json":     {Data: []byte(`{"one": "Uno", "other": "Otro", "zh": {"one": "un chino", "other": "{count} chinos"}, "id": "Indonesio"}`)},
+		"flat/fr.yaml":     {Data: []byte("zh:\n  other: chinois\nid: indonésien\n")},
+	}
+
+	keyed, err := NewFSLoader(fsys, "keyed").Load()
+	if err != nil {
+		t.Fatalf("Load keyed: %v", err)
+	}
+	if got := keyed["en"].Messages["one"].Content(); got != "One" {
+		t.Fatalf("en one = %q want One", got)
+	}
+	if got := keyed["zh"].Messages["id"].Content(); got != "Identifier" {
+		t.Fatalf("zh id = %q want Identifier", got)
+	}
+	if got := keyed["en"].Messages["home.title"].Content(); got != "Welcome" {
+		t.Fatalf("en home.title = %q want Welcome", got)
+	}
+	if es, ok := keyed["es"]; !ok || len(es.Messages) != 0 {
+		t.Fatalf("expected empty es catalog, got %+v", es)
+	}
+
+	flat, err := NewFSLoader(fsys, "flat").WithSingleLocaleCatalogs().Load()
+	if err != nil {
+		t.Fatalf("Load flat: %v", err)
+	}
+	if len(flat) != 2 {
+		t.Fatalf("flat locales = %v", NewStaticStore(flat).Locales())
+	}
+	for key, want := range map[string]string{"one": "Uno", "other": "Otro", "zh": "{count} chinos", "id": "Indonesio"} {
+		if got := flat["es"].Messages[key].Content(); got != want {
+			t.Fatalf("es %s = %q want %q", key, got, want)
+		}
+	}
+	if got := flat["fr"].Messages["zh"].Content(); got != "chinois" {
+		t.Fatalf("fr zh = %q want chinois", got)
+	}
+}
@@ -0,0 +1,694 @@
+package i18n
+
+import (
+	"container/list"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+var _ MessageFormatter = &ICUFormatter{}
+
+// ICUFormatter renders ICU MessageFormat templates such as
+// "{count, plural, one {# item} other {# items}}". Plural and selectordinal
+// branches are resolved through the same PluralRuleSet evaluation used by
+// SimpleTranslator, so a single message can carry every variant inline.
+type ICUFormatter struct {
+	locale    string
+	cardinal  map[string]*PluralRuleSet
+	ordinal   map[string]*PluralRuleSet
+	cacheSize int
+
+	mu     sync.Mutex
+	parsed map[string]*list.Element
+	order  *list.List
+}
+
+// defaultICUCacheSize is the number of parsed templates ICUFormatter keeps
+// unless WithICUCacheSize says otherwise.
+const defaultICUCacheSize = 1024
+
+// ICUFormatterOption configures ICUFormatter
+type ICUFormatterOption func(*ICUFormatter)
+
+// NewICUFormatter builds a MessageFormat renderer
+func NewICUFormatter(opts ...ICUFormatterOption) *ICUFormatter {
+	f := &ICUFormatter{
+		cardinal:  make(map[string]*PluralRuleSet),
+		ordinal:   make(map[string]*PluralRuleSet),
+		cacheSize: defaultICUCacheSize,
+		parsed:    make(map[string]*list.Element),
+		order:     list.New(),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(f)
+		}
+	}
+	return f
+}
+
+// WithICULocale sets the locale used by Format when no locale is supplied
+func WithICULocale(locale string) ICUFormatterOption {
+	return func(f *ICUFormatter) {
+		f.locale = locale
+	}
+}
+
+// WithICUCacheSize bounds the parsed templates kept in memory, evicting the
+// least recently used one past size. Zero or less disables the cache.
+func WithICUCacheSize(size int) ICUFormatterOption {
+	return func(f *ICUFormatter) {
+		f.cacheSize = size
+	}
+}
+
+// WithICUPluralRules registers cardinal rule sets keyed by their Locale
+func WithICUPluralRules(sets ...*PluralRuleSet) ICUFormatterOption {
+	return func(f *ICUFormatter) {
+		registerICURules(f.cardinal, sets)
+	}
+}
+
+// WithICUOrdinalRules registers ordinal rule sets keyed by their Locale
+func WithICUOrdinalRules(sets ...*PluralRuleSet) ICUFormatterOption {
+	return func(f *ICUFormatter) {
+		registerICURules(f.ordinal, sets)
+	}
+}
+
+func registerICURules(dst map[string]*PluralRuleSet, sets []*PluralRuleSet) {
+	for _, set := range sets {
+		if set == nil || set.Locale == "" {
+			continue
+		}
+		dst[set.Locale] = set
+	}
+}
+
+// Format implements Formatter using the formatter default locale
+func (f *ICUFormatter) Format(template string, args ...any) (string, error) {
+	locale := ""
+	if f != nil {
+		locale = f.locale
+	}
+	return f.FormatMessage(FormatInput{Locale: locale, Template: template, Args: args})
+}
+
+// FormatMessage implements MessageFormatter
+func (f *ICUFormatter) FormatMessage(input FormatInput) (string, error) {
+	if f == nil {
+		return input.Template, nil
+	}
+
+	nodes, err := f.parse(input.Template)
+	if err != nil {
+		return "", err
+	}
+
+	locale := input.Locale
+	if locale == "" {
+		locale = f.locale
+	}
+
+	r := icuRenderer{
+		locale:   locale,
+		input:    input,
+		cardinal: input.Cardinal,
+		ordinal:  input.Ordinal,
+	}
+	if r.cardinal == nil {
+		r.cardinal = lookupICURules(f.cardinal, locale)
+	}
+	if r.ordinal == nil {
+		r.ordinal = lookupICURules(f.ordinal, locale)
+	}
+
+	var b strings.Builder
+	b.Grow(len(input.Template))
+	if err := r.render(&b, nodes, ""); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+type icuParseResult struct {
+	template string
+	nodes    []icuNode
+	err      error
+}
+
+func (f *ICUFormatter) parse(template string) ([]icuNode, error) {
+	if f.cacheSize <= 0 || f.parsed == nil {
+		return parseICUMessage(template)
+	}
+
+	f.mu.Lock()
+	if elem, ok := f.parsed[template]; ok {
+		f.order.MoveToFront(elem)
+		result := elem.Value.(*icuParseResult)
+		f.mu.Unlock()
+		return result.nodes, result.err
+	}
+	f.mu.Unlock()
+
+	nodes, err := parseICUMessage(template)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.parsed[template]; !ok {
+		f.parsed[template] = f.order.PushFront(&icuParseResult{template: template, nodes: nodes, err: err})
+		for f.order.Len() > f.cacheSize {
+			oldest := f.order.Back()
+			delete(f.parsed, oldest.Value.(*icuParseResult).template)
+			f.order.Remove(oldest)
+		}
+	}
+	return nodes, err
+}
+
+func lookupICURules(sets map[string]*PluralRuleSet, locale string) *PluralRuleSet {
+	if len(sets) == 0 || locale == "" {
+		return nil
+	}
+	if set, ok := sets[locale]; ok {
+		return set
+	}
+	for _, parent := range localeParentChain(locale) {
+		if set, ok := sets[parent]; ok {
+			return set
+		}
+	}
+	return nil
+}
+
+type icuNode interface{}
+
+type icuText string
+
+type icuPound struct{}
+
+type icuArgument struct {
+	name  string
+	kind  string
+	style string
+}
+
+type icuSelect struct {
+	name   string
+	kind   string
+	offset string // canonical decimal, empty without an offset
+	cases  []icuCase
+}
+
+type icuCase struct {
+	selector string
+	body     []icuNode
+}
+
+const (
+	icuKindPlural        = "plural"
+	icuKindSelect        = "select"
+	icuKindSelectOrdinal = "selectordinal"
+)
+
+type icuParser struct {
+	src string
+	pos int
+}
+
+func parseICUMessage(src string) ([]icuNode, error) {
+	p := &icuParser{src: src}
+	return p.parseMessage(false, 0)
+}
+
+func (p *icuParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("i18n: message format: %s at offset %d", fmt.Sprintf(format, args...), p.pos)
+}
+
+// parseMessage consumes text until EOF or, when nested, the closing brace of
+// the enclosing case (left for the caller to consume).
+func (p *icuParser) parseMessage(inPlural bool, depth int) ([]icuNode, error) {
+	var (
+		nodes []icuNode
+		text  strings.Builder
+	)
+
+	flush := func() {
+		if text.Len() > 0 {
+			nodes = append(nodes, icuText(text.String()))
+			text.Reset()
+		}
+	}
+
+	for p.pos < len(p.src) {
+		switch ch := p.src[p.pos]; ch {
+		case '\'':
+			p.readApostrophe(&text, inPlural)
+		case '{':
+			flush()
+			node, err := p.parseArgument(inPlural, depth)
+			if err != nil {
+				return nil, err
+			}
+			nodes = append(nodes, node)
+		case '}':
+			if depth == 0 {
+				return nil, p.errorf("unexpected '}'")
+			}
+			flush()
+			return nodes, nil
+		case '#':
+			p.pos++
+			if inPlural {
+				flush()
+				nodes = append(nodes, icuPound{})
+				continue
+			}
+			text.WriteByte(ch)
+		default:
+			text.WriteByte(ch)
+			p.pos++
+		}
+	}
+
+	if depth > 0 {
+		return nil, p.errorf("unterminated message")
+	}
+
+	flush()
+	return nodes, nil
+}
+
+// readApostrophe applies ICU apostrophe rules: a doubled apostrophe is literal and
+// a single apostrophe before a syntax character starts quoted literal text.
+func (p *icuParser) readApostrophe(text *strings.Builder, inPlural bool) {
+	next := p.pos + 1
+	if next < len(p.src) && p.src[next] == '\'' {
+		text.WriteByte('\'')
+		p.pos += 2
+		return
+	}
+
+	if next >= len(p.src) || !isICUSyntaxChar(p.src[next], inPlural) {
+		text.WriteByte('\'')
+		p.pos++
+		return
+	}
+
+	p.pos = next
+	for p.pos < len(p.src) {
+		ch := p.src[p.pos]
+		if ch == '\'' {
+			if p.pos+1 < len(p.src) && p.src[p.pos+1] == '\'' {
+				text.WriteByte('\'')
+				p.pos += 2
+				continue
+			}
+			p.pos++
+			return
+		}
+		text.WriteByte(ch)
+		p.pos++
+	}
+}
+
+func isICUSyntaxChar(ch byte, inPlural bool) bool {
+	switch ch {
+	case '{', '}', '|':
+		return true
+	case '#':
+		return inPlural
+	default:
+		return false
+	}
+}
+
+func (p *icuParser) parseArgument(inPlural bool, depth int) (icuNode, error) {
+	p.pos++ // opening brace
+	p.skipSpace()
+
+	name := p.readWord()
+	if name == "" {
+		return nil, p.errorf("missing argument name")
+	}
+
+	p.skipSpace()
+	if p.consume('}') {
+		return icuArgument{name: name}, nil
+	}
+	if !p.consume(',') {
+		return nil, p.errorf("expected ',' or '}' after argument %q", name)
+	}
+
+	p.skipSpace()
+	kind := strings.ToLower(p.readWord())
+	p.skipSpace()
+
+	switch kind {
+	case icuKindPlural, icuKindSelectOrdinal, icuKindSelect:
+		if !p.consume(',') {
+			return nil, p.errorf("expected ',' after %s", kind)
+		}
+		childPlural := inPlural || kind != icuKindSelect
+		return p.parseSelect(name, kind, childPlural, depth)
+	case "number", "date", "time":
+		if p.consume('}') {
+			return icuArgument{name: name, kind: kind}, nil
+		}
+		if !p.consume(',') {
+			return nil, p.errorf("expected ',' or '}' after %s", kind)
+		}
+		style, err := p.readStyle()
+		if err != nil {
+			return nil, err
+		}
+		return icuArgument{name: name, kind: kind, style: style}, nil
+	case "":
+		return nil, p.errorf("missing argument type for %q", name)
+	default:
+		return nil, p.errorf("unsupported argument type %q", kind)
+	}
+}
+
+func (p *icuParser) parseSelect(name, kind string, inPlural bool, depth int) (icuNode, error) {
+	node := &icuSelect{name: name, kind: kind}
+
+	p.skipSpace()
+	if kind != icuKindSelect && strings.HasPrefix(p.src[p.pos:], "offset:") {
+		p.pos += len("offset:")
+		p.skipSpace()
+		raw := p.readWord()
+		offset, ok := canonicalDecimal(raw)
+		if !ok {
+			return nil, p.errorf("invalid plural offset %q", raw)
+		}
+		if offset != "0" {
+			node.offset = offset
+		}
+	}
+
+	seen := make(map[string]struct{})
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.src) {
+			return nil, p.errorf("unterminated %s argument %q", kind, name)
+		}
+		if p.consume('}') {
+			break
+		}
+
+		selector := p.readWord()
+		if selector == "" {
+			return nil, p.errorf("missing selector in %s argument %q", kind, name)
+		}
+		if _, dup := seen[selector]; dup {
+			return nil, p.errorf("duplicate selector %q in %s argument %q", selector, kind, name)
+		}
+		seen[selector] = struct{}{}
+
+		p.skipSpace()
+		if !p.consume('{') {
+			return nil, p.errorf("expected '{' after selector %q", selector)
+		}
+
+		body, err := p.parseMessage(inPlural, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		p.pos++ // closing brace of the case
+
+		node.cases = append(node.cases, icuCase{selector: selector, body: body})
+	}
+
+	if _, ok := seen["other"]; !ok {
+		return nil, p.errorf("%s argument %q is missing the 'other' case", kind, name)
+	}
+
+	return node, nil
+}
+
+// hasComplexICUArgument reports whether nodes use ICU syntax beyond plain
+// {name} placeholders.
+func hasComplexICUArgument(nodes []icuNode) bool {
+	for _, node := range nodes {
+		switch n := node.(type) {
+		case icuArgument:
+			if n.kind != "" {
+				return true
+			}
+		case *icuSelect, icuPound:
+			return true
+		}
+	}
+	return false
+}
+
+// icuArgumentNames appends the argument names referenced by nodes, including
+// arguments nested in select and plural cases, in source order.
+func icuArgumentNames(nodes []icuNode, names []string) []string {
+	for _, node := range nodes {
+		switch n := node.(type) {
+		case icuArgument:
+			names = append(names, n.name)
+		case *icuSelect:
+			names = append(names, n.name)
+			for _, c := range n.cases {
+				names = icuArgumentNames(c.body, names)
+			}
+		}
+	}
+	return names
+}
+
+func (p *icuParser) readStyle() (string, error) {
+	start := p.pos
+	level := 0
+	for p.pos < len(p.src) {
+		switch p.src[p.pos] {
+		case '{':
+			level++
+		case '}':
+			if level == 0 {
+				style := strings.TrimSpace(p.src[start:p.pos])
+				p.pos++
+				return style, nil
+			}
+			level--
+		}
+		p.pos++
+	}
+	return "", p.errorf("unterminated argument style")
+}
+
+func (p *icuParser) readWord() string {
+	start := p.pos
+	for p.pos < len(p.src) {
+		r, size := utf8.DecodeRuneInString(p.src[p.pos:])
+		if unicode.IsSpace(r) || strings.ContainsRune("{},'#", r) {
+			break
+		}
+		p.pos += size
+	}
+	return p.src[start:p.pos]
+}
+
+func (p *icuParser) skipSpace() {
+	for p.pos < len(p.src) {
+		r, size := utf8.DecodeRuneInString(p.src[p.pos:])
+		if !unicode.IsSpace(r) {
+			return
+		}
+		p.pos += size
+	}
+}
+
+func (p *icuParser) consume(ch byte) bool {
+	if p.pos < len(p.src) && p.src[p.pos] == ch {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+type icuRenderer struct {
+	locale   string
+	input    FormatInput
+	cardinal *PluralRuleSet
+	ordinal  *PluralRuleSet
+}
+
+func (r *icuRenderer) render(b *strings.Builder, nodes []icuNode, pound string) error {
+	for _, node := range nodes {
+		switch n := node.(type) {
+		case icuText:
+			b.WriteString(string(n))
+		case icuPound:
+			b.WriteString(r.formatPound(pound))
+		case icuArgument:
+			r.renderArgument(b, n)
+		case *icuSelect:
+			if err := r.renderSelect(b, n, pound); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *icuRenderer) renderArgument(b *strings.Builder, arg icuArgument) {
+	value, ok := r.lookup(arg.name)
+	if !ok {
+		b.WriteString("{" + arg.name + "}")
+		return
+	}
+
+	switch arg.kind {
+	case "number":
+		b.WriteString(r.formatNumber(value, arg.style))
+	case "date", "time":
+		b.WriteString(r.formatICUTime(value, arg.kind))
+	default:
+		b.WriteString(fmt.Sprint(value))
+	}
+}
+
+func (r *icuRenderer) renderSelect(b *strings.Builder, node *icuSelect, pound string) error {
+	value, ok := r.lookup(node.name)
+
+	if node.kind == icuKindSelect {
+		key := "other"
+		if ok && value != nil {
+			key = fmt.Sprint(value)
+		}
+		return r.render(b, node.findCase(key), pound)
+	}
+
+	if !ok {
+		return fmt.Errorf("i18n: message format: missing %s argument %q", node.kind, node.name)
+	}
+
+	_, literal, ok := icuNumeric(value)
+	if !ok {
+		return fmt.Errorf("i18n: message format: %s argument %q is not numeric: %v", node.kind, node.name, value)
+	}
+
+	if value, ok := canonicalDecimal(literal); ok {
+		for _, c := range node.cases {
+			if !strings.HasPrefix(c.selector, "=") {
+				continue
+			}
+			if exact, ok := canonicalDecimal(c.selector[1:]); ok && exact == value {
+				return r.render(b, c.body, literal)
+			}
+		}
+	}
+
+	if node.offset != "" {
+		literal = subtractDecimal(literal, node.offset)
+	}
+
+	rules := r.cardinal
+	if node.kind == icuKindSelectOrdinal {
+		rules = r.ordinal
+	}
+
+	category := PluralOther
+	if operands, _, ok := toPluralOperands(literal); ok {
+		category = selectPluralCategory(rules, operands)
+	}
+
+	return r.render(b, node.findCase(string(category)), literal)
+}
+
+func (n *icuSelect) findCase(selector string) []icuNode {
+	var other []icuNode
+	for _, c := range n.cases {
+		if c.selector == selector {
+			return c.body
+		}
+		if c.selector == "other" {
+			other = c.body
+		}
+	}
+	return other
+}
+
+func (r *icuRenderer) lookup(name string) (any, bool) {
+	if idx, err := strconv.Atoi(name); err == nil {
+		if idx >= 0 && idx < len(r.input.Args) {
+			return r.input.Args[idx], true
+		}
+		return nil, false
+	}
+
+	if value, ok := r.input.Named[name]; ok {
+		return value, true
+	}
+
+	for i := len(r.input.Args) - 1; i >= 0; i-- {
+		switch named := r.input.Args[i].(type) {
+		case map[string]any:
+			if value, ok := named[name]; ok {
+				return value, true
+			}
+		case map[string]string:
+			if value, ok := named[name]; ok {
+				return value, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+func (r *icuRenderer) formatICUTime(value any, kind string) string {
+	moment, ok := value.(time.Time)
+	if !ok {
+		return fmt.Sprint(value)
+	}
+	if kind == "time" {
+		return FormatTime(r.locale, moment)
+	}
+	return FormatDate(r.locale, moment)
+}
+
+// icuNumeric converts an argument into a float plus the literal used for
+// plural operands and '#' substitution.
+func icuNumeric(value any) (float64, string, bool) {
+	_, literal, ok := toPluralOperands(value)
+	if !ok {
+		return 0, "", false
+	}
+	number, ok := literalFloat(literal)
+	if !ok {
+		return 0, "", false
+	}
+	return number, literal, true
+}
+
+// formatNumber renders a {name, number, style} argument through
+// FormatInput.FormatNumber when set.
+func (r *icuRenderer) formatNumber(value any, style string) string {
+	number, literal, ok := icuNumeric(value)
+	if !ok {
+		return fmt.Sprint(value)
+	}
+	return formatNumberLiteral(r.input.FormatNumber, r.input.NumberSymbols, r.locale, number, literal, parseNumberStyle(style))
+}
+
+// formatPound renders '#' with the visible decimals of the plural value.
+func (r *icuRenderer) formatPound(literal string) string {
+	if r.input.FormatNumber == nil || literal == "" {
+		return literal
+	}
+	number, ok := literalFloat(literal)
+	if !ok {
+		return literal
+	}
+	return formatNumberLiteral(r.input.FormatNumber, r.input.NumberSymbols, r.locale, number, literal, numberStyle{decimals: -1})
+}
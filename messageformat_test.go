@@ -0,0 +1,355 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func loadTestPluralRules(t *testing.T) map[string]*PluralRuleSet {
+	t.Helper()
+	path := filepath.Join("testdata", "cldr_cardinal.json")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read plural rules: %v", err)
+	}
+	rules, err := decodePluralRules(path, data)
+	if err != nil {
+		t.Fatalf("decode plural rules: %v", err)
+	}
+	return rules.cardinal
+}
+
+func TestICUFormatterFormat(t *testing.T) {
+	rules := loadTestPluralRules(t)
+	formatter := NewICUFormatter(
+		WithICULocale("en"),
+		WithICUPluralRules(rules["en"], rules["ru"]),
+	)
+
+	tests := []struct {
+		name     string
+		template string
+		args     []any
+		want     string
+	}{
+		{
+			name:     "plain text",
+			template: "Hello world",
+			want:     "Hello world",
+		},
+		{
+			name:     "named argument",
+			template: "Hello {name}",
+			args:     []any{map[string]any{"name": "Alice"}},
+			want:     "Hello Alice",
+		},
+		{
+			name:     "positional argument",
+			template: "{0} and {1}",
+			args:     []any{"tea", "cake"},
+			want:     "tea and cake",
+		},
+		{
+			name:     "missing argument is preserved",
+			template: "Hello {name}",
+			want:     "Hello {name}",
+		},
+		{
+			name:     "plural one",
+			template: "{n, plural, one {# item} other {# items}}",
+			args:     []any{map[string]any{"n": 1}},
+			want:     "1 item",
+		},
+		{
+			name:     "plural other",
+			template: "{n, plural, one {# item} other {# items}}",
+			args:     []any{map[string]any{"n": 7}},
+			want:     "7 items",
+		},
+		{
+			name:     "plural exact match",
+			template: "{n, plural, =0 {No items} one {# item} other {# items}}",
+			args:     []any{map[string]any{"n": 0}},
+			want:     "No items",
+		},
+		{
+			name:     "plural offset",
+			template: "{n, plural, offset:1 =0 {Nobody} =1 {{host}} one {{host} and # other} other {{host} and # others}}",
+			args:     []any{map[string]any{"n": 3, "host": "Ana"}},
+			want:     "Ana and 2 others",
+		},
+		{
+			name:     "select",
+			template: "{g, select, female {She} male {He} other {They}} replied",
+			args:     []any{map[string]any{"g": "female"}},
+			want:     "She replied",
+		},
+		{
+			name:     "select falls back to other",
+			template: "{g, select, female {She} male {He} other {They}} replied",
+			args:     []any{map[string]any{"g": "unknown"}},
+			want:     "They replied",
+		},
+		{
+			name:     "nested select and plural",
+			template: "{g, select, female {{n, plural, one {She has # cat} other {She has # cats}}} other {{n, plural, one {They have # cat} other {They have # cats}}}}",
+			args:     []any{map[string]any{"g": "female", "n": 2}},
+			want:     "She has 2 cats",
+		},
+		{
+			name:     "pound inside nested select",
+			template: "{n, plural, other {{g, select, other {# total}}}}",
+			args:     []any{map[string]any{"n": 4, "g": "x"}},
+			want:     "4 total",
+		},
+		{
+			name:     "apostrophe escaping",
+			template: "It''s '{literal}' and '#' {n, plural, other {'#' #}}",
+			args:     []any{map[string]any{"n": 5}},
+			want:     "It's {literal} and '#' # 5",
+		},
+		{
+			name:     "lone apostrophe",
+			template: "don't",
+			want:     "don't",
+		},
+		{
+			name:     "number styles",
+			template: "{a, number} {b, number, integer} {c, number, percent}",
+			args:     []any{map[string]any{"a": 1.5, "b": 2.6, "c": 0.25}},
+			want:     "1.5 3 25%",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := formatter.Format(tc.template, tc.args...)
+			if err != nil {
+				t.Fatalf("Format: %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("Format() = %q want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestICUFormatterUsesLocaleRules(t *testing.T) {
+	rules := loadTestPluralRules(t)
+	formatter := NewICUFormatter(WithICUPluralRules(rules["ru"]))
+
+	template := "{n, plural, one {# файл} few {# файла} many {# файлов} other {# файла}}"
+
+	tests := []struct {
+		count any
+		want  string
+	}{
+		{count: 1, want: "1 файл"},
+		{count: 3, want: "3 файла"},
+		{count: 5, want: "5 файлов"},
+		{count: 21, want: "21 файл"},
+		{count: 1.5, want: "1.5 файла"},
+	}
+
+	for _, tc := range tests {
+		got, err := formatter.FormatMessage(FormatInput{
+			Locale:   "ru-RU",
+			Template: template,
+			Named:    map[string]any{"n": tc.count},
+		})
+		if err != nil {
+			t.Fatalf("FormatMessage(%v): %v", tc.count, err)
+		}
+		if got != tc.want {
+			t.Fatalf("FormatMessage(%v) = %q want %q", tc.count, got, tc.want)
+		}
+	}
+}
+
+func TestICUFormatterSelectOrdinal(t *testing.T) {
+	ordinal := &PluralRuleSet{
+		Locale: "en",
+		Rules: []PluralRule{
+			{Category: PluralOne, Groups: [][]PluralCondition{{
+				{Operand: "n", Mod: 10, Operator: OperatorIn, Values: []float64{1}},
+				{Operand: "n", Mod: 100, Operator: OperatorNotIn, Values: []float64{11}},
+			}}},
+			{Category: PluralTwo, Groups: [][]PluralCondition{{
+				{Operand: "n", Mod: 10, Operator: OperatorIn, Values: []float64{2}},
+				{Operand: "n", Mod: 100, Operator: OperatorNotIn, Values: []float64{12}},
+			}}},
+			{Category: PluralOther},
+		},
+	}
+	formatter := NewICUFormatter(WithICULocale("en"), WithICUOrdinalRules(ordinal))
+	template := "{pos, selectordinal, one {#st} two {#nd} other {#th}}"
+
+	for count, want := range map[int]string{1: "1st", 2: "2nd", 11: "11th", 22: "22nd", 5: "5th"} {
+		got, err := formatter.Format(template, map[string]any{"pos": count})
+		if err != nil {
+			t.Fatalf("Format(%d): %v", count, err)
+		}
+		if got != want {
+			t.Fatalf("Format(%d) = %q want %q", count, got, want)
+		}
+	}
+}
+
+func TestICUFormatterErrors(t *testing.T) {
+	formatter := NewICUFormatter()
+
+	tests := []struct {
+		name     string
+		template string
+		args     []any
+	}{
+		{name: "unterminated argument", template: "Hello {name"},
+		{name: "unexpected brace", template: "Hello }"},
+		{name: "missing other", template: "{n, plural, one {x}}", args: []any{map[string]any{"n": 1}}},
+		{name: "unknown type", template: "{n, spreadsheet}"},
+		{name: "duplicate selector", template: "{g, select, a {x} a {y} other {z}}"},
+		{name: "missing plural argument", template: "{n, plural, other {x}}"},
+		{name: "non numeric plural", template: "{n, plural, other {x}}", args: []any{map[string]any{"n": "many"}}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := formatter.Format(tc.template, tc.args...); err == nil {
+				t.Fatalf("expected error for %q", tc.template)
+			}
+		})
+	}
+}
+
+func TestICUFormatterCacheIsBounded(t *testing.T) {
+	formatter := NewICUFormatter(WithICUCacheSize(2))
+	for i := range 5 {
+		template := fmt.Sprintf("{name} #%d", i)
+		if got, err := formatter.Format(template, map[string]any{"name": "Ana"}); err != nil || got != fmt.Sprintf("Ana #%d", i) {
+			t.Fatalf("Format(%q) = %q, %v", template, got, err)
+		}
+	}
+	formatter.Format("{name} #3", map[string]any{"name": "Ana"})
+	formatter.Format("{name} #5", map[string]any{"name": "Ana"})
+	if got := formatter.order.Len(); got != 2 || len(formatter.parsed) != 2 {
+		t.Fatalf("cache holds %d templates, want 2", got)
+	}
+	if _, ok := formatter.parsed["{name} #3"]; !ok {
+		t.Fatal("recently used template was evicted")
+	}
+	if _, err := formatter.Format("{name"); err == nil {
+		t.Fatal("expected parse error")
+	}
+	if _, err := formatter.Format("{name"); err == nil {
+		t.Fatal("expected cached parse error")
+	}
+
+	uncached := NewICUFormatter(WithICUCacheSize(0))
+	if got, _ := uncached.Format("{name}", map[string]any{"name": "Bo"}); got != "Bo" || len(uncached.parsed) != 0 {
+		t.Fatalf("uncached Format = %q, cache %d", got, len(uncached.parsed))
+	}
+}
+
+func TestExtractFormatArgsICU(t *testing.T) {
+	tests := []struct {
+		template string
+		want     []string
+	}{
+		{template: "Hello {name}, you have {count} new {kind}", want: []string{"kind", "name"}},
+		{template: "{gender, select, female {She} male {He} other {They}} liked {post}", want: []string{"gender", "post"}},
+		{template: "{count, plural, one {# item from {seller}} other {# items}}", want: []string{"seller"}},
+		{template: "{total, number, ::.00} {when, date, short}", want: []string{"total", "when"}},
+		{template: "{gender, select, female {{name} liked it} other {They}}", want: []string{"gender", "name"}},
+	}
+
+	for _, tc := range tests {
+		got := extractFormatArgs(tc.template)
+		if strings.Join(got, ",") != strings.Join(tc.want, ",") {
+			t.Fatalf("extractFormatArgs(%q) = %v want %v", tc.template, got, tc.want)
+		}
+	}
+}
+
+func TestSimpleTranslatorStrictArgsWithICUSelect(t *testing.T) {
+	template := "{gender, select, female {She} male {He} other {They}} liked {post}"
+	store := NewStaticStore(Translations{
+		"en": {
+			Locale: Locale{Code: "en"},
+			Messages: map[string]Message{
+				"feed.liked": {
+					MessageMetadata: MessageMetadata{ID: "feed.liked", Locale: "en"},
+					Variants:        map[PluralCategory]MessageVariant{PluralOther: buildVariant(template, "")},
+				},
+			},
+		},
+	})
+	translator, err := NewSimpleTranslator(store,
+		WithTranslatorDefaultLocale("en"),
+		WithTranslatorFormatter(NewICUFormatter()),
+		WithTranslatorStrictArgs(true),
+	)
+	if err != nil {
+		t.Fatalf("NewSimpleTranslator: %v", err)
+	}
+
+	got, meta, err := translator.TranslateWithMetadata("en", "feed.liked", WithSelect("gender", "male"), WithArg("post", "your photo"))
+	if err != nil {
+		t.Fatalf("TranslateWithMetadata: %v", err)
+	}
+	if got != "He liked your photo" || meta[metadataArgsMissing] != nil {
+		t.Fatalf("got %q, metadata %v", got, meta)
+	}
+
+	var argsErr *MissingArgsError
+	_, err = translator.Translate("en", "feed.liked", WithSelect("gender", "male"))
+	if !errors.As(err, &argsErr) || len(argsErr.Args) != 1 || argsErr.Args[0] != "post" {
+		t.Fatalf("expected MissingArgsError for post, got %v", err)
+	}
+}
+
+func TestSimpleTranslatorWithICUFormatter(t *testing.T) {
+	rules := loadTestPluralRules(t)
+
+	catalog := newStringCatalog("en", map[string]string{
+		"cart.items":   "{count, plural, =0 {Your cart is empty} one {# item in cart} other {# items in cart}}",
+		"user.welcome": "Welcome back, {0}",
+		"feed.liked":   "{gender, select, female {She} male {He} other {They}} liked {post}",
+	})
+	catalog.CardinalRules = rules["en"]
+
+	store := NewStaticStore(Translations{"en": catalog})
+
+	translator, err := NewSimpleTranslator(store,
+		WithTranslatorDefaultLocale("en"),
+		WithTranslatorFormatter(NewICUFormatter()),
+	)
+	if err != nil {
+		t.Fatalf("NewSimpleTranslator: %v", err)
+	}
+
+	tests := []struct {
+		key  string
+		args []any
+		want string
+	}{
+		{key: "cart.items", args: []any{WithCount(0)}, want: "Your cart is empty"},
+		{key: "cart.items", args: []any{WithCount(1)}, want: "1 item in cart"},
+		{key: "cart.items", args: []any{WithCount(12)}, want: "12 items in cart"},
+		{key: "user.welcome", args: []any{"Ana"}, want: "Welcome back, Ana"},
+		{key: "feed.liked", args: []any{WithArgs(map[string]any{"gender": "female", "post": "your photo"})}, want: "She liked your photo"},
+	}
+
+	for _, tc := range tests {
+		got, err := translator.Translate("en-GB", tc.key, tc.args...)
+		if err != nil {
+			t.Fatalf("Translate(%s): %v", tc.key, err)
+		}
+		if got != tc.want {
+			t.Fatalf("Translate(%s) = %q want %q", tc.key, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package i18n
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var _ TranslationHook = &MissingTranslationCollector{}
+
+// MissingTranslation is a key, or the plural categories of a key, that a
+// locale lacks. Categories is empty when the whole key is missing; Category
+// is the plural category requested when the key was missing; Hits counts the
+// translations that ran into the gap.
+type MissingTranslation struct {
+	Locale     string           `json:"locale"`
+	Key        string           `json:"key"`
+	Category   PluralCategory   `json:"category,omitempty"`
+	Categories []PluralCategory `json:"categories,omitempty"`
+	Hits       int              `json:"hits"`
+}
+
+type missingEntry struct {
+	keyMissing bool
+	category   PluralCategory
+	categories map[PluralCategory]struct{}
+	hits       int
+}
+
+// MissingCollectorOption configures MissingTranslationCollector
+type MissingCollectorOption func(*MissingTranslationCollector)
+
+// WithMissingFallbacks also records keys served by a fallback locale that is
+// not a parent of the requested one (es served by the default en, but not
+// en-US served by en).
+func WithMissingFallbacks() MissingCollectorOption {
+	return func(c *MissingTranslationCollector) {
+		c.fallbacks = true
+	}
+}
+
+// MissingTranslationCollector is a TranslationHook that records failed
+// lookups and plural categories that fell back to "other". It is safe for
+// concurrent use.
+type MissingTranslationCollector struct {
+	fallbacks bool
+
+	mu      sync.Mutex
+	entries map[MessageRef]*missingEntry
+}
+
+// NewMissingTranslationCollector returns an empty collector.
+func NewMissingTranslationCollector(opts ...MissingCollectorOption) *MissingTranslationCollector {
+	c := &MissingTranslationCollector{entries: make(map[MessageRef]*missingEntry)}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(c)
+		}
+	}
+	return c
+}
+
+func (c *MissingTranslationCollector) BeforeTranslate(*TranslatorHookContext) {}
+
+func (c *MissingTranslationCollector) AfterTranslate(ctx *TranslatorHookContext) {
+	if c == nil || ctx == nil {
+		return
+	}
+
+	var missing *MissingTranslationError
+	if errors.As(ctx.Error, &missing) {
+		c.recordKey(missing.Locale, missing.Key, missing.Category)
+		return
+	}
+	if ctx.Error != nil {
+		return
+	}
+
+	served := ctx.Locale
+	if locale, ok := ctx.LocaleMetadata(); ok {
+		served = locale.Resolved
+		if c.fallbacks && locale.Fallback && locale.Requested != "" && !isParentLocale(locale.Requested, locale.Resolved) {
+			category := PluralCategory("")
+			if plural, ok := ctx.PluralMetadata(); ok {
+				category = plural.Category
+			}
+			c.recordKey(locale.Requested, ctx.Key, category)
+		}
+	}
+
+	if plural, ok := ctx.PluralMetadata(); ok && plural.Missing != nil {
+		c.recordCategory(served, ctx.Key, plural.Missing.Requested)
+	}
+}
+
+func (c *MissingTranslationCollector) entry(locale, key string) *missingEntry {
+	if c.entries == nil {
+		c.entries = make(map[MessageRef]*missingEntry)
+	}
+	ref := MessageRef{Locale: locale, Key: key}
+	entry := c.entries[ref]
+	if entry == nil {
+		entry = &missingEntry{}
+		c.entries[ref] = entry
+	}
+	return entry
+}
+
+func (c *MissingTranslationCollector) recordKey(locale, key string, category PluralCategory) {
+	if locale == "" || key == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := c.entry(locale, key)
+	entry.keyMissing = true
+	if category != "" {
+		entry.category = category
+	}
+	entry.hits++
+}
+
+func (c *MissingTranslationCollector) recordCategory(locale, key string, category PluralCategory) {
+	if locale == "" || key == "" || category == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := c.entry(locale, key)
+	if entry.categories == nil {
+		entry.categories = make(map[PluralCategory]struct{})
+	}
+	entry.categories[category] = struct{}{}
+	entry.hits++
+}
+
+// Missing returns the recorded gaps sorted by locale and key.
+func (c *MissingTranslationCollector) Missing() []MissingTranslation {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	out := make([]MissingTranslation, 0, len(c.entries))
+	for ref, entry := range c.entries {
+		item := MissingTranslation{Locale: ref.Locale, Key: ref.Key, Hits: entry.hits}
+		if entry.keyMissing {
+			item.Category = entry.category
+		} else {
+			for category := range entry.categories {
+				item.Categories = append(item.Categories, category)
+			}
+			sort.Slice(item.Categories, func(i, j int) bool {
+				return pluralCategoryOrder(item.Categories[i]) < pluralCategoryOrder(item.Categories[j])
+			})
+		}
+		out = append(out, item)
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Locale != out[j].Locale {
+			return out[i].Locale < out[j].Locale
+		}
+		return out[i].Key < out[j].Key
+	})
+	return out
+}
+
+// Reset drops everything recorded so far.
+func (c *MissingTranslationCollector) Reset() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	c.entries = make(map[MessageRef]*missingEntry)
+	c.mu.Unlock()
+}
+
+// isParentLocale reports whether parent is on the implicit parent chain of
+// locale (en for en-US).
+func isParentLocale(locale, parent string) bool {
+	for _, candidate := range localeParentChain(locale) {
+		if strings.EqualFold(candidate, parent) {
+			return true
+		}
+	}
+	return false
+}
+
+// WriteJSON exports the gaps as a locale-keyed JSON catalog for translators:
+// missing keys map to null and missing plural categories to a variant map of
+// nulls that always carries "other". Loaders skip messages with null
+// templates, so the file can sit next to the real catalogs until every
+// template of a message is filled in.
+func (c *MissingTranslationCollector) WriteJSON(w io.Writer) error {
+	catalog := make(map[string]map[string]any)
+	for _, item := range c.Missing() {
+		messages := catalog[item.Locale]
+		if messages == nil {
+			messages = make(map[string]any)
+			catalog[item.Locale] = messages
+		}
+		if len(item.Categories) == 0 {
+			messages[item.Key] = nil
+			continue
+		}
+		variants := map[string]any{string(PluralOther): nil}
+		for _, category := range item.Categories {
+			variants[string(category)] = nil
+		}
+		messages[item.Key] = variants
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(catalog)
+}
@@ -0,0 +1,179 @@
+package i18n
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestMissingTranslationError(t *testing.T) {
+	translator, err := NewSimpleTranslator(newRangeStore(t), WithTranslatorDefaultLocale("en"))
+	if err != nil {
+		t.Fatalf("NewSimpleTranslator: %v", err)
+	}
+
+	_, err = translator.Translate("fr", "cart.items", WithCount(5))
+	var missing *MissingTranslationError
+	if !errors.As(err, &missing) {
+		t.Fatalf("expected MissingTranslationError, got %T %v", err, err)
+	}
+	if !errors.Is(err, ErrMissingTranslation) {
+		t.Fatal("MissingTranslationError must unwrap to ErrMissingTranslation")
+	}
+	if missing.Locale != "fr" || missing.Key != "cart.items" || missing.Category != PluralOther {
+		t.Fatalf("unexpected error %#v", missing)
+	}
+	if !reflect.DeepEqual(missing.Tried, []string{"fr", "en"}) {
+		t.Fatalf("Tried = %v", missing.Tried)
+	}
+
+	_, err = translator.Translate("fr", "cart.items", WithCount(1))
+	if errors.As(err, &missing); missing.Category != PluralOne {
+		t.Fatalf("Category = %q want one", missing.Category)
+	}
+}
+
+func newMissingCollectorStore() Store {
+	return NewStaticStore(Translations{
+		"en": newStringCatalog("en", map[string]string{"home.title": "Welcome"}),
+		"ru": {
+			Locale: Locale{Code: "ru"},
+			Messages: map[string]Message{
+				"files": {
+					MessageMetadata: MessageMetadata{ID: "files", Locale: "ru"},
+					Variants: map[PluralCategory]MessageVariant{
+						PluralOne:   {Template: "{count} файл"},
+						PluralOther: {Template: "{count} файла"},
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestMissingTranslationCollector(t *testing.T) {
+	base, err := NewSimpleTranslator(newMissingCollectorStore(), WithTranslatorDefaultLocale("en"))
+	if err != nil {
+		t.Fatalf("NewSimpleTranslator: %v", err)
+	}
+
+	collector := NewMissingTranslationCollector()
+	translator := WrapTranslatorWithHooks(base, collector)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			translator.Translate("en-US", "home.title")
+			translator.Translate("ru", "home.title")
+			translator.Translate("ru", "files", WithCount(5))
+			translator.Translate("ru", "files", WithCount(3))
+			translator.Translate("ru", "files", WithCount(1))
+			translator.Translate("en", "nav.back")
+		}()
+	}
+	wg.Wait()
+
+	// Only failed lookups and plural gaps are recorded: en-US is served by
+	// its parent en and ru by the default locale.
+	want := []MissingTranslation{
+		{Locale: "en", Key: "nav.back", Hits: 8},
+		{Locale: "ru", Key: "files", Categories: []PluralCategory{PluralFew, PluralMany}, Hits: 16},
+	}
+	if got := collector.Missing(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Missing = %#v\nwant %#v", got, want)
+	}
+
+	collector.Reset()
+	if got := collector.Missing(); len(got) != 0 {
+		t.Fatalf("Reset left %v", got)
+	}
+}
+
+func TestMissingTranslationCollectorFallbacks(t *testing.T) {
+	base, err := NewSimpleTranslator(newMissingCollectorStore(), WithTranslatorDefaultLocale("en"))
+	if err != nil {
+		t.Fatalf("NewSimpleTranslator: %v", err)
+	}
+
+	failedOnly := NewMissingTranslationCollector()
+	withFallbacks := NewMissingTranslationCollector(WithMissingFallbacks())
+	translator := WrapTranslatorWithHooks(base, failedOnly, withFallbacks)
+
+	translator.Translate("en-US", "home.title")
+	translator.Translate("ru", "home.title")
+	translator.Translate("ru", "nav.back")
+
+	if got, want := failedOnly.Missing(), []MissingTranslation{{Locale: "ru", Key: "nav.back", Hits: 1}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("failed lookups = %#v want %#v", got, want)
+	}
+	want := []MissingTranslation{
+		{Locale: "ru", Key: "home.title", Hits: 1},
+		{Locale: "ru", Key: "nav.back", Hits: 1},
+	}
+	if got := withFallbacks.Missing(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("with fallbacks = %#v want %#v", got, want)
+	}
+}
+
+func TestMissingTranslationCollectorWriteJSON(t *testing.T) {
+	base, err := NewSimpleTranslator(newMissingCollectorStore(), WithTranslatorDefaultLocale("en"))
+	if err != nil {
+		t.Fatalf("NewSimpleTranslator: %v", err)
+	}
+	collector := NewMissingTranslationCollector(WithMissingFallbacks())
+	translator := WrapTranslatorWithHooks(base, collector)
+	translator.Translate("ru", "home.title")
+	translator.Translate("ru", "files", WithCount(5))
+
+	var buf bytes.Buffer
+	if err := collector.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+	var exported map[string]map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &exported); err != nil {
+		t.Fatalf("decode export: %v", err)
+	}
+	wantExport := map[string]map[string]any{
+		"ru": {
+			"home.title": nil,
+			"files":      map[string]any{"many": nil, "other": nil},
+		},
+	}
+	if !reflect.DeepEqual(exported, wantExport) {
+		t.Fatalf("export = %s", buf.String())
+	}
+
+	// Stubs next to the real catalog must not shadow the fallback or the
+	// existing plural variants.
+	dir := t.TempDir()
+	catalogPath := writeTempFile(t, dir, "catalog.json", []byte(`{"en": {"home.title": "Welcome"}, "ru": {"files": {"one": "{count} файл", "other": "{count} файла"}}}`))
+	stubPath := writeTempFile(t, dir, "missing.json", buf.Bytes())
+	store, err := NewStaticStoreFromLoader(NewFileLoader(catalogPath, stubPath))
+	if err != nil {
+		t.Fatalf("catalog with stubs does not load: %v", err)
+	}
+	reloaded, err := NewSimpleTranslator(store, WithTranslatorDefaultLocale("en"))
+	if err != nil {
+		t.Fatalf("NewSimpleTranslator: %v", err)
+	}
+	if got, _ := reloaded.Translate("ru", "home.title"); got != "Welcome" {
+		t.Fatalf("stub hid the fallback, got %q", got)
+	}
+	if got, _ := reloaded.Translate("ru", "files", WithCount(5)); got != "5 файла" {
+		t.Fatalf("stub replaced plural variants, got %q", got)
+	}
+
+	yamlPath := writeTempFile(t, dir, "ru.yaml", []byte("home.title: ~\nfiles:\n  many: ~\n  other: ~\n"))
+	translations, err := NewFileLoader(yamlPath).WithSingleLocaleCatalogs().Load()
+	if err != nil {
+		t.Fatalf("YAML stubs do not load: %v", err)
+	}
+	if messages := translations["ru"].Messages; len(messages) != 0 {
+		t.Fatalf("YAML stubs loaded as messages: %v", messages)
+	}
+}
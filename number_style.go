@@ -0,0 +1,294 @@
+package i18n
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// NumberFormatFunc formats value for locale with a fixed number of decimals.
+// It matches the "format_number" helper of FormatterRegistry.
+type NumberFormatFunc func(locale string, value float64, decimals int) string
+
+// NumberSymbols is the layout a locale "format_number" helper writes digits
+// with. FormatterRegistry serves it as the "number_symbols" helper, a
+// func(locale string) NumberSymbols, so digits a float64 cannot hold are laid
+// out the same way.
+type NumberSymbols struct {
+	Decimal string
+	Group   string
+	// PrimaryGroup and SecondaryGroup are the group sizes next to the decimal
+	// point and further left (3 and 2 for "12,34,567"); zero disables grouping.
+	PrimaryGroup   int
+	SecondaryGroup int
+	// Zero is the zero digit of the numbering system; unset means '0'.
+	Zero rune
+	// CompactPatterns are the CLDR short decimal patterns keyed by the power
+	// of ten they start at ("0K" at 3, "00K" at 4). Without them the compact
+	// style writes the plain number.
+	CompactPatterns map[int]string
+}
+
+// numberSymbolsISO matches FormatNumber: a "." decimal point and no grouping.
+func numberSymbolsISO(_ string) NumberSymbols {
+	return NumberSymbols{Decimal: "."}
+}
+
+// countPlaceholderPattern matches {count} and {count, number[, style]} in
+// plain (non ICU) templates.
+var countPlaceholderPattern = regexp.MustCompile(`\{count(?:\s*,\s*number(?:\s*,\s*([^{}]*?))?)?\s*\}`)
+
+// numberStyle is a parsed {name, number, style} argument style:
+//
+//	(none)           visible fraction digits of the value ("1,000", "2.50")
+//	integer          rounded to a whole number
+//	percent          value * 100 followed by "%"
+//	compact          CLDR short form ("1.2K", "3M"); also ::compact-short
+//	::.00            exactly as many decimals as zeros
+type numberStyle struct {
+	decimals int
+	integer  bool
+	percent  bool
+	compact  bool
+}
+
+func parseNumberStyle(style string) numberStyle {
+	parsed := numberStyle{decimals: -1}
+	style = strings.ToLower(strings.TrimSpace(style))
+	switch {
+	case style == "integer":
+		parsed.integer = true
+		parsed.decimals = 0
+	case style == "percent":
+		parsed.percent = true
+	case style == "compact", style == "::compact-short":
+		parsed.compact = true
+	case strings.HasPrefix(style, "::."):
+		digits := strings.TrimLeft(style[3:], "0")
+		if digits == "" {
+			parsed.decimals = len(style) - 3
+		}
+	}
+	return parsed
+}
+
+// formatNumberLiteral renders a numeric argument in the given style. literal
+// is the argument as written, which decides the decimals of unstyled output.
+// A nil format keeps unstyled numbers as written and uses FormatNumber
+// otherwise. symbols describes the output of format; see formatDecimal.
+func formatNumberLiteral(format NumberFormatFunc, symbols *NumberSymbols, locale string, value float64, literal string, style numberStyle) string {
+	if format == nil {
+		if style == (numberStyle{decimals: -1}) {
+			return literal
+		}
+		format = FormatNumber
+	}
+
+	decimals := style.decimals
+	if decimals < 0 {
+		decimals = literalDecimals(literal)
+	}
+
+	switch {
+	case style.integer:
+		return formatDecimal(format, symbols, locale, value, literal, 0)
+	case style.percent:
+		value *= 100
+		if style.decimals < 0 {
+			decimals = literalDecimals(strconv.FormatFloat(value, 'f', -1, 64))
+		}
+		return format(locale, value, decimals) + "%"
+	case style.compact:
+		return formatCompactNumber(format, symbols, locale, value, literal, decimals)
+	default:
+		return formatDecimal(format, symbols, locale, value, literal, decimals)
+	}
+}
+
+// formatDecimal formats literal with decimals fraction digits. Values a
+// float64 holds exactly go through format; longer ones are rounded as
+// decimal strings and laid out with symbols, so counts beyond 2^53 keep every
+// digit. Without symbols those digits are written plainly, with a "." decimal
+// point and no grouping.
+func formatDecimal(format NumberFormatFunc, symbols *NumberSymbols, locale string, value float64, literal string, decimals int) string {
+	if exact, ok := canonicalDecimal(literal); !ok || exact == strconv.FormatFloat(value, 'f', -1, 64) {
+		if decimals == 0 {
+			value = math.Round(value)
+		}
+		return format(locale, value, decimals)
+	}
+	layout := numberSymbolsISO(locale)
+	if symbols != nil {
+		layout = *symbols
+	}
+	zero := layout.Zero
+	if zero == 0 {
+		zero = '0'
+	}
+
+	negative := strings.HasPrefix(literal, "-")
+	intPart, fracPart, _ := strings.Cut(strings.TrimLeft(literal, "+-"), ".")
+	intPart, fracPart = roundDecimal(intPart, fracPart, decimals)
+	if strings.Trim(intPart+fracPart, "0") == "" {
+		negative = false
+	}
+
+	var b strings.Builder
+	if negative {
+		b.WriteByte('-')
+	}
+	for i, digit := range intPart {
+		if i > 0 && layout.Group != "" && layout.groupBoundary(len(intPart)-i) {
+			b.WriteString(layout.Group)
+		}
+		b.WriteRune(zero + digit - '0')
+	}
+	if fracPart != "" {
+		b.WriteString(layout.Decimal)
+		for _, digit := range fracPart {
+			b.WriteRune(zero + digit - '0')
+		}
+	}
+	return b.String()
+}
+
+// roundDecimal rounds the decimal intPart.fracPart half away from zero, like
+// math.Round, to decimals fraction digits, padding with zeros. A negative
+// decimals keeps fracPart.
+func roundDecimal(intPart, fracPart string, decimals int) (string, string) {
+	intPart = strings.TrimLeft(intPart, "0")
+	if intPart == "" {
+		intPart = "0"
+	}
+	if decimals < 0 {
+		return intPart, fracPart
+	}
+	if len(fracPart) <= decimals {
+		return intPart, fracPart + strings.Repeat("0", decimals-len(fracPart))
+	}
+
+	digits := []byte(intPart + fracPart[:decimals])
+	rest := fracPart[decimals:]
+	up := rest[0] >= '5'
+	for i := len(digits) - 1; up && i >= 0; i-- {
+		if digits[i] == '9' {
+			digits[i] = '0'
+			continue
+		}
+		digits[i]++
+		up = false
+	}
+	if up {
+		digits = append([]byte{'1'}, digits...)
+	}
+
+	point := len(digits) - decimals
+	return string(digits[:point]), string(digits[point:])
+}
+
+// groupBoundary reports whether a group separator goes before the digit
+// that has remaining integer digits from it to the decimal point.
+func (s NumberSymbols) groupBoundary(remaining int) bool {
+	if s.PrimaryGroup <= 0 || remaining < s.PrimaryGroup {
+		return false
+	}
+	if remaining == s.PrimaryGroup {
+		return true
+	}
+	return s.SecondaryGroup > 0 && (remaining-s.PrimaryGroup)%s.SecondaryGroup == 0
+}
+
+// formatCompactNumber applies the short pattern for the magnitude of value,
+// keeping one decimal below ten ("1.2K", "15K"). Values below the first
+// pattern, and locales without patterns, are written as plain numbers.
+func formatCompactNumber(format NumberFormatFunc, symbols *NumberSymbols, locale string, value float64, literal string, decimals int) string {
+	if symbols == nil || len(symbols.CompactPatterns) == 0 || math.IsInf(value, 0) {
+		return formatDecimal(format, symbols, locale, value, literal, decimals)
+	}
+	patterns := symbols.CompactPatterns
+	largest := 0
+	for power := range patterns {
+		largest = max(largest, power)
+	}
+
+	power := integerDigits(value) - 1
+	for {
+		start := min(power, largest)
+		for ; start >= 0; start-- {
+			if _, ok := patterns[start]; ok {
+				break
+			}
+		}
+		if start < 0 {
+			return formatDecimal(format, symbols, locale, value, literal, decimals)
+		}
+		pattern := patterns[start]
+		zeros := strings.Count(pattern, "0")
+		if zeros == 0 || pattern == "0" {
+			return formatDecimal(format, symbols, locale, value, literal, decimals)
+		}
+
+		scale := start - zeros + 1
+		scaled := value / math.Pow10(scale)
+		digits := 0
+		if math.Abs(scaled) < 10 {
+			scaled = math.Round(scaled*10) / 10
+			if scaled != math.Trunc(scaled) {
+				digits = 1
+			}
+		} else {
+			scaled = math.Round(scaled)
+		}
+		// 999,999 rounds to 1000K; use the pattern of the next magnitude.
+		if rounded := scale + integerDigits(scaled) - 1; rounded > power {
+			power = rounded
+			continue
+		}
+		return strings.Replace(pattern, strings.Repeat("0", zeros), format(locale, scaled, digits), 1)
+	}
+}
+
+// integerDigits counts the digits of the integer part of value.
+func integerDigits(value float64) int {
+	return len(strconv.FormatFloat(math.Trunc(math.Abs(value)), 'f', 0, 64))
+}
+
+// literalFloat returns the float64 nearest to a decimal literal, saturating
+// to an infinity beyond its range; the literal keeps the exact digits.
+func literalFloat(literal string) (float64, bool) {
+	value, err := strconv.ParseFloat(literal, 64)
+	if err != nil && !errors.Is(err, strconv.ErrRange) {
+		return 0, false
+	}
+	return value, true
+}
+
+// subtractDecimal returns the exact difference of two decimal literals, with
+// the fraction digits of the longer one ("5.50" - "1" is "4.50").
+func subtractDecimal(literal, offset string) string {
+	a, ok := new(big.Rat).SetString(literal)
+	if !ok {
+		return literal
+	}
+	b, ok := new(big.Rat).SetString(offset)
+	if !ok {
+		return literal
+	}
+	return a.Sub(a, b).FloatString(max(literalDecimals(literal), literalDecimals(offset)))
+}
+
+// literalDecimals returns the visible fraction digits of a numeric literal.
+func literalDecimals(literal string) int {
+	if _, frac, ok := strings.Cut(literal, "."); ok {
+		return len(frac)
+	}
+	return 0
+}
+
+// usesCountPlaceholder reports whether a plain template references {count}.
+func usesCountPlaceholder(template string) bool {
+	return strings.Contains(template, "{count") && countPlaceholderPattern.MatchString(template)
+}
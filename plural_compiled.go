@@ -0,0 +1,129 @@
+package i18n
+
+import "strings"
+
+// pluralRuleKind selects cardinal or ordinal rules.
+type pluralRuleKind int
+
+const (
+	cardinalRuleKind pluralRuleKind = iota
+	ordinalRuleKind
+)
+
+// compiledRuleStore is implemented by stores that compile their plural rules
+// when they are loaded.
+type compiledRuleStore interface {
+	compiledRules(locale string, kind pluralRuleKind) (*compiledPluralRules, bool)
+}
+
+// compiledPluralRules is a PluralRuleSet compiled into flat condition
+// tables. Selecting a category does not walk the rule structures, look
+// operands up by name or allocate. set is the source rule set; it is shared
+// and must not be modified.
+type compiledPluralRules struct {
+	set   *PluralRuleSet
+	rules []compiledPluralRule
+}
+
+// compiledPluralRule matches when any group matches; a rule without groups
+// always matches.
+type compiledPluralRule struct {
+	category PluralCategory
+	groups   [][]compiledPluralCondition
+	always   bool
+}
+
+// compiledPluralCondition is a condition with its operand resolved to a
+// single byte; kind 0 marks an unknown operand, which never matches.
+type compiledPluralCondition struct {
+	kind      byte
+	condition PluralCondition
+}
+
+// compilePluralRules compiles set once; the result is safe for concurrent
+// use.
+func compilePluralRules(set *PluralRuleSet) *compiledPluralRules {
+	if set == nil {
+		return nil
+	}
+
+	compiled := &compiledPluralRules{set: set}
+	for _, rule := range set.Rules {
+		if rule.Category == PluralOther {
+			continue
+		}
+		out := compiledPluralRule{category: rule.Category, always: len(rule.Groups) == 0}
+		for _, group := range rule.Groups {
+			if len(group) == 0 {
+				continue
+			}
+			conditions := make([]compiledPluralCondition, len(group))
+			for i, condition := range group {
+				conditions[i] = compilePluralCondition(condition)
+			}
+			out.groups = append(out.groups, conditions)
+		}
+		compiled.rules = append(compiled.rules, out)
+	}
+	return compiled
+}
+
+func compilePluralCondition(condition PluralCondition) compiledPluralCondition {
+	operand := strings.ToLower(condition.Operand)
+	compiled := compiledPluralCondition{condition: condition}
+	if len(operand) == 1 {
+		compiled.kind = operand[0]
+	}
+	return compiled
+}
+
+// selectCategory is the compiled counterpart of selectPluralCategory.
+func (c *compiledPluralRules) selectCategory(operands *pluralOperands) PluralCategory {
+	if c == nil {
+		return PluralOther
+	}
+	for i := range c.rules {
+		if c.rules[i].match(operands) {
+			return c.rules[i].category
+		}
+	}
+	return PluralOther
+}
+
+func (r *compiledPluralRule) match(operands *pluralOperands) bool {
+	if r.always {
+		return true
+	}
+	for _, group := range r.groups {
+		matched := true
+		for i := range group {
+			if !group[i].match(operands) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *compiledPluralCondition) match(operands *pluralOperands) bool {
+	if c.kind == 0 {
+		return false
+	}
+	value, integral, ok := readOperand(c.kind, c.condition.Mod, operands)
+	if !ok {
+		return false
+	}
+	return compareOperand(&c.condition, value, integral)
+}
+
+// ruleSet returns the source rule set, or nil.
+func (c *compiledPluralRules) ruleSet() *PluralRuleSet {
+	if c == nil {
+		return nil
+	}
+	return c.set
+}
@@ -0,0 +1,140 @@
+package i18n
+
+import (
+	"path/filepath"
+	"sort"
+	"strconv"
+	"testing"
+)
+
+// pluralSampleValues covers integers, decimals and values beyond int64.
+func pluralSampleValues(t testing.TB) []pluralOperands {
+	t.Helper()
+	var literals []string
+	for i := 0; i <= 220; i++ {
+		literals = append(literals, strconv.Itoa(i))
+	}
+	literals = append(literals,
+		"0.0", "0.5", "1.0", "1.5", "2.10", "3.25", "11.0", "21.5", "101.1",
+		"1000", "1000000", "1c6", "1.5c3", "1000021", "100000000000000000000000000022",
+	)
+
+	values := make([]pluralOperands, 0, len(literals))
+	for _, literal := range literals {
+		operands, _, ok := buildOperandsFromLiteral(literal)
+		if !ok {
+			t.Fatalf("invalid sample %q", literal)
+		}
+		values = append(values, operands)
+	}
+	return values
+}
+
+func loadCardinalTestRules(t testing.TB) ([]string, map[string]*PluralRuleSet) {
+	t.Helper()
+	rules, err := loadPluralRuleFiles(nil, []string{filepath.Join("testdata", "cldr_cardinal.json")})
+	if err != nil {
+		t.Fatalf("loadPluralRuleFiles: %v", err)
+	}
+	locales := make([]string, 0, len(rules.cardinal))
+	for locale := range rules.cardinal {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales, rules.cardinal
+}
+
+func TestCompiledPluralRulesMatchInterpreter(t *testing.T) {
+	values := pluralSampleValues(t)
+
+	locales, sets := loadCardinalTestRules(t)
+	for _, locale := range locales {
+		compiled := compilePluralRules(sets[locale])
+		for _, operands := range values {
+			if got, want := compiled.selectCategory(&operands), selectPluralCategory(sets[locale], operands); got != want {
+				t.Fatalf("%s %s.%s: compiled %s want %s", locale, operands.intDigits, operands.fracDigits, got, want)
+			}
+		}
+	}
+
+	for _, index := range []map[string]int{cldrCardinalRuleIndex, cldrOrdinalRuleIndex} {
+		for locale, id := range index {
+			set, err := ParsePluralRuleSet(locale, cldrPluralRuleData[id])
+			if err != nil {
+				t.Fatalf("%s: %v", locale, err)
+			}
+			compiled := compilePluralRules(set)
+			for _, operands := range values {
+				if got, want := compiled.selectCategory(&operands), selectPluralCategory(set, operands); got != want {
+					t.Fatalf("built-in %s %s.%s: compiled %s want %s", locale, operands.intDigits, operands.fracDigits, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestStaticStoreCompilesPluralRules(t *testing.T) {
+	_, sets := loadCardinalTestRules(t)
+	store := NewStaticStore(Translations{
+		"ru": {Locale: Locale{Code: "ru"}, CardinalRules: sets["ru"]},
+	})
+
+	compiled, ok := store.compiledRules("ru", cardinalRuleKind)
+	if !ok || compiled == nil {
+		t.Fatal("expected compiled ru rules")
+	}
+	if again, _ := store.compiledRules("ru", cardinalRuleKind); again != compiled {
+		t.Fatal("expected rules compiled once")
+	}
+	if _, ok := store.compiledRules("ru", ordinalRuleKind); ok {
+		t.Fatal("unexpected ordinal rules")
+	}
+
+	translator, err := NewSimpleTranslator(store, WithTranslatorDefaultLocale("ru"))
+	if err != nil {
+		t.Fatalf("NewSimpleTranslator: %v", err)
+	}
+	operands, _, _ := buildOperandsFromLiteral("22")
+	allocs := testing.AllocsPerRun(100, func() {
+		if got := translator.resolvePluralCategory("ru", Message{}, operands); got != PluralFew {
+			t.Fatalf("category = %s", got)
+		}
+	})
+	if allocs != 0 {
+		t.Fatalf("plural selection allocates %.0f times per call", allocs)
+	}
+}
+
+// BenchmarkPluralRuleSelection compares cloning and interpreting store rules
+// per call (the pre-compiled path) with the compiled evaluator, across all
+// locales of testdata/cldr_cardinal.json.
+func BenchmarkPluralRuleSelection(b *testing.B) {
+	values := pluralSampleValues(b)
+	locales, sets := loadCardinalTestRules(b)
+
+	translations := make(Translations, len(sets))
+	for locale, set := range sets {
+		translations[locale] = &TranslationCatalog{Locale: Locale{Code: locale}, CardinalRules: set}
+	}
+	store := NewStaticStore(translations)
+	translator, err := NewSimpleTranslator(store, WithTranslatorDefaultLocale("en"))
+	if err != nil {
+		b.Fatalf("NewSimpleTranslator: %v", err)
+	}
+
+	for _, locale := range locales {
+		b.Run(locale+"/interpreted", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				rules, _ := store.Rules(locale)
+				selectPluralCategory(rules, values[i%len(values)])
+			}
+		})
+		b.Run(locale+"/compiled", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				translator.resolvePluralCategory(locale, Message{}, values[i%len(values)])
+			}
+		})
+	}
+}
@@ -0,0 +1,296 @@
+package i18n
+
+import (
+	"context"
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+const (
+	// PseudoLocaleAccented renders accented, expanded text ("[Ŵéļçöɱé one]").
+	PseudoLocaleAccented = "en-XA"
+	// PseudoLocaleBidi renders right-to-left text using bidi override marks.
+	PseudoLocaleBidi = "ar-XB"
+)
+
+const (
+	bidiRLM = "\u200f"
+	bidiRLO = "\u202e"
+	bidiPDF = "\u202c"
+)
+
+// pseudoProtectedPattern matches output that pseudo-localization leaves
+// untouched: printf verbs, {placeholders}, HTML tags and entities.
+var pseudoProtectedPattern = regexp.MustCompile(`%(?:\[\d+\])?[-+#0]*(?:\d+|\*)?(?:\.(?:\d+|\*))?[a-zA-Z%]|\{[^{}]*\}|<[^<>]*>|&(?:[a-zA-Z]+|#\d+|#x[0-9a-fA-F]+);`)
+
+var pseudoAccents = map[rune]rune{
+	'a': 'á', 'b': 'ƀ', 'c': 'ç', 'd': 'ð', 'e': 'é', 'f': 'ƒ', 'g': 'ĝ', 'h': 'ĥ', 'i': 'î',
+	'j': 'ĵ', 'k': 'ķ', 'l': 'ļ', 'm': 'ɱ', 'n': 'ñ', 'o': 'ö', 'p': 'þ', 'q': 'ǫ', 'r': 'ŕ',
+	's': 'š', 't': 'ţ', 'u': 'û', 'v': 'ṽ', 'w': 'ŵ', 'x': 'ẋ', 'y': 'ý', 'z': 'ž',
+	'A': 'Å', 'B': 'Ɓ', 'C': 'Ç', 'D': 'Ð', 'E': 'É', 'F': 'Ƒ', 'G': 'Ĝ', 'H': 'Ĥ', 'I': 'Î',
+	'J': 'Ĵ', 'K': 'Ķ', 'L': 'Ļ', 'M': 'Ṁ', 'N': 'Ñ', 'O': 'Ö', 'P': 'Þ', 'Q': 'Ǫ', 'R': 'Ŕ',
+	'S': 'Š', 'T': 'Ţ', 'U': 'Û', 'V': 'Ṽ', 'W': 'Ŵ', 'X': 'Ẋ', 'Y': 'Ý', 'Z': 'Ž',
+}
+
+var pseudoFiller = []string{"one", "two", "three", "four", "five", "six", "seven", "eight", "nine", "ten"}
+
+// PseudoOption configures WrapTranslatorWithPseudoLocales
+type PseudoOption func(*pseudoConfig)
+
+type pseudoConfig struct {
+	sourceLocale string
+	expansion    float64
+	open         string
+	close        string
+}
+
+// WithPseudoSourceLocale sets the locale translated before the pseudo
+// transformation is applied (defaults to the wrapped translator's default
+// locale, then "en").
+func WithPseudoSourceLocale(locale string) PseudoOption {
+	return func(cfg *pseudoConfig) {
+		cfg.sourceLocale = locale
+	}
+}
+
+// WithPseudoExpansion sets how much longer pseudo text gets, as a ratio of
+// its letters (default 0.3). Zero disables expansion.
+func WithPseudoExpansion(ratio float64) PseudoOption {
+	return func(cfg *pseudoConfig) {
+		if ratio >= 0 {
+			cfg.expansion = ratio
+		}
+	}
+}
+
+// WithPseudoMarkers sets the strings wrapped around pseudo text (default
+// "[" and "]"), which make truncation visible. Empty markers disable them.
+func WithPseudoMarkers(open, close string) PseudoOption {
+	return func(cfg *pseudoConfig) {
+		cfg.open = open
+		cfg.close = close
+	}
+}
+
+var (
+	_ Translator         = &PseudoTranslator{}
+	_ ContextTranslator  = &PseudoTranslator{}
+	_ metadataTranslator = &PseudoTranslator{}
+
+	_ contextMetadataTranslator = &PseudoTranslator{}
+)
+
+// PseudoTranslator serves the pseudo locales en-XA and ar-XB by translating
+// the source locale and transforming the result. Other locales pass through
+// unchanged.
+type PseudoTranslator struct {
+	next Translator
+	cfg  pseudoConfig
+}
+
+// WrapTranslatorWithPseudoLocales wraps next so that en-XA returns accented,
+// expanded text and ar-XB returns bidi-mirrored text. Printf verbs,
+// {placeholders} and HTML tags in the output are preserved.
+func WrapTranslatorWithPseudoLocales(next Translator, opts ...PseudoOption) Translator {
+	if next == nil {
+		return nil
+	}
+	if pseudo, ok := next.(*PseudoTranslator); ok {
+		next = pseudo.next
+	}
+
+	cfg := pseudoConfig{expansion: 0.3, open: "[", close: "]"}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+	if cfg.sourceLocale == "" {
+		if provider, ok := next.(defaultLocaleProvider); ok {
+			cfg.sourceLocale = provider.DefaultLocale()
+		}
+	}
+	if cfg.sourceLocale == "" {
+		cfg.sourceLocale = "en"
+	}
+
+	return &PseudoTranslator{next: next, cfg: cfg}
+}
+
+// IsPseudoLocale reports whether locale is one of the pseudo locales.
+func IsPseudoLocale(locale string) bool {
+	return pseudoLocale(locale) != ""
+}
+
+func pseudoLocale(locale string) string {
+	locale = normalizeLocale(locale)
+	switch {
+	case strings.EqualFold(locale, PseudoLocaleAccented):
+		return PseudoLocaleAccented
+	case strings.EqualFold(locale, PseudoLocaleBidi):
+		return PseudoLocaleBidi
+	default:
+		return ""
+	}
+}
+
+func (t *PseudoTranslator) Translate(locale, key string, args ...any) (string, error) {
+	if t == nil || t.next == nil {
+		return "", ErrMissingTranslation
+	}
+	pseudo := pseudoLocale(locale)
+	if pseudo == "" {
+		return t.next.Translate(locale, key, args...)
+	}
+
+	result, err := t.next.Translate(t.cfg.sourceLocale, key, args...)
+	if err != nil {
+		return "", err
+	}
+	return t.transform(pseudo, result), nil
+}
+
+// TranslateWithMetadata forwards metadata from the wrapped translator when it
+// provides any.
+func (t *PseudoTranslator) TranslateWithMetadata(locale, key string, args ...any) (string, map[string]any, error) {
+	if t == nil || t.next == nil {
+		return "", nil, ErrMissingTranslation
+	}
+	mt, ok := t.next.(metadataTranslator)
+	if !ok {
+		result, err := t.Translate(locale, key, args...)
+		return result, nil, err
+	}
+
+	pseudo := pseudoLocale(locale)
+	if pseudo == "" {
+		return mt.TranslateWithMetadata(locale, key, args...)
+	}
+
+	result, metadata, err := mt.TranslateWithMetadata(t.cfg.sourceLocale, key, args...)
+	if err != nil {
+		return "", metadata, err
+	}
+	return t.transform(pseudo, result), metadata, nil
+}
+
+// TranslateContext implements ContextTranslator.
+func (t *PseudoTranslator) TranslateContext(ctx context.Context, key string, args ...any) (string, error) {
+	if t == nil || t.next == nil {
+		return "", ErrMissingTranslation
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	result, _, err := t.translateContext(ctx, contextLocale(ctx, t.next), key, contextArgs(ctx, args), false)
+	return result, err
+}
+
+// translateContext passes ctx down to the wrapped translator, asking it for
+// the source locale when locale is a pseudo locale.
+func (t *PseudoTranslator) translateContext(ctx context.Context, locale, key string, args []any, withMetadata bool) (string, map[string]any, error) {
+	if t == nil || t.next == nil {
+		return "", nil, ErrMissingTranslation
+	}
+	if err := ctx.Err(); err != nil {
+		return "", nil, err
+	}
+	pseudo := pseudoLocale(locale)
+	if pseudo == "" {
+		return translateNext(ctx, t.next, locale, key, args, withMetadata)
+	}
+
+	result, metadata, err := translateNext(ctx, t.next, t.cfg.sourceLocale, key, args, withMetadata)
+	if err != nil {
+		return "", metadata, err
+	}
+	return t.transform(pseudo, result), metadata, nil
+}
+
+func (t *PseudoTranslator) DefaultLocale() string {
+	if t == nil || t.next == nil {
+		return ""
+	}
+	if provider, ok := t.next.(defaultLocaleProvider); ok {
+		return provider.DefaultLocale()
+	}
+	return ""
+}
+
+func (t *PseudoTranslator) transform(locale, text string) string {
+	var b strings.Builder
+	b.Grow(len(text) * 2)
+	b.WriteString(t.cfg.open)
+
+	letters := 0
+	last := 0
+	for _, loc := range pseudoProtectedPattern.FindAllStringIndex(text, -1) {
+		letters += t.writeSegment(&b, locale, text[last:loc[0]])
+		b.WriteString(text[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	letters += t.writeSegment(&b, locale, text[last:])
+
+	if extra := int(math.Ceil(float64(letters) * t.cfg.expansion)); extra > 0 {
+		b.WriteByte(' ')
+		t.writeSegment(&b, locale, pseudoPadding(extra))
+	}
+
+	b.WriteString(t.cfg.close)
+	return b.String()
+}
+
+// writeSegment transforms unprotected text and returns its letter count.
+func (t *PseudoTranslator) writeSegment(b *strings.Builder, locale, text string) int {
+	letters := 0
+	if locale == PseudoLocaleBidi {
+		inWord := false
+		for _, r := range text {
+			isLetter := unicode.IsLetter(r) || unicode.IsDigit(r)
+			if isLetter {
+				letters++
+			}
+			if isLetter && !inWord {
+				b.WriteString(bidiRLM + bidiRLO)
+			} else if !isLetter && inWord {
+				b.WriteString(bidiPDF + bidiRLM)
+			}
+			inWord = isLetter
+			b.WriteRune(r)
+		}
+		if inWord {
+			b.WriteString(bidiPDF + bidiRLM)
+		}
+		return letters
+	}
+
+	for _, r := range text {
+		if unicode.IsLetter(r) {
+			letters++
+		}
+		if accented, ok := pseudoAccents[r]; ok {
+			r = accented
+		}
+		b.WriteRune(r)
+	}
+	return letters
+}
+
+// pseudoPadding returns filler words ("one two three") of n letters.
+func pseudoPadding(n int) string {
+	var b strings.Builder
+	for i := 0; n > 0; i++ {
+		word := pseudoFiller[i%len(pseudoFiller)]
+		if len(word) > n {
+			word = word[:n]
+		}
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(word)
+		n -= len(word)
+	}
+	return b.String()
+}
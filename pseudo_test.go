@@ -0,0 +1,109 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+)
+
+func newPseudoStore() Store {
+	return NewStaticStore(Translations{
+		"en": newStringCatalog("en", map[string]string{
+			"home.title":  "Welcome",
+			"home.intro":  "Hello %s, you have {count} <b>new</b> messages &amp; alerts",
+			"home.banner": "Sale",
+		}),
+		"es": newStringCatalog("es", map[string]string{"home.title": "Bienvenido"}),
+	})
+}
+
+func TestPseudoTranslatorAccented(t *testing.T) {
+	base, err := NewSimpleTranslator(newPseudoStore(), WithTranslatorDefaultLocale("en"))
+	if err != nil {
+		t.Fatalf("NewSimpleTranslator: %v", err)
+	}
+	translator := WrapTranslatorWithPseudoLocales(base)
+
+	got, err := translator.Translate("en-XA", "home.title")
+	if err != nil {
+		t.Fatalf("Translate: %v", err)
+	}
+	if got != "[Ŵéļçöɱé öñé]" {
+		t.Fatalf("en-XA home.title = %q", got)
+	}
+
+	got, err = translator.Translate("en_xa", "home.intro", "%s")
+	if err != nil {
+		t.Fatalf("Translate: %v", err)
+	}
+	for _, kept := range []string{"%s", "{count}", "<b>", "</b>", "&amp;"} {
+		if !strings.Contains(got, kept) {
+			t.Fatalf("expected %q to be preserved in %q", kept, got)
+		}
+	}
+	if strings.Contains(got, "Hello") || !strings.HasPrefix(got, "[Ĥéļļö") {
+		t.Fatalf("expected accented text, got %q", got)
+	}
+
+	if got, _ := translator.Translate("es", "home.title"); got != "Bienvenido" {
+		t.Fatalf("real locales must pass through, got %q", got)
+	}
+
+	plain := WrapTranslatorWithPseudoLocales(base, WithPseudoExpansion(0), WithPseudoMarkers("", ""))
+	if got, _ := plain.Translate("en-XA", "home.banner"); got != "Šáļé" {
+		t.Fatalf("without expansion and markers = %q", got)
+	}
+	long := WrapTranslatorWithPseudoLocales(base, WithPseudoExpansion(2), WithPseudoMarkers("⟦", "⟧"))
+	if got, _ := long.Translate("en-XA", "home.banner"); got != "⟦Šáļé öñé ţŵö ţĥ⟧" {
+		t.Fatalf("with expansion 2 = %q", got)
+	}
+}
+
+func TestPseudoTranslatorBidi(t *testing.T) {
+	base, err := NewSimpleTranslator(newPseudoStore(), WithTranslatorDefaultLocale("en"))
+	if err != nil {
+		t.Fatalf("NewSimpleTranslator: %v", err)
+	}
+	translator := WrapTranslatorWithPseudoLocales(base, WithPseudoExpansion(0), WithPseudoMarkers("", ""))
+
+	got, err := translator.Translate("ar-XB", "home.intro", "%s")
+	if err != nil {
+		t.Fatalf("Translate: %v", err)
+	}
+	word := func(w string) string { return bidiRLM + bidiRLO + w + bidiPDF + bidiRLM }
+	want := word("Hello") + " %s, " + word("you") + " " + word("have") + " {count} <b>" + word("new") + "</b> " + word("messages") + " &amp; " + word("alerts")
+	if got != want {
+		t.Fatalf("ar-XB = %q\nwant %q", got, want)
+	}
+
+	_, meta, err := translator.(*PseudoTranslator).TranslateWithMetadata("ar-XB", "home.title")
+	if err != nil || meta[metadataLocaleResolved] != "en" {
+		t.Fatalf("TranslateWithMetadata metadata = %v, %v", meta, err)
+	}
+}
+
+func TestPseudoLocalizationConfigAndHelpers(t *testing.T) {
+	cfg, err := NewConfig(WithStore(newPseudoStore()), WithDefaultLocale("en"), WithPseudoLocalization(WithPseudoExpansion(0)))
+	if err != nil {
+		t.Fatalf("NewConfig: %v", err)
+	}
+	translator, err := cfg.BuildTranslator()
+	if err != nil {
+		t.Fatalf("BuildTranslator: %v", err)
+	}
+	if got, _ := translator.Translate("en-XA", "home.title"); got != "[Ŵéļçöɱé]" {
+		t.Fatalf("config en-XA = %q", got)
+	}
+
+	base, err := NewSimpleTranslator(newPseudoStore(), WithTranslatorDefaultLocale("en"))
+	if err != nil {
+		t.Fatalf("NewSimpleTranslator: %v", err)
+	}
+	helpers := TemplateHelpers(base, HelperConfig{PseudoLocalization: true, PseudoOptions: []PseudoOption{WithPseudoExpansion(0)}})
+	translate := helpers["translate"].(func(any, string, ...any) string)
+	if got := translate("en-XA", "home.title"); got != "[Ŵéļçöɱé]" {
+		t.Fatalf("helper en-XA = %q", got)
+	}
+	if got := translate("es", "home.title"); got != "Bienvenido" {
+		t.Fatalf("helper es = %q", got)
+	}
+}
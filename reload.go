@@ -0,0 +1,380 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ReloadValidator inspects a freshly loaded snapshot before it replaces the
+// current one. Returning an error keeps the last good snapshot.
+type ReloadValidator func(Translations) error
+
+// MessageRef identifies a message within a snapshot.
+type MessageRef struct {
+	Locale string
+	Key    string
+}
+
+// ReloadEvent describes the outcome of a reload attempt. Err is set when the
+// loader or validator rejected the new snapshot, in which case no keys changed.
+type ReloadEvent struct {
+	Time    time.Time
+	Added   []MessageRef
+	Removed []MessageRef
+	Changed []MessageRef
+	Err     error
+}
+
+// Empty reports whether the reload changed no messages.
+func (e ReloadEvent) Empty() bool {
+	return len(e.Added) == 0 && len(e.Removed) == 0 && len(e.Changed) == 0
+}
+
+// ReloadableStoreOption configures ReloadableStore
+type ReloadableStoreOption func(*ReloadableStore)
+
+// WithReloadValidator registers validators that must accept a snapshot before it is swapped in.
+func WithReloadValidator(validators ...ReloadValidator) ReloadableStoreOption {
+	return func(s *ReloadableStore) {
+		for _, validator := range validators {
+			if validator != nil {
+				s.validators = append(s.validators, validator)
+			}
+		}
+	}
+}
+
+// WithWatchPaths adds files polled by Watch in addition to the loader's own paths.
+func WithWatchPaths(paths ...string) ReloadableStoreOption {
+	return func(s *ReloadableStore) {
+		s.watchPaths = append(s.watchPaths, paths...)
+	}
+}
+
+// watchPathProvider is implemented by loaders backed by files on disk.
+type watchPathProvider interface {
+	watchedPaths() []string
+}
+
+// ReloadableStore is a Store backed by a Loader whose snapshot can be swapped
+// at runtime. Readers never block: each read uses the current StaticStore.
+type ReloadableStore struct {
+	loader     Loader
+	validators []ReloadValidator
+	watchPaths []string
+
+	current atomic.Pointer[StaticStore]
+
+	reloadMu    sync.Mutex
+	fingerprint string
+	subMu       sync.Mutex
+	subscribers map[int]func(ReloadEvent)
+	nextID      int
+}
+
+var _ Store = &ReloadableStore{}
+var _ compiledRuleStore = &ReloadableStore{}
+var _ messageViewStore = &ReloadableStore{}
+
+// NewReloadableStore loads the initial snapshot from loader. It fails if the
+// initial load or validation fails.
+func NewReloadableStore(loader Loader, opts ...ReloadableStoreOption) (*ReloadableStore, error) {
+	if loader == nil {
+		return nil, errors.New("i18n: reloadable store requires a loader")
+	}
+
+	s := &ReloadableStore{
+		loader:      loader,
+		subscribers: make(map[int]func(ReloadEvent)),
+	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			opt(s)
+		}
+	}
+
+	if provider, ok := loader.(watchPathProvider); ok {
+		s.watchPaths = append(provider.watchedPaths(), s.watchPaths...)
+	}
+
+	s.fingerprint = fingerprintFiles(s.watchPaths)
+	snapshot, err := s.loadSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	s.current.Store(snapshot)
+
+	return s, nil
+}
+
+func (s *ReloadableStore) snapshot() *StaticStore {
+	if s == nil {
+		return nil
+	}
+	return s.current.Load()
+}
+
+func (s *ReloadableStore) Get(locale, key string) (string, bool) {
+	return s.snapshot().Get(locale, key)
+}
+
+func (s *ReloadableStore) Message(locale, key string) (Message, bool) {
+	return s.snapshot().Message(locale, key)
+}
+
+func (s *ReloadableStore) messageView(locale, key string) (Message, bool) {
+	return s.snapshot().messageView(locale, key)
+}
+
+func (s *ReloadableStore) Rules(locale string) (*PluralRuleSet, bool) {
+	return s.snapshot().Rules(locale)
+}
+
+func (s *ReloadableStore) OrdinalRules(locale string) (*PluralRuleSet, bool) {
+	return s.snapshot().OrdinalRules(locale)
+}
+
+func (s *ReloadableStore) compiledRules(locale string, kind pluralRuleKind) (*compiledPluralRules, bool) {
+	return s.snapshot().compiledRules(locale, kind)
+}
+
+func (s *ReloadableStore) Locales() []string {
+	return s.snapshot().Locales()
+}
+
+// Reload loads and validates a new snapshot and swaps it in. On failure the
+// current snapshot is kept and the returned event carries the error.
+func (s *ReloadableStore) Reload() (ReloadEvent, error) {
+	if s == nil {
+		return ReloadEvent{}, errors.New("i18n: nil reloadable store")
+	}
+
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+
+	event := ReloadEvent{Time: time.Now()}
+	s.fingerprint = fingerprintFiles(s.watchPaths)
+
+	next, err := s.loadSnapshot()
+	if err != nil {
+		event.Err = err
+		s.notify(event)
+		return event, err
+	}
+
+	event.Added, event.Removed, event.Changed = diffSnapshots(s.current.Load(), next)
+	s.current.Store(next)
+	s.notify(event)
+
+	return event, nil
+}
+
+func (s *ReloadableStore) loadSnapshot() (*StaticStore, error) {
+	translations, err := s.loader.Load()
+	if err != nil {
+		return nil, fmt.Errorf("i18n: reload: %w", err)
+	}
+
+	for _, validate := range s.validators {
+		if err := validate(translations); err != nil {
+			return nil, fmt.Errorf("i18n: reload validation: %w", err)
+		}
+	}
+
+	return NewStaticStore(translations), nil
+}
+
+// Subscribe registers fn for reload events and returns a function that
+// removes the subscription. Events are delivered synchronously after each
+// reload attempt.
+func (s *ReloadableStore) Subscribe(fn func(ReloadEvent)) func() {
+	if s == nil || fn == nil {
+		return func() {}
+	}
+
+	s.subMu.Lock()
+	id := s.nextID
+	s.nextID++
+	s.subscribers[id] = fn
+	s.subMu.Unlock()
+
+	return func() {
+		s.subMu.Lock()
+		delete(s.subscribers, id)
+		s.subMu.Unlock()
+	}
+}
+
+// notify delivers event in subscription order. Subscribers run outside subMu
+// so they may subscribe or unsubscribe, but must not call Reload.
+func (s *ReloadableStore) notify(event ReloadEvent) {
+	s.subMu.Lock()
+	ids := make([]int, 0, len(s.subscribers))
+	for id := range s.subscribers {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	fns := make([]func(ReloadEvent), 0, len(ids))
+	for _, id := range ids {
+		fns = append(fns, s.subscribers[id])
+	}
+	s.subMu.Unlock()
+
+	for _, fn := range fns {
+		fn(event)
+	}
+}
+
+// Watch polls the watched files every interval and reloads the store when
+// their size or modification time differs from the last load. It blocks until
+// ctx is done.
+func (s *ReloadableStore) Watch(ctx context.Context, interval time.Duration) error {
+	if s == nil {
+		return errors.New("i18n: nil reloadable store")
+	}
+	if len(s.watchPaths) == 0 {
+		return errors.New("i18n: reloadable store has no paths to watch")
+	}
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if !s.filesChanged() {
+				continue
+			}
+			// failures are reported to subscribers; the last good snapshot stays active
+			_, _ = s.Reload()
+		}
+	}
+}
+
+func (s *ReloadableStore) filesChanged() bool {
+	current := fingerprintFiles(s.watchPaths)
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+	return current != s.fingerprint
+}
+
+func fingerprintFiles(paths []string) string {
+	var b strings.Builder
+	for _, path := range paths {
+		b.WriteString(path)
+		info, err := os.Stat(path)
+		if err != nil {
+			b.WriteString("|missing;")
+			continue
+		}
+		fmt.Fprintf(&b, "|%d|%d;", info.Size(), info.ModTime().UnixNano())
+	}
+	return b.String()
+}
+
+func diffSnapshots(prev, next *StaticStore) (added, removed, changed []MessageRef) {
+	prevSums := snapshotChecksums(prev)
+	nextSums := snapshotChecksums(next)
+
+	for ref, sum := range nextSums {
+		old, ok := prevSums[ref]
+		switch {
+		case !ok:
+			added = append(added, ref)
+		case old != sum:
+			changed = append(changed, ref)
+		}
+	}
+	for ref := range prevSums {
+		if _, ok := nextSums[ref]; !ok {
+			removed = append(removed, ref)
+		}
+	}
+
+	sortMessageRefs(added)
+	sortMessageRefs(removed)
+	sortMessageRefs(changed)
+	return added, removed, changed
+}
+
+func snapshotChecksums(store *StaticStore) map[MessageRef]string {
+	sums := make(map[MessageRef]string)
+	if store == nil {
+		return sums
+	}
+	for locale, catalog := range store.translations {
+		if catalog == nil {
+			continue
+		}
+		for key, message := range catalog.Messages {
+			sums[MessageRef{Locale: locale, Key: key}] = messageChecksum(message)
+		}
+	}
+	return sums
+}
+
+// messageChecksum combines variant checksums in category order so any template
+// change is detected regardless of map iteration order. Select messages add
+// every case in name order.
+func messageChecksum(message Message) string {
+	var b strings.Builder
+	writeVariantChecksums(&b, message.Variants)
+	if message.Select != nil {
+		names := make([]string, 0, len(message.Select.Cases))
+		for name := range message.Select.Cases {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		b.WriteString(message.Select.Arg)
+		b.WriteByte(0)
+		for _, name := range names {
+			b.WriteString(name)
+			b.WriteByte(0)
+			writeVariantChecksums(&b, message.Select.Cases[name])
+		}
+	}
+	return checksum(b.String())
+}
+
+func writeVariantChecksums(b *strings.Builder, variants map[PluralCategory]MessageVariant) {
+	categories := make([]string, 0, len(variants))
+	for category := range variants {
+		categories = append(categories, string(category))
+	}
+	sort.Strings(categories)
+
+	for _, category := range categories {
+		variant := variants[PluralCategory(category)]
+		sum := variant.Checksum
+		if sum == "" {
+			sum = checksum(variant.Template)
+		}
+		b.WriteString(category)
+		b.WriteByte(0)
+		b.WriteString(sum)
+		b.WriteByte(0)
+	}
+}
+
+func sortMessageRefs(refs []MessageRef) {
+	sort.Slice(refs, func(i, j int) bool {
+		if refs[i].Locale != refs[j].Locale {
+			return refs[i].Locale < refs[j].Locale
+		}
+		return refs[i].Key < refs[j].Key
+	})
+}
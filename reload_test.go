@@ -0,0 +1,174 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeTranslationFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+func TestReloadableStoreReloadEmitsDiff(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "messages.json")
+	writeTranslationFile(t, path, `{"en": {"home.title": "Welcome", "home.subtitle": "Hi", "home.footer": "Bye"}}`)
+
+	store, err := NewReloadableStore(NewFileLoader(path))
+	if err != nil {
+		t.Fatalf("NewReloadableStore: %v", err)
+	}
+
+	translator, err := NewSimpleTranslator(store, WithTranslatorDefaultLocale("en"))
+	if err != nil {
+		t.Fatalf("NewSimpleTranslator: %v", err)
+	}
+
+	var events []ReloadEvent
+	unsubscribe := store.Subscribe(func(event ReloadEvent) {
+		events = append(events, event)
+	})
+
+	writeTranslationFile(t, path, `{"en": {"home.title": "Welcome back", "home.subtitle": "Hi", "home.cta": "Start"}, "es": {"home.title": "Hola"}}`)
+
+	event, err := store.Reload()
+	if err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+
+	wantRefs := func(label string, got []MessageRef, want ...MessageRef) {
+		t.Helper()
+		if len(got) != len(want) {
+			t.Fatalf("%s = %v want %v", label, got, want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("%s = %v want %v", label, got, want)
+			}
+		}
+	}
+	wantRefs("added", event.Added, MessageRef{"en", "home.cta"}, MessageRef{"es", "home.title"})
+	wantRefs("removed", event.Removed, MessageRef{"en", "home.footer"})
+	wantRefs("changed", event.Changed, MessageRef{"en", "home.title"})
+
+	if len(events) != 1 || events[0].Err != nil {
+		t.Fatalf("expected one successful event, got %#v", events)
+	}
+
+	if got, _ := translator.Translate("en", "home.title"); got != "Welcome back" {
+		t.Fatalf("translator did not observe reload, got %q", got)
+	}
+
+	unsubscribe()
+	if event, _ := store.Reload(); !event.Empty() {
+		t.Fatalf("expected empty event for unchanged files, got %#v", event)
+	}
+	if len(events) != 1 {
+		t.Fatalf("unsubscribed handler should not receive events")
+	}
+}
+
+func TestReloadableStoreKeepsLastGoodSnapshot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "messages.json")
+	writeTranslationFile(t, path, `{"en": {"home.title": "Welcome"}}`)
+
+	errNoSpanish := errors.New("es required")
+	validator := func(data Translations) error {
+		if len(data) > 1 && data["es"] == nil {
+			return errNoSpanish
+		}
+		return nil
+	}
+
+	store, err := NewReloadableStore(NewFileLoader(path), WithReloadValidator(validator))
+	if err != nil {
+		t.Fatalf("NewReloadableStore: %v", err)
+	}
+
+	var failures int
+	store.Subscribe(func(event ReloadEvent) {
+		if event.Err != nil {
+			failures++
+		}
+	})
+
+	writeTranslationFile(t, path, `{"en": {"home.title": `)
+	if _, err := store.Reload(); err == nil {
+		t.Fatalf("expected decode error")
+	}
+
+	writeTranslationFile(t, path, `{"en": {"home.title": "Changed"}, "fr": {"home.title": "Bonjour"}}`)
+	if _, err := store.Reload(); !errors.Is(err, errNoSpanish) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+
+	if got, _ := store.Get("en", "home.title"); got != "Welcome" {
+		t.Fatalf("expected last good snapshot, got %q", got)
+	}
+	if failures != 2 {
+		t.Fatalf("expected 2 failure events, got %d", failures)
+	}
+}
+
+func TestReloadableStoreWatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "messages.json")
+	writeTranslationFile(t, path, `{"en": {"home.title": "Welcome"}}`)
+
+	store, err := NewReloadableStore(NewFileLoader(path))
+	if err != nil {
+		t.Fatalf("NewReloadableStore: %v", err)
+	}
+
+	reloaded := make(chan ReloadEvent, 1)
+	store.Subscribe(func(event ReloadEvent) {
+		select {
+		case reloaded <- event:
+		default:
+		}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- store.Watch(ctx, 10*time.Millisecond) }()
+
+	writeTranslationFile(t, path, `{"en": {"home.title": "Welcome to the new release"}}`)
+
+	select {
+	case event := <-reloaded:
+		if len(event.Changed) != 1 {
+			t.Fatalf("unexpected event: %#v", event)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("watch did not reload")
+	}
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("Watch returned %v", err)
+	}
+
+	if got, _ := store.Get("en", "home.title"); got != "Welcome to the new release" {
+		t.Fatalf("Get() = %q", got)
+	}
+}
+
+func TestReloadableStoreRequiresLoader(t *testing.T) {
+	if _, err := NewReloadableStore(nil); err == nil {
+		t.Fatalf("expected error for nil loader")
+	}
+
+	loader := LoaderFunc(func() (Translations, error) { return nil, nil })
+	store, err := NewReloadableStore(loader)
+	if err != nil {
+		t.Fatalf("NewReloadableStore: %v", err)
+	}
+	if err := store.Watch(context.Background(), time.Millisecond); err == nil {
+		t.Fatalf("expected error when no paths are watched")
+	}
+}
@@ -16,6 +16,19 @@ type Store interface {
 	Locales() []string
 }
 
+// ordinalRuleStore is implemented by stores that also carry ordinal plural
+// rules, used to select variants for WithOrdinal.
+type ordinalRuleStore interface {
+	OrdinalRules(locale string) (*PluralRuleSet, bool)
+}
+
+// messageViewStore is implemented by stores whose messages are immutable,
+// letting the translator read them without the copy made by Message. The
+// returned message must not be modified.
+type messageViewStore interface {
+	messageView(locale, key string) (Message, bool)
+}
+
 // Loader retrieves the translations used to seed a Store
 type Loader interface {
 	Load() (Translations, error)
@@ -33,9 +46,14 @@ func (fn LoaderFunc) Load() (Translations, error) {
 type StaticStore struct {
 	translations Translations
 	locales      []string
+	// rules holds the plural rules compiled at construction, by kind.
+	rules [2]map[string]*compiledPluralRules
 }
 
 var _ Store = &StaticStore{}
+var _ ordinalRuleStore = &StaticStore{}
+var _ compiledRuleStore = &StaticStore{}
+var _ messageViewStore = &StaticStore{}
 
 // NewStaticStore builds an immutable snapthot from the given translations
 func NewStaticStore(data Translations) *StaticStore {
@@ -45,6 +63,8 @@ func NewStaticStore(data Translations) *StaticStore {
 
 	translations := make(Translations, len(data))
 	locales := make([]string, 0, len(data))
+	var rules [2]map[string]*compiledPluralRules
+	interned := make(map[string]string)
 
 	for locale, catalog := range data {
 		if catalog == nil {
@@ -60,7 +80,7 @@ func NewStaticStore(data Translations) *StaticStore {
 		if len(catalog.Messages) > 0 {
 			clone.Messages = make(map[string]Message, len(catalog.Messages))
 			for key, message := range catalog.Messages {
-				clone.Messages[key] = message.Clone()
+				clone.Messages[key] = internMessage(message.Clone(), interned)
 			}
 		}
 
@@ -74,6 +94,20 @@ func NewStaticStore(data Translations) *StaticStore {
 			}
 		}
 
+		if catalog.OrdinalRules != nil {
+			clone.OrdinalRules = catalog.OrdinalRules.Clone()
+		}
+
+		for kind, set := range [2]*PluralRuleSet{clone.CardinalRules, clone.OrdinalRules} {
+			if set == nil {
+				continue
+			}
+			if rules[kind] == nil {
+				rules[kind] = make(map[string]*compiledPluralRules)
+			}
+			rules[kind][locale] = compilePluralRules(set)
+		}
+
 		translations[locale] = clone
 		locales = append(locales, locale)
 	}
@@ -84,6 +118,7 @@ func NewStaticStore(data Translations) *StaticStore {
 	return &StaticStore{
 		translations: translations,
 		locales:      locales,
+		rules:        rules,
 	}
 }
 
@@ -123,6 +158,19 @@ func (s *StaticStore) Message(locale, key string) (Message, bool) {
 	return msg.Clone(), ok
 }
 
+// messageView returns the stored message without copying it.
+func (s *StaticStore) messageView(locale, key string) (Message, bool) {
+	if s == nil {
+		return Message{}, false
+	}
+	catalog, ok := s.translations[locale]
+	if !ok || catalog == nil {
+		return Message{}, false
+	}
+	msg, ok := catalog.Messages[key]
+	return msg, ok
+}
+
 // Get returns the the message template for locale/key
 func (s *StaticStore) Get(locale, key string) (string, bool) {
 	msg, ok := s.Message(locale, key)
@@ -143,6 +191,28 @@ func (s *StaticStore) Rules(locale string) (*PluralRuleSet, bool) {
 	return catalog.CardinalRules.Clone(), true
 }
 
+// OrdinalRules returns the ordinal plural rule set for the requested locale
+func (s *StaticStore) OrdinalRules(locale string) (*PluralRuleSet, bool) {
+	if s == nil {
+		return nil, false
+	}
+	catalog, ok := s.translations[locale]
+	if !ok || catalog == nil || catalog.OrdinalRules == nil {
+		return nil, false
+	}
+	return catalog.OrdinalRules.Clone(), true
+}
+
+// compiledRules returns the rules of kind compiled for locale at
+// construction, without cloning them.
+func (s *StaticStore) compiledRules(locale string, kind pluralRuleKind) (*compiledPluralRules, bool) {
+	if s == nil {
+		return nil, false
+	}
+	rules, ok := s.rules[kind][locale]
+	return rules, ok
+}
+
 // Locales returns a slice with all locale codes
 func (s *StaticStore) Locales() []string {
 	if s == nil || len(s.locales) == 0 {
@@ -152,3 +222,30 @@ func (s *StaticStore) Locales() []string {
 	copy(out, s.locales)
 	return out
 }
+
+// internMessage shares identical templates and argument names between the
+// messages of a store. message must be a private copy.
+func internMessage(message Message, interned map[string]string) Message {
+	sets := message.variantSets()
+	if message.Select != nil {
+		sets = append(sets, message.Variants)
+	}
+	for _, variants := range sets {
+		for category, variant := range variants {
+			variant.Template = internString(interned, variant.Template)
+			for i, arg := range variant.FormatArgs {
+				variant.FormatArgs[i] = internString(interned, arg)
+			}
+			variants[category] = variant
+		}
+	}
+	return message
+}
+
+func internString(interned map[string]string, value string) string {
+	if shared, ok := interned[value]; ok {
+		return shared
+	}
+	interned[value] = value
+	return value
+}
@@ -145,3 +145,71 @@ func TestNewStaticStoreFromLoaderNil(t *testing.T) {
 		t.Fatalf("expected no locales, got %v", locales)
 	}
 }
+
+func TestStaticStoreTranslateDoesNotAllocate(t *testing.T) {
+	translations := Translations{
+		"en": newStringCatalog("en", map[string]string{"home.title": "Welcome", "cta.buy": "Buy now"}),
+		"es": newStringCatalog("es", map[string]string{"home.title": "Bienvenido"}),
+		"pt": newStringCatalog("pt", map[string]string{"cta.buy": "Comprar"}),
+	}
+	resolver := NewStaticFallbackResolver()
+	resolver.Set("es-MX", "es")
+
+	reloadable, err := NewReloadableStore(LoaderFunc(func() (Translations, error) { return translations, nil }))
+	if err != nil {
+		t.Fatalf("NewReloadableStore: %v", err)
+	}
+	chain := NewFallbackChain().
+		Add(FallbackSourceDeclared, resolver).
+		Add(FallbackSourceParent, ParentFallbackResolver())
+
+	stores := map[string]Store{"static": NewStaticStore(translations), "reloadable": reloadable}
+	for name, store := range stores {
+		for _, fallback := range []FallbackResolver{resolver, chain} {
+			translator, err := NewSimpleTranslator(store,
+				WithTranslatorDefaultLocale("en"),
+				WithTranslatorFallbackResolver(fallback))
+			if err != nil {
+				t.Fatalf("NewSimpleTranslator: %v", err)
+			}
+
+			for _, tc := range []struct{ locale, key, want string }{
+				{"en", "home.title", "Welcome"},
+				{"es-MX", "home.title", "Bienvenido"},
+				{"es-MX", "cta.buy", "Buy now"},
+			} {
+				allocs := testing.AllocsPerRun(100, func() {
+					if got, err := translator.Translate(tc.locale, tc.key); err != nil || got != tc.want {
+						t.Fatalf("%s: Translate(%s, %s) = %q, %v", name, tc.locale, tc.key, got, err)
+					}
+				})
+				if allocs != 0 {
+					t.Fatalf("%s: Translate(%s, %s) allocates %.0f times", name, tc.locale, tc.key, allocs)
+				}
+			}
+		}
+	}
+
+	// Cached lookup chains follow resolver updates.
+	translator, err := NewSimpleTranslator(NewStaticStore(translations),
+		WithTranslatorDefaultLocale("en"),
+		WithTranslatorFallbackResolver(chain))
+	if err != nil {
+		t.Fatalf("NewSimpleTranslator: %v", err)
+	}
+	if got, _ := translator.Translate("es-MX", "cta.buy"); got != "Buy now" {
+		t.Fatalf("Translate(es-MX) = %q", got)
+	}
+	resolver.Set("es-MX", "pt")
+	if got, _ := translator.Translate("es-MX", "cta.buy"); got != "Comprar" {
+		t.Fatalf("Translate(es-MX) after Set = %q", got)
+	}
+
+	// Message still hands external callers their own copy.
+	store := NewStaticStore(translations)
+	message, _ := store.Message("en", "home.title")
+	message.Variants[PluralOther] = MessageVariant{Template: "changed"}
+	if got, _ := store.Get("en", "home.title"); got != "Welcome" {
+		t.Fatalf("store mutated through Message: %q", got)
+	}
+}
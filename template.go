@@ -20,6 +20,11 @@ type HelperConfig struct {
 	OnMissing MissingTranslationHandler
 	// TemplateHelperKey customizes the translator helper name (defaults to "translate").
 	TemplateHelperKey string
+	// PseudoLocalization serves the pseudo locales en-XA and ar-XB through the
+	// translate helpers.
+	PseudoLocalization bool
+	// PseudoOptions configures pseudo-localization when enabled.
+	PseudoOptions []PseudoOption
 }
 
 type defaultLocaleProvider interface {
@@ -70,6 +75,10 @@ func TemplateHelpers(t Translator, cfg HelperConfig) map[string]any {
 		)
 	}
 
+	if cfg.PseudoLocalization {
+		t = WrapTranslatorWithPseudoLocales(t, cfg.PseudoOptions...)
+	}
+
 	helpers := make(map[string]any)
 
 	translateKey := cfg.TemplateHelperKey
@@ -93,7 +102,7 @@ func TemplateHelpers(t Translator, cfg HelperConfig) map[string]any {
 		return msg
 	}
 
-	helpers["translate_count"] = func(localeSrc any, key string, count any, params ...any) map[string]any {
+	translatePlural := func(localeSrc any, key string, call helperCall, count any) map[string]any {
 		result := map[string]any{
 			"key":    key,
 			"locale": resolveLocale(localeSrc, cfg.LocaleKey),
@@ -104,10 +113,6 @@ func TemplateHelpers(t Translator, cfg HelperConfig) map[string]any {
 			return result
 		}
 
-		call := prepareTranslateCall(params...)
-		call.hasCount = true
-		call.count = count
-
 		text, metadata, err := executeTemplateTranslation(t, result["locale"].(string), key, call)
 		if err != nil {
 			result["text"] = handleMissing(cfg.OnMissing, result["locale"].(string), key, append(call.args, call.optionArgs()...), err)
@@ -130,6 +135,21 @@ func TemplateHelpers(t Translator, cfg HelperConfig) map[string]any {
 		return result
 	}
 
+	helpers["translate_count"] = func(localeSrc any, key string, count any, params ...any) map[string]any {
+		call := prepareTranslateCall(params...)
+		call.hasCount = true
+		call.count = count
+		return translatePlural(localeSrc, key, call, count)
+	}
+
+	helpers["translate_range"] = func(localeSrc any, key string, start, end any, params ...any) map[string]any {
+		call := prepareTranslateCall(params...)
+		call.hasRange = true
+		call.rangeStart = start
+		call.rangeEnd = end
+		return translatePlural(localeSrc, key, call, nil)
+	}
+
 	helpers["current_locale"] = func(localeSrc any) string {
 		return resolveLocale(localeSrc, cfg.LocaleKey)
 	}
@@ -163,17 +183,31 @@ func handleMissing(handler MissingTranslationHandler, locale, key string, args [
 
 type helperCall struct {
 	args     []any
+	named    map[string]any
 	hasCount bool
 	count    any
+
+	hasRange   bool
+	rangeStart any
+	rangeEnd   any
 }
 
 func (h helperCall) optionArgs() []any {
-	if !h.hasCount {
-		return nil
+	var opts []any
+	if h.hasCount {
+		opts = append(opts, WithCount(h.count))
+	}
+	if h.hasRange {
+		opts = append(opts, WithCountRange(h.rangeStart, h.rangeEnd))
+	}
+	if len(h.named) > 0 {
+		opts = append(opts, WithArgs(h.named))
 	}
-	return []any{WithCount(h.count)}
+	return opts
 }
 
+// prepareTranslateCall splits helper params into positional args, a count and
+// named args. Map params supply named args; their "count" entry drives plurals.
 func prepareTranslateCall(params ...any) helperCall {
 	call := helperCall{}
 
@@ -181,27 +215,42 @@ func prepareTranslateCall(params ...any) helperCall {
 		if count, options, ok := extractCountOption(param); ok {
 			call.hasCount = true
 			call.count = count
-			if residual := removeKnownOptions(options, "count"); residual != nil {
-				call.args = append(call.args, residual)
+			if residual, ok := removeKnownOptions(options, "count").(map[string]any); ok {
+				call.mergeNamed(residual)
 			}
 			continue
 		}
 
+		if options, ok := toStringMap(param); ok {
+			call.mergeNamed(options)
+			continue
+		}
+
 		call.args = append(call.args, param)
 	}
 
 	return call
 }
 
+func (h *helperCall) mergeNamed(values map[string]any) {
+	if len(values) == 0 {
+		return
+	}
+	if h.named == nil {
+		h.named = make(map[string]any, len(values))
+	}
+	for k, v := range values {
+		h.named[k] = v
+	}
+}
+
 func executeTemplateTranslation(t Translator, locale, key string, call helperCall) (string, map[string]any, error) {
 	if t == nil {
 		return "", nil, ErrMissingTranslation
 	}
 
-	args := make([]any, 0, len(call.args)+1)
-	if call.hasCount {
-		args = append(args, WithCount(call.count))
-	}
+	args := make([]any, 0, len(call.args)+2)
+	args = append(args, call.optionArgs()...)
 	args = append(args, call.args...)
 
 	if mt, ok := t.(metadataTranslator); ok {
@@ -303,6 +352,15 @@ func extractPluralMetadata(metadata map[string]any, count any) map[string]any {
 		if missing, ok := metadata[metadataPluralMissing]; ok {
 			plural["missing"] = missing
 		}
+		if selector, ok := metadata[metadataPluralSelector]; ok {
+			plural["selector"] = selector
+		}
+		if start, ok := metadata[metadataPluralStart]; ok {
+			plural["start"] = start
+		}
+		if end, ok := metadata[metadataPluralEnd]; ok {
+			plural["end"] = end
+		}
 	}
 
 	if _, ok := plural["count"]; !ok && count != nil {
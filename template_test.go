@@ -1,6 +1,7 @@
 package i18n
 
 import (
+	"errors"
 	"testing"
 	"time"
 )
@@ -47,7 +48,7 @@ func TestTemplateHelpersMissingTranslationHandler(t *testing.T) {
 		if locale != "en" {
 			t.Fatalf("expected locale en, got %q", locale)
 		}
-		if err != ErrMissingTranslation {
+		if !errors.Is(err, ErrMissingTranslation) {
 			t.Fatalf("unexpected error: %v", err)
 		}
 		return "missing"
@@ -242,3 +243,51 @@ func TestTemplateHelpers_MultiPartLocaleFallback(t *testing.T) {
 		t.Errorf("format_date(pt-BR) = %q; want %q (should fallback through pt to English)", got2, want2)
 	}
 }
+
+func TestTemplateHelpersNamedArgs(t *testing.T) {
+	translator, err := NewSimpleTranslator(newNamedArgsStore(), WithTranslatorDefaultLocale("en"))
+	if err != nil {
+		t.Fatalf("NewSimpleTranslator: %v", err)
+	}
+
+	helpers := TemplateHelpers(translator, HelperConfig{})
+	translate := helpers["translate"].(func(any, string, ...any) string)
+
+	got := translate("en", "inbox.summary", map[string]any{"name": "Ana", "kind": "files", "count": 4})
+	if got != "Hello Ana, you have 4 new files" {
+		t.Fatalf("translate named args = %q", got)
+	}
+
+	translateCount := helpers["translate_count"].(func(any, string, any, ...any) map[string]any)
+	result := translateCount("en", "inbox.summary", 2, map[string]any{"name": "Ana"})
+	if result["text"] != "Hello Ana, you have 2 new {kind}" {
+		t.Fatalf("translate_count text = %v", result["text"])
+	}
+	metadata, _ := result["metadata"].(map[string]any)
+	if missing, _ := metadata[metadataArgsMissing].([]string); len(missing) != 1 || missing[0] != "kind" {
+		t.Fatalf("translate_count missing args = %v", metadata[metadataArgsMissing])
+	}
+}
+
+func TestTemplateHelpersTranslateRange(t *testing.T) {
+	translator, err := NewSimpleTranslator(newRangeStore(t), WithTranslatorDefaultLocale("fr"))
+	if err != nil {
+		t.Fatalf("NewSimpleTranslator: %v", err)
+	}
+
+	helpers := TemplateHelpers(translator, HelperConfig{})
+	translateRange := helpers["translate_range"].(func(any, string, any, any, ...any) map[string]any)
+
+	result := translateRange("fr", "delivery.days", 2, 5)
+	if result["text"] != "2–5 jours" {
+		t.Fatalf("translate_range text = %v", result["text"])
+	}
+	plural, _ := result["plural"].(map[string]any)
+	if plural["category"] != PluralOther || plural["start"] != 2 || plural["end"] != 5 {
+		t.Fatalf("translate_range plural = %v", plural)
+	}
+
+	if result := translateRange("fr", "delivery.days", 0, 1); result["text"] != "0–1 jour" {
+		t.Fatalf("translate_range one = %v", result["text"])
+	}
+}
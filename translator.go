@@ -1,10 +1,14 @@
 package i18n
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"math"
+	"math/big"
 	"strconv"
 	"strings"
+	"sync"
 )
 
 // Translator resolves a string for a given locale and message key.
@@ -17,6 +21,32 @@ type Formatter interface {
 	Format(template string, args ...any) (string, error)
 }
 
+// FormatInput carries the locale aware context handed to a MessageFormatter.
+// Cardinal and Ordinal are shared with the translator and must not be
+// modified.
+type FormatInput struct {
+	Locale   string
+	Template string
+	Args     []any
+	Named    map[string]any
+	Cardinal *PluralRuleSet
+	Ordinal  *PluralRuleSet
+	// FormatNumber renders '#' and {name, number} arguments; nil keeps
+	// numbers as written.
+	FormatNumber NumberFormatFunc
+	// NumberSymbols describes the output of FormatNumber for digits a
+	// float64 cannot hold; nil writes them without grouping.
+	NumberSymbols *NumberSymbols
+}
+
+// MessageFormatter is implemented by formatters that need the resolved locale,
+// plural rules and named arguments (e.g. ICU MessageFormat). SimpleTranslator
+// prefers FormatMessage over Format when the configured formatter supports it.
+type MessageFormatter interface {
+	Formatter
+	FormatMessage(input FormatInput) (string, error)
+}
+
 // FormatterFunc adapts plain functions into Formatter
 type FormatterFunc func(string, ...any) (string, error)
 
@@ -33,12 +63,30 @@ type SimpleTranslatorOption func(*SimpleTranslator)
 
 // SimpleTranslator performs in memory lookups backed by a Store
 type SimpleTranslator struct {
-	store         Store
-	defaultLocale string
-	formatter     Formatter
-	resolver      FallbackResolver
+	store               Store
+	defaultLocale       string
+	formatter           Formatter
+	resolver            FallbackResolver
+	strictArgs          bool
+	disableBuiltinRules bool
+	lookupPolicy        LookupPolicy
+	registry            *FormatterRegistry
+
+	chainsMu sync.RWMutex
+	chains   map[string]lookupChain
+}
+
+// lookupChain is a cached lookupLocales result, valid while the resolver
+// reports the same version.
+type lookupChain struct {
+	version uint64
+	locales []string
 }
 
+// maxCachedLookupChains bounds the lookup chains cached per translator, so
+// arbitrary requested locales cannot grow the cache without limit.
+const maxCachedLookupChains = 1024
+
 type metadataTranslator interface {
 	TranslateWithMetadata(locale, key string, args ...any) (string, map[string]any, error)
 }
@@ -48,6 +96,17 @@ const (
 	metadataPluralCategory = "plural.category"
 	metadataPluralMessage  = "plural.message"
 	metadataPluralMissing  = "plural.missing"
+	metadataPluralOrdinal  = "plural.ordinal"
+	metadataPluralStart    = "plural.range.start"
+	metadataPluralEnd      = "plural.range.end"
+	metadataPluralSelector = "plural.selector"
+	metadataArgsMissing    = "args.missing"
+	metadataSelectArg      = "select.arg"
+	metadataSelectValue    = "select.value"
+	metadataSelectCase     = "select.case"
+	metadataSelectMissing  = "select.missing"
+	metadataLocaleResolved = "locale.resolved"
+	metadataLocaleFallback = "locale.fallback"
 )
 
 type translateOption interface {
@@ -75,15 +134,81 @@ func WithCount(value any) TranslateOption {
 	})
 }
 
+// WithOrdinal selects message variants by the locale's ordinal plural rules
+// (1st, 2nd, 3rd...) and exposes the value as the {ordinal} placeholder. It
+// takes precedence over WithCount for variant selection.
+func WithOrdinal(value any) TranslateOption {
+	return translateOptionFunc(func(rt *translateRuntime) {
+		rt.setOrdinal(value)
+	})
+}
+
+// WithCountRange selects message variants for a range such as "2–5 days".
+// The start and end categories are combined through the locale's plural
+// range rules, and the values are exposed as {start} and {end}. Range rules
+// come only from loaded plural rule files; without them the end category is
+// used.
+func WithCountRange(start, end any) TranslateOption {
+	return translateOptionFunc(func(rt *translateRuntime) {
+		rt.setRange(start, end)
+	})
+}
+
+// WithSelect supplies the argument a select message branches on, e.g.
+// WithSelect("gender", "female"). The value is also available as a named
+// {placeholder}; plain named args are matched the same way.
+func WithSelect(name string, value any) TranslateOption {
+	return translateOptionFunc(func(rt *translateRuntime) {
+		rt.setArg(name, value)
+	})
+}
+
+// WithArgs supplies values for named {placeholder} arguments.
+func WithArgs(values map[string]any) TranslateOption {
+	return translateOptionFunc(func(rt *translateRuntime) {
+		for name, value := range values {
+			rt.setArg(name, value)
+		}
+	})
+}
+
+// WithArg supplies a single named {placeholder} argument.
+func WithArg(name string, value any) TranslateOption {
+	return translateOptionFunc(func(rt *translateRuntime) {
+		rt.setArg(name, value)
+	})
+}
+
 type translateRuntime struct {
 	formatArgs    []any
+	namedArgs     map[string]any
 	hasCount      bool
 	countValue    pluralOperands
 	countLiteral  string
 	countOriginal any
+
+	hasOrdinal      bool
+	ordinalValue    pluralOperands
+	ordinalLiteral  string
+	ordinalOriginal any
+
+	hasRange      bool
+	rangeStart    pluralOperands
+	rangeEnd      pluralOperands
+	startOriginal any
+	endOriginal   any
 }
 
 func newTranslateRuntime(args []any) translateRuntime {
+	if len(args) == 0 {
+		return translateRuntime{}
+	}
+	return buildTranslateRuntime(args)
+}
+
+// buildTranslateRuntime is split from newTranslateRuntime because handing
+// &rt to options moves it to the heap, which calls without args avoid.
+func buildTranslateRuntime(args []any) translateRuntime {
 	rt := translateRuntime{}
 	for _, arg := range args {
 		if opt, ok := arg.(translateOption); ok {
@@ -109,13 +234,150 @@ func (rt *translateRuntime) setCount(value any) {
 	rt.countOriginal = value
 }
 
+func (rt *translateRuntime) setOrdinal(value any) {
+	op, literal, ok := toPluralOperands(value)
+	if !ok {
+		rt.hasOrdinal = false
+		rt.ordinalLiteral = ""
+		rt.ordinalOriginal = nil
+		return
+	}
+	rt.hasOrdinal = true
+	rt.ordinalValue = op
+	rt.ordinalLiteral = literal
+	rt.ordinalOriginal = value
+	rt.setArg("ordinal", value)
+}
+
+func (rt *translateRuntime) setRange(start, end any) {
+	startOp, _, okStart := toPluralOperands(start)
+	endOp, _, okEnd := toPluralOperands(end)
+	if !okStart || !okEnd {
+		rt.hasRange = false
+		rt.startOriginal = nil
+		rt.endOriginal = nil
+		return
+	}
+	rt.hasRange = true
+	rt.rangeStart = startOp
+	rt.rangeEnd = endOp
+	rt.startOriginal = start
+	rt.endOriginal = end
+	rt.setArg("start", start)
+	rt.setArg("end", end)
+}
+
+func (rt *translateRuntime) setArg(name string, value any) {
+	if name == "" {
+		return
+	}
+	if rt.namedArgs == nil {
+		rt.namedArgs = make(map[string]any)
+	}
+	rt.namedArgs[name] = value
+}
+
+// missingArgs returns the declared FormatArgs that were not supplied by name,
+// through a trailing map argument or, for numeric placeholders such as {0},
+// by position.
+func (rt *translateRuntime) missingArgs(declared []string) []string {
+	var missing []string
+	for _, name := range declared {
+		if rt.hasArg(name) {
+			continue
+		}
+		missing = append(missing, name)
+	}
+	return missing
+}
+
+func (rt *translateRuntime) hasArg(name string) bool {
+	if idx, err := strconv.Atoi(name); err == nil && idx >= 0 && idx < len(rt.formatArgs) {
+		return true
+	}
+	_, ok := rt.argValue(name)
+	return ok
+}
+
+// argValue returns a named argument supplied through options or a trailing
+// map argument.
+func (rt *translateRuntime) argValue(name string) (any, bool) {
+	if value, ok := rt.namedArgs[name]; ok {
+		return value, true
+	}
+	for _, arg := range rt.formatArgs {
+		switch values := arg.(type) {
+		case map[string]any:
+			if value, ok := values[name]; ok {
+				return value, true
+			}
+		case map[string]string:
+			if value, ok := values[name]; ok {
+				return value, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// templateArgs splits formatArgs for plain templates: trailing maps supply
+// named arguments, read the same way as argValue, and the rest go to the
+// Formatter.
+func (rt *translateRuntime) templateArgs() ([]any, map[string]any) {
+	hasMap := false
+	for _, arg := range rt.formatArgs {
+		switch arg.(type) {
+		case map[string]any, map[string]string:
+			hasMap = true
+		}
+	}
+	if !hasMap {
+		return rt.formatArgs, rt.namedArgs
+	}
+
+	var formatArgs []any
+	named := make(map[string]any, len(rt.namedArgs))
+	add := func(name string, value any) {
+		if _, ok := named[name]; !ok {
+			named[name] = value
+		}
+	}
+	for _, arg := range rt.formatArgs {
+		switch values := arg.(type) {
+		case map[string]any:
+			for name, value := range values {
+				add(name, value)
+			}
+		case map[string]string:
+			for name, value := range values {
+				add(name, value)
+			}
+		default:
+			formatArgs = append(formatArgs, arg)
+		}
+	}
+	for name, value := range rt.namedArgs {
+		named[name] = value
+	}
+	return formatArgs, named
+}
+
+// pluralOperands are the CLDR plural operands of a decimal value. The
+// integer and visible fraction digits are kept as written so modulo stays
+// exact beyond float64 and int64 precision.
 type pluralOperands struct {
 	n float64
-	i int64
+	i float64
 	v int
 	w int
-	f int64
-	t int64
+	f float64
+	t float64
+	e int
+
+	intDigits  string
+	fracDigits string
+	// fraction is n - i, used for n % m.
+	fraction float64
 }
 
 func NewSimpleTranslator(store Store, opts ...SimpleTranslatorOption) (*SimpleTranslator, error) {
@@ -143,6 +405,13 @@ func NewSimpleTranslator(store Store, opts ...SimpleTranslatorOption) (*SimpleTr
 		st.resolver = NewStaticFallbackResolver()
 	}
 
+	if st.lookupPolicy == "" {
+		st.lookupPolicy = LookupImplicit
+	}
+	if !st.lookupPolicy.valid() {
+		return nil, fmt.Errorf("i18n: unknown lookup policy %q", st.lookupPolicy)
+	}
+
 	return st, nil
 }
 
@@ -164,11 +433,66 @@ func WithTranslatorFallbackResolver(resolver FallbackResolver) SimpleTranslatorO
 	}
 }
 
+// WithTranslatorFormatterRegistry renders {count} and ICU '#' through the
+// registry "format_number" helper of the locale ("1,000" in en, "1.000" in
+// es). Without a registry counts are rendered as written.
+func WithTranslatorFormatterRegistry(registry *FormatterRegistry) SimpleTranslatorOption {
+	return func(st *SimpleTranslator) {
+		st.registry = registry
+	}
+}
+
+// WithTranslatorBuiltinPluralRules toggles the bundled CLDR cardinal and
+// ordinal rules used when the store has no rules for a locale (enabled by
+// default).
+func WithTranslatorBuiltinPluralRules(enabled bool) SimpleTranslatorOption {
+	return func(st *SimpleTranslator) {
+		st.disableBuiltinRules = !enabled
+	}
+}
+
+// WithTranslatorLookupPolicy selects which locales are searched for a key:
+// LookupImplicit (default), LookupDeclared or LookupStrict.
+func WithTranslatorLookupPolicy(policy LookupPolicy) SimpleTranslatorOption {
+	return func(st *SimpleTranslator) {
+		st.lookupPolicy = policy
+	}
+}
+
+// WithTranslatorStrictArgs makes translations fail with MissingArgsError when
+// a declared named placeholder was not supplied.
+func WithTranslatorStrictArgs(strict bool) SimpleTranslatorOption {
+	return func(st *SimpleTranslator) {
+		st.strictArgs = strict
+	}
+}
+
 func (t *SimpleTranslator) Translate(locale, key string, args ...any) (string, error) {
-	result, _, err := t.TranslateWithMetadata(locale, key, args...)
+	result, _, err := t.translate(locale, key, args, false)
 	return result, err
 }
 
+// TranslateContext implements ContextTranslator, reading the locale, count and
+// translate options from ctx. Explicit args take precedence over ctx values.
+func (t *SimpleTranslator) TranslateContext(ctx context.Context, key string, args ...any) (string, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	locale, _ := LocaleFromContext(ctx)
+	result, _, err := t.translate(locale, key, contextArgs(ctx, args), false)
+	return result, err
+}
+
+func (t *SimpleTranslator) translateContext(ctx context.Context, locale, key string, args []any, withMetadata bool) (string, map[string]any, error) {
+	if err := ctx.Err(); err != nil {
+		return "", nil, err
+	}
+	return t.translate(locale, key, args, withMetadata)
+}
+
 func (t *SimpleTranslator) DefaultLocale() string {
 	if t == nil {
 		return ""
@@ -177,6 +501,12 @@ func (t *SimpleTranslator) DefaultLocale() string {
 }
 
 func (t *SimpleTranslator) TranslateWithMetadata(locale, key string, args ...any) (string, map[string]any, error) {
+	return t.translate(locale, key, args, true)
+}
+
+// translate resolves key for locale; metadata is only built when requested,
+// so a Translate that needs no formatting does not allocate.
+func (t *SimpleTranslator) translate(locale, key string, args []any, withMetadata bool) (string, map[string]any, error) {
 	if t == nil {
 		return "", nil, ErrMissingTranslation
 	}
@@ -196,24 +526,60 @@ func (t *SimpleTranslator) TranslateWithMetadata(locale, key string, args ...any
 		return "", nil, ErrMissingTranslation
 	}
 
-	for _, candidate := range t.lookupLocales(primary) {
-		message, ok := t.store.Message(candidate, key)
+	tried := t.lookupLocales(primary)
+	for _, candidate := range tried {
+		message, ok := t.message(candidate, key)
 		if !ok {
 			continue
 		}
 
-		variant, category, missing := t.selectVariant(candidate, message, runtime)
-		text, err := t.renderVariant(variant, runtime)
+		message, selection := selectCase(message, runtime)
+		variant, category, selector, missing := t.selectVariant(candidate, message, runtime)
+		missingArgs := runtime.missingArgs(variant.FormatArgs)
+		if t.strictArgs && len(missingArgs) > 0 {
+			return "", nil, &MissingArgsError{Locale: candidate, Key: key, Args: missingArgs}
+		}
+
+		text, err := t.renderVariant(candidate, variant, runtime)
 		if err != nil {
 			return "", nil, err
 		}
+		if !withMetadata {
+			return text, nil, nil
+		}
 
 		metadata := map[string]any{
-			metadataPluralMessage: variant.Template,
+			metadataPluralMessage:  variant.Template,
+			metadataLocaleResolved: candidate,
+			metadataLocaleFallback: candidate != primary,
+		}
+		if len(missingArgs) > 0 {
+			metadata[metadataArgsMissing] = missingArgs
+		}
+		if selection.arg != "" {
+			metadata[metadataSelectArg] = selection.arg
+			metadata[metadataSelectValue] = selection.value
+			metadata[metadataSelectCase] = selection.name
+			if selection.missing {
+				metadata[metadataSelectMissing] = map[string]any{
+					"requested": selection.value,
+					"fallback":  SelectOther,
+				}
+			}
 		}
 		if runtime.hasCount {
 			metadata[metadataPluralCount] = runtime.countOriginal
+		}
+		if runtime.hasOrdinal {
+			metadata[metadataPluralOrdinal] = runtime.ordinalOriginal
+		}
+		if runtime.hasRange {
+			metadata[metadataPluralStart] = runtime.startOriginal
+			metadata[metadataPluralEnd] = runtime.endOriginal
+		}
+		if runtime.hasCount || runtime.hasOrdinal || runtime.hasRange {
 			metadata[metadataPluralCategory] = category
+			metadata[metadataPluralSelector] = selector
 			if missing {
 				metadata[metadataPluralMissing] = map[string]any{
 					"requested": category,
@@ -225,66 +591,144 @@ func (t *SimpleTranslator) TranslateWithMetadata(locale, key string, args ...any
 		return text, metadata, nil
 	}
 
-	return "", nil, ErrMissingTranslation
+	missing := &MissingTranslationError{Locale: primary, Key: key, Tried: append([]string(nil), tried...)}
+	if runtime.hasCount || runtime.hasOrdinal || runtime.hasRange {
+		_, missing.Category, _, _ = t.selectVariant(primary, Message{}, runtime)
+	}
+	return "", nil, missing
+}
+
+// message returns the stored message for locale/key, without a copy when
+// the store's messages are immutable. It must not be modified.
+func (t *SimpleTranslator) message(locale, key string) (Message, bool) {
+	if store, ok := t.store.(messageViewStore); ok {
+		return store.messageView(locale, key)
+	}
+	return t.store.Message(locale, key)
 }
 
+// lookupLocales lists the locales searched for primary under the
+// translator's lookup policy. Chains are cached while the resolver reports
+// an unchanged version; the result is shared and must not be modified.
 func (t *SimpleTranslator) lookupLocales(primary string) []string {
-	order := make([]string, 0, 4)
-	seen := make(map[string]struct{}, 4)
-
-	appendLocale := func(locale string) {
-		if locale == "" {
-			return
-		}
-
-		if _, ok := seen[locale]; ok {
-			return
+	version, cacheable := resolverVersion(t.resolver)
+	if cacheable {
+		t.chainsMu.RLock()
+		chain, ok := t.chains[primary]
+		t.chainsMu.RUnlock()
+		if ok && chain.version == version {
+			return chain.locales
 		}
-		seen[locale] = struct{}{}
-		order = append(order, locale)
 	}
 
-	appendLocale(primary)
-
-	for parent := localeParentTag(primary); parent != ""; parent = localeParentTag(parent) {
-		appendLocale(parent)
+	hops := t.ExplainFallback(primary)
+	order := make([]string, len(hops))
+	for i, hop := range hops {
+		order[i] = hop.Locale
 	}
 
-	if t.resolver != nil {
-		for _, fb := range t.resolver.Resolve(primary) {
-			appendLocale(fb)
+	if cacheable {
+		t.chainsMu.Lock()
+		if t.chains == nil {
+			t.chains = make(map[string]lookupChain)
+		}
+		if _, ok := t.chains[primary]; ok || len(t.chains) < maxCachedLookupChains {
+			t.chains[primary] = lookupChain{version: version, locales: order}
 		}
+		t.chainsMu.Unlock()
 	}
-
-	appendLocale(t.defaultLocale)
-
 	return order
 }
 
+// ExplainFallback lists the locales searched for locale, in order, and where
+// each one came from: the request itself, locale parents, the resolver chain
+// or the default locale.
+func (t *SimpleTranslator) ExplainFallback(locale string) []FallbackHop {
+	if t == nil {
+		return nil
+	}
+	if locale == "" {
+		locale = t.defaultLocale
+	}
+	return explainLookup(locale, t.defaultLocale, t.lookupPolicy, t.resolver)
+}
+
 func sprintfFormatter(template string, args ...any) (string, error) {
 	return fmt.Sprintf(template, args...), nil
 }
 
-func (t *SimpleTranslator) selectVariant(locale string, message Message, runtime translateRuntime) (MessageVariant, PluralCategory, bool) {
+// selectResult describes the case picked for a select message.
+type selectResult struct {
+	arg     string
+	value   string
+	name    string
+	missing bool
+}
+
+// selectCase narrows a select message to the variants of the case matching
+// its argument, falling back to "other" when the argument is absent or has no
+// case of its own. Other messages are returned unchanged.
+func selectCase(message Message, runtime translateRuntime) (Message, selectResult) {
+	if message.Select == nil {
+		return message, selectResult{}
+	}
+
+	result := selectResult{arg: message.Select.Arg}
+	if value, ok := runtime.argValue(result.arg); ok && value != nil {
+		result.value = fmt.Sprint(value)
+	}
+
+	variants, name, ok := message.Select.Case(result.value)
+	if !ok {
+		variants, name = message.Variants, SelectOther
+	}
+	result.name = name
+	result.missing = name != result.value
+
+	message.Variants = variants
+	return message, result
+}
+
+// selectVariant picks the variant for the runtime's count, ordinal or range.
+// Explicit-value selectors ("=0") win over the resolved CLDR category. It
+// returns the variant, the category, the variant key that matched and
+// whether the category had to fall back to "other".
+func (t *SimpleTranslator) selectVariant(locale string, message Message, runtime translateRuntime) (MessageVariant, PluralCategory, PluralCategory, bool) {
 	variant, ok := message.Variant(PluralOther)
 	if !ok {
 		variant = MessageVariant{}
 	}
 	category := PluralOther
+	selector := PluralOther
 	missing := false
 
-	if runtime.hasCount {
-		resolved := t.resolvePluralCategory(locale, message, runtime.countValue)
+	if runtime.hasCount || runtime.hasOrdinal || runtime.hasRange {
+		var resolved PluralCategory
+		switch {
+		case runtime.hasOrdinal:
+			resolved = t.resolveOrdinalCategory(locale, message, runtime.ordinalValue)
+		case runtime.hasRange:
+			resolved = t.resolveRangeCategory(locale, message, runtime.rangeStart, runtime.rangeEnd)
+		default:
+			resolved = t.resolvePluralCategory(locale, message, runtime.countValue)
+		}
 		if resolved == "" {
 			resolved = PluralOther
 		}
 
 		category = resolved
 
+		if exact, matched, ok := exactVariant(message, runtime); ok {
+			return matched, category, exact, false
+		}
+
 		hasExact := false
 		if message.Variants != nil {
 			_, hasExact = message.Variants[resolved]
 		}
+		if hasExact {
+			selector = resolved
+		}
 
 		if selected, ok := message.Variant(resolved); ok {
 			variant = selected
@@ -296,46 +740,270 @@ func (t *SimpleTranslator) selectVariant(locale string, message Message, runtime
 		}
 	}
 
-	return variant, category, missing
+	return variant, category, selector, missing
 }
 
-func (t *SimpleTranslator) renderVariant(variant MessageVariant, runtime translateRuntime) (string, error) {
+// exactVariant returns the explicit-value variant ("=0", "=1") matching the
+// ordinal or count value, if any. Ranges never match exact selectors.
+func exactVariant(message Message, runtime translateRuntime) (PluralCategory, MessageVariant, bool) {
+	var literal string
+	switch {
+	case runtime.hasOrdinal:
+		literal = runtime.ordinalLiteral
+	case runtime.hasRange:
+		return "", MessageVariant{}, false
+	case runtime.hasCount:
+		literal = runtime.countLiteral
+	}
+
+	value, ok := canonicalDecimal(literal)
+	if !ok {
+		return "", MessageVariant{}, false
+	}
+
+	// Selectors written differently ("=1", "=1.0") can name the same value;
+	// the smallest one wins so map order never decides.
+	var (
+		match   PluralCategory
+		variant MessageVariant
+	)
+	for selector, candidate := range message.Variants {
+		if match != "" && selector >= match {
+			continue
+		}
+		if expected, ok := selector.exactValue(); ok && expected == value {
+			match, variant = selector, candidate
+		}
+	}
+	return match, variant, match != ""
+}
+
+// canonicalDecimal rewrites a numeric literal as the shortest exact decimal
+// ("+1.50" and "15e-1" give "1.5", "-0" gives "0"), so explicit selectors
+// compare equal only when the numbers are equal.
+func canonicalDecimal(literal string) (string, bool) {
+	op, _, ok := buildOperandsFromLiteral(literal)
+	if !ok {
+		return "", false
+	}
+	value := op.intDigits
+	if frac := strings.TrimRight(op.fracDigits, "0"); frac != "" {
+		value += "." + frac
+	}
+	if value != "0" && strings.HasPrefix(strings.TrimSpace(literal), "-") {
+		value = "-" + value
+	}
+	return value, true
+}
+
+func (t *SimpleTranslator) renderVariant(locale string, variant MessageVariant, runtime translateRuntime) (string, error) {
+	if mf, ok := t.formatter.(MessageFormatter); ok {
+		input := FormatInput{
+			Locale:        locale,
+			Template:      variant.Template,
+			Args:          runtime.formatArgs,
+			Cardinal:      t.ruleSetFor(locale),
+			Ordinal:       t.ordinalRuleSetFor(locale),
+			FormatNumber:  t.numberFormatter(locale),
+			NumberSymbols: t.numberSymbols(locale),
+		}
+		if runtime.hasCount || len(runtime.namedArgs) > 0 {
+			input.Named = make(map[string]any, len(runtime.namedArgs)+1)
+			for name, value := range runtime.namedArgs {
+				input.Named[name] = value
+			}
+			if runtime.hasCount {
+				input.Named["count"] = runtime.countOriginal
+			}
+		}
+		return mf.FormatMessage(input)
+	}
+
 	text := variant.Template
 	if runtime.hasCount {
-		text = strings.ReplaceAll(text, "{count}", runtime.countLiteral)
+		text = t.substituteCount(locale, text, runtime)
 	}
 
-	if len(runtime.formatArgs) == 0 || t.formatter == nil {
-		return text, nil
+	formatArgs, namedArgs := runtime.templateArgs()
+	if len(formatArgs) > 0 && t.formatter != nil {
+		formatted, err := t.formatter.Format(text, formatArgs...)
+		if err != nil {
+			return "", err
+		}
+		text = formatted
 	}
 
-	return t.formatter.Format(text, runtime.formatArgs...)
+	if len(namedArgs) > 0 {
+		text = substituteNamedArgs(text, namedArgs)
+	}
+
+	return text, nil
 }
 
-func (t *SimpleTranslator) resolvePluralCategory(locale string, message Message, operands pluralOperands) PluralCategory {
-	rules := t.ruleSetFor(locale)
-	if rules != nil {
-		if category := selectPluralCategory(rules, operands); category != "" {
-			return category
-		}
+// substituteCount replaces {count} and {count, number, style} placeholders
+// with the locale formatted count.
+func (t *SimpleTranslator) substituteCount(locale, text string, runtime translateRuntime) string {
+	if !strings.Contains(text, "{count") {
+		return text
+	}
+	value, ok := literalFloat(runtime.countLiteral)
+	if !ok {
+		return strings.ReplaceAll(text, "{count}", runtime.countLiteral)
 	}
+	format, symbols := t.numberFormatter(locale), t.numberSymbols(locale)
+	return countPlaceholderPattern.ReplaceAllStringFunc(text, func(match string) string {
+		style := countPlaceholderPattern.FindStringSubmatch(match)[1]
+		return formatNumberLiteral(format, symbols, locale, value, runtime.countLiteral, parseNumberStyle(style))
+	})
+}
 
-	if message.Locale != "" && !strings.EqualFold(message.Locale, locale) {
-		if rules = t.ruleSetFor(message.Locale); rules != nil {
-			if category := selectPluralCategory(rules, operands); category != "" {
-				return category
-			}
+// numberFormatter returns the registry "format_number" helper for locale.
+func (t *SimpleTranslator) numberFormatter(locale string) NumberFormatFunc {
+	if t.registry == nil {
+		return nil
+	}
+	helper, ok := t.registry.Formatter("format_number", locale)
+	if !ok {
+		return nil
+	}
+	switch format := helper.(type) {
+	case NumberFormatFunc:
+		return format
+	case func(string, float64, int) string:
+		return format
+	}
+	return nil
+}
+
+// numberSymbols returns the registry "number_symbols" of locale, or nil when
+// the registry has none.
+func (t *SimpleTranslator) numberSymbols(locale string) *NumberSymbols {
+	if t.registry == nil {
+		return nil
+	}
+	helper, ok := t.registry.Formatter("number_symbols", locale)
+	if !ok {
+		return nil
+	}
+	fn, ok := helper.(func(string) NumberSymbols)
+	if !ok {
+		return nil
+	}
+	symbols := fn(locale)
+	return &symbols
+}
+
+func substituteNamedArgs(text string, named map[string]any) string {
+	if !strings.Contains(text, "{") {
+		return text
+	}
+	return placeholderPattern.ReplaceAllStringFunc(text, func(match string) string {
+		value, ok := named[match[1:len(match)-1]]
+		if !ok {
+			return match
 		}
+		return fmt.Sprint(value)
+	})
+}
+
+func (t *SimpleTranslator) resolvePluralCategory(locale string, message Message, operands pluralOperands) PluralCategory {
+	return t.messageRules(locale, message, cardinalRuleKind).selectCategory(&operands)
+}
+
+// resolveRangeCategory picks the category for a start–end range from the
+// categories of both ends and the locale's range rules.
+func (t *SimpleTranslator) resolveRangeCategory(locale string, message Message, start, end pluralOperands) PluralCategory {
+	rules := t.messageRules(locale, message, cardinalRuleKind)
+	if rules == nil {
+		return PluralOther
 	}
+	return rules.set.RangeCategory(rules.selectCategory(&start), rules.selectCategory(&end))
+}
 
-	return PluralOther
+func (t *SimpleTranslator) resolveOrdinalCategory(locale string, message Message, operands pluralOperands) PluralCategory {
+	return t.messageRules(locale, message, ordinalRuleKind).selectCategory(&operands)
 }
 
+// messageRules returns the rules for locale, falling back to the rules of
+// the message's own locale.
+func (t *SimpleTranslator) messageRules(locale string, message Message, kind pluralRuleKind) *compiledPluralRules {
+	if rules := t.rulesFor(locale, kind); rules != nil {
+		return rules
+	}
+	if message.Locale != "" && !strings.EqualFold(message.Locale, locale) {
+		return t.rulesFor(message.Locale, kind)
+	}
+	return nil
+}
+
+// ruleSetFor returns the cardinal rules used for locale. The set is shared
+// and must not be modified.
 func (t *SimpleTranslator) ruleSetFor(locale string) *PluralRuleSet {
+	return t.rulesFor(locale, cardinalRuleKind).ruleSet()
+}
+
+// ordinalRuleSetFor returns the ordinal rules used for locale. The set is
+// shared and must not be modified.
+func (t *SimpleTranslator) ordinalRuleSetFor(locale string) *PluralRuleSet {
+	return t.rulesFor(locale, ordinalRuleKind).ruleSet()
+}
+
+// rulesFor walks the locale's parent chain in the store, then in the
+// built-in CLDR data (unless disabled), and finally tries the default
+// locale's store rules.
+func (t *SimpleTranslator) rulesFor(locale string, kind pluralRuleKind) *compiledPluralRules {
 	if t == nil || locale == "" {
 		return nil
 	}
 
+	storeRules := func(locale string) (*compiledPluralRules, bool) {
+		return t.storeRules(locale, kind)
+	}
+	if rules := walkRuleChain(locale, storeRules); rules != nil {
+		return rules
+	}
+
+	if !t.disableBuiltinRules {
+		builtin := func(locale string) (*compiledPluralRules, bool) {
+			return builtinCompiledRules(kind, locale)
+		}
+		if rules := walkRuleChain(locale, builtin); rules != nil {
+			return rules
+		}
+	}
+
+	if t.defaultLocale != "" && !strings.EqualFold(locale, t.defaultLocale) {
+		if rules, ok := storeRules(t.defaultLocale); ok {
+			return rules
+		}
+	}
+
+	return nil
+}
+
+// storeRules returns the compiled rules of the store, compiling them per call
+// for stores that do not compile at load time.
+func (t *SimpleTranslator) storeRules(locale string, kind pluralRuleKind) (*compiledPluralRules, bool) {
+	if store, ok := t.store.(compiledRuleStore); ok {
+		return store.compiledRules(locale, kind)
+	}
+
+	var set *PluralRuleSet
+	switch kind {
+	case ordinalRuleKind:
+		if store, ok := t.store.(ordinalRuleStore); ok {
+			set, _ = store.OrdinalRules(locale)
+		}
+	default:
+		set, _ = t.store.Rules(locale)
+	}
+	if set == nil {
+		return nil, false
+	}
+	return compilePluralRules(set), true
+}
+
+func walkRuleChain(locale string, rulesFor func(string) (*compiledPluralRules, bool)) *compiledPluralRules {
 	visited := make(map[string]struct{}, 4)
 	current := locale
 	for current != "" {
@@ -343,7 +1011,7 @@ func (t *SimpleTranslator) ruleSetFor(locale string) *PluralRuleSet {
 			break
 		}
 		visited[current] = struct{}{}
-		if rules, ok := t.store.Rules(current); ok {
+		if rules, ok := rulesFor(current); ok && rules != nil {
 			return rules
 		}
 		base := localeParentTag(current)
@@ -352,13 +1020,6 @@ func (t *SimpleTranslator) ruleSetFor(locale string) *PluralRuleSet {
 		}
 		current = base
 	}
-
-	if t.defaultLocale != "" && !strings.EqualFold(locale, t.defaultLocale) {
-		if rules, ok := t.store.Rules(t.defaultLocale); ok {
-			return rules
-		}
-	}
-
 	return nil
 }
 
@@ -405,82 +1066,117 @@ func matchPluralRule(rule PluralRule, operands pluralOperands) bool {
 const floatEqualityEpsilon = 1e-9
 
 func matchPluralCondition(condition PluralCondition, operands pluralOperands) bool {
-	value, ok := operandValue(condition, operands)
+	value, integral, ok := operandValue(condition, operands)
 	if !ok {
 		return false
 	}
+	return compareOperand(&condition, value, integral)
+}
 
+func compareOperand(condition *PluralCondition, value float64, integral bool) bool {
 	switch condition.Operator {
 	case OperatorEquals:
 		if len(condition.Values) == 0 {
 			return false
 		}
-		return numbersEqual(value, condition.Values[0])
+		return operandEquals(value, integral, condition.Values[0])
 	case OperatorNotEquals:
 		if len(condition.Values) == 0 {
 			return true
 		}
-		return !numbersEqual(value, condition.Values[0])
+		return !operandEquals(value, integral, condition.Values[0])
 	case OperatorIn:
-		return membershipMatch(value, condition, false)
+		return membershipMatch(value, integral, condition, false)
 	case OperatorNotIn:
-		return !membershipMatch(value, condition, false)
+		return !membershipMatch(value, integral, condition, false)
 	case OperatorWithin:
-		return membershipMatch(value, condition, true)
+		return membershipMatch(value, integral, condition, true)
 	case OperatorNotWithin:
-		return !membershipMatch(value, condition, true)
+		return !membershipMatch(value, integral, condition, true)
 	default:
 		return false
 	}
 }
 
-func operandValue(condition PluralCondition, operands pluralOperands) (float64, bool) {
+// operandValue returns the operand named by condition, reduced modulo
+// condition.Mod, and whether it is a whole number.
+func operandValue(condition PluralCondition, operands pluralOperands) (float64, bool, bool) {
 	operand := strings.ToLower(condition.Operand)
+	if len(operand) != 1 {
+		return 0, false, false
+	}
+	return readOperand(operand[0], condition.Mod, &operands)
+}
+
+// readOperand returns operand kind (n, i, v, w, f, t, c or e) reduced modulo
+// mod and whether it is a whole number. Modulo of n, i, f and t is computed
+// from their digits.
+func readOperand(kind byte, mod int, operands *pluralOperands) (float64, bool, bool) {
 	var value float64
-	switch operand {
-	case "n":
-		value = operands.n
-	case "i":
-		value = float64(operands.i)
-	case "v":
+	var digits string
+	exact := false
+	integral := true
+	switch kind {
+	case 'n':
+		value, digits, exact = operands.n, operands.intDigits, true
+		integral = operands.w == 0
+	case 'i':
+		value, digits, exact = operands.i, operands.intDigits, true
+	case 'v':
 		value = float64(operands.v)
-	case "w":
+	case 'w':
 		value = float64(operands.w)
-	case "f":
-		value = float64(operands.f)
-	case "t":
-		value = float64(operands.t)
+	case 'f':
+		value, digits, exact = operands.f, operands.fracDigits, true
+	case 't':
+		value, digits, exact = operands.t, strings.TrimRight(operands.fracDigits, "0"), true
+	case 'c', 'e':
+		value = float64(operands.e)
 	default:
-		return 0, false
+		return 0, false, false
 	}
 
-	if condition.Mod > 0 {
-		mod := float64(condition.Mod)
-		if operand == "n" {
-			value = math.Mod(value, mod)
-		} else {
-			intValue := math.Round(value)
-			value = math.Mod(math.Abs(intValue), mod)
+	if mod > 0 {
+		if !exact {
+			return math.Mod(value, float64(mod)), true, true
+		}
+		value = float64(digitsMod(digits, mod))
+		if kind == 'n' {
+			value += operands.fraction
 		}
 	}
 
-	return value, true
+	return value, integral, true
 }
 
-func membershipMatch(value float64, condition PluralCondition, allowFraction bool) bool {
-	if len(condition.Values) > 0 {
-		for _, candidate := range condition.Values {
-			if allowFraction {
-				if numbersEqual(value, candidate) {
-					return true
-				}
-				continue
-			}
-			if isInteger(value) && isInteger(candidate) {
-				if int64(math.Round(value)) == int64(math.Round(candidate)) {
-					return true
-				}
+// digitsMod returns a decimal digit string modulo mod.
+func digitsMod(digits string, mod int) int {
+	rem := 0
+	for i := 0; i < len(digits); i++ {
+		rem = (rem*10 + int(digits[i]-'0')) % mod
+	}
+	return rem
+}
+
+// operandEquals compares an operand with a rule value; integer rule values
+// only match whole operands.
+func operandEquals(value float64, integral bool, candidate float64) bool {
+	if isInteger(candidate) {
+		return integral && value == math.Round(candidate)
+	}
+	return numbersEqual(value, candidate)
+}
+
+func membershipMatch(value float64, integral bool, condition *PluralCondition, allowFraction bool) bool {
+	for _, candidate := range condition.Values {
+		if allowFraction {
+			if numbersEqual(value, candidate) {
+				return true
 			}
+			continue
+		}
+		if isInteger(candidate) && operandEquals(value, integral, candidate) {
+			return true
 		}
 	}
 
@@ -491,13 +1187,7 @@ func membershipMatch(value float64, condition PluralCondition, allowFraction boo
 			}
 			continue
 		}
-		if !isInteger(value) {
-			continue
-		}
-		start := math.Round(r.Start)
-		end := math.Round(r.End)
-		intValue := math.Round(value)
-		if intValue >= start && intValue <= end {
+		if integral && value >= math.Round(r.Start) && value <= math.Round(r.End) {
 			return true
 		}
 	}
@@ -547,6 +1237,29 @@ func toPluralOperands(value any) (pluralOperands, string, bool) {
 		return buildOperandsFromLiteral(strconv.FormatFloat(v, 'f', -1, 64))
 	case string:
 		return buildOperandsFromLiteral(v)
+	case json.Number:
+		return buildOperandsFromLiteral(string(v))
+	case *big.Int:
+		if v == nil {
+			return pluralOperands{}, "", false
+		}
+		return buildOperandsFromLiteral(v.String())
+	case big.Int:
+		return toPluralOperands(&v)
+	case *big.Float:
+		if v == nil || v.IsInf() {
+			return pluralOperands{}, "", false
+		}
+		return buildOperandsFromLiteral(v.Text('f', -1))
+	case big.Float:
+		return toPluralOperands(&v)
+	case *big.Rat:
+		if v == nil {
+			return pluralOperands{}, "", false
+		}
+		return buildOperandsFromLiteral(ratLiteral(v))
+	case big.Rat:
+		return toPluralOperands(&v)
 	case fmt.Stringer:
 		return buildOperandsFromLiteral(v.String())
 	default:
@@ -562,19 +1275,56 @@ func convertUnsignedInt(v uint64) (pluralOperands, string, bool) {
 	return buildOperandsFromLiteral(strconv.FormatUint(v, 10))
 }
 
+// ratLiteral renders r as an exact decimal when it terminates and as the
+// nearest float64 otherwise.
+func ratLiteral(r *big.Rat) string {
+	if r.IsInt() {
+		return r.Num().String()
+	}
+
+	denom := new(big.Int).Set(r.Denom())
+	digits := 0
+	for _, factor := range []int64{2, 5} {
+		count := 0
+		divisor := big.NewInt(factor)
+		rem := new(big.Int)
+		for {
+			quo, m := new(big.Int).QuoRem(denom, divisor, rem)
+			if m.Sign() != 0 {
+				break
+			}
+			denom = quo
+			count++
+		}
+		digits = max(digits, count)
+	}
+	if denom.Cmp(big.NewInt(1)) == 0 {
+		return r.FloatString(digits)
+	}
+
+	value, _ := r.Float64()
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// maxOperandExponent bounds the exponent of "1.2e6" and "1.2c6" literals.
+const maxOperandExponent = 1000
+
+// buildOperandsFromLiteral computes plural operands from a decimal literal
+// such as "12", "-1.50" or "1.2e6". An exponent ("e" or the CLDR compact "c")
+// shifts the decimal point and, when positive, sets the c/e operands. The
+// returned literal is the value as written, expanded when it carries an
+// exponent.
 func buildOperandsFromLiteral(raw string) (pluralOperands, string, bool) {
 	trimmed := strings.TrimSpace(raw)
 	if trimmed == "" {
 		return pluralOperands{}, "", false
 	}
 
-	literal := trimmed
-	sign := 1
+	negative := false
 	if strings.HasPrefix(trimmed, "+") {
 		trimmed = trimmed[1:]
-		literal = strings.TrimSpace(trimmed)
 	} else if strings.HasPrefix(trimmed, "-") {
-		sign = -1
+		negative = true
 		trimmed = trimmed[1:]
 	}
 
@@ -582,66 +1332,73 @@ func buildOperandsFromLiteral(raw string) (pluralOperands, string, bool) {
 		return pluralOperands{}, "", false
 	}
 
-	parts := strings.SplitN(trimmed, ".", 2)
-	intPart := parts[0]
-	fracPart := ""
-	if len(parts) == 2 {
-		fracPart = parts[1]
+	mantissa := trimmed
+	exponent := 0
+	hasExponent := false
+	if idx := strings.IndexAny(trimmed, "eEcC"); idx >= 0 {
+		exp, err := strconv.Atoi(trimmed[idx+1:])
+		if err != nil || strings.Trim(trimmed[:idx], ".") == "" || exp > maxOperandExponent || exp < -maxOperandExponent {
+			return pluralOperands{}, "", false
+		}
+		mantissa, exponent, hasExponent = trimmed[:idx], exp, true
 	}
 
+	intPart, fracPart, _ := strings.Cut(mantissa, ".")
 	if intPart == "" {
 		intPart = "0"
 	}
-
-	if !digitsOnly(intPart) || (fracPart != "" && !digitsOnly(fracPart)) {
+	if !digitsOnly(intPart) || !digitsOnly(fracPart) {
 		return pluralOperands{}, "", false
 	}
 
-	intValue, err := strconv.ParseInt(intPart, 10, 64)
-	if err != nil {
-		return pluralOperands{}, "", false
+	if exponent != 0 {
+		digits := intPart + fracPart
+		point := len(intPart) + exponent
+		switch {
+		case point >= len(digits):
+			intPart, fracPart = digits+strings.Repeat("0", point-len(digits)), ""
+		case point <= 0:
+			intPart, fracPart = "0", strings.Repeat("0", -point)+digits
+		default:
+			intPart, fracPart = digits[:point], digits[point:]
+		}
 	}
 
-	floatInput := trimmed
-	if sign < 0 {
-		floatInput = "-" + trimmed
+	intPart = strings.TrimLeft(intPart, "0")
+	if intPart == "" {
+		intPart = "0"
 	}
 
-	floatValue, err := strconv.ParseFloat(floatInput, 64)
-	if err != nil {
-		return pluralOperands{}, "", false
+	op := pluralOperands{
+		intDigits:  intPart,
+		fracDigits: fracPart,
+		v:          len(fracPart),
+		w:          len(strings.TrimRight(fracPart, "0")),
+	}
+	if exponent > 0 {
+		op.e = exponent
 	}
 
-	op := pluralOperands{}
-	op.n = math.Abs(floatValue)
-	op.i = intValue
-	if sign < 0 {
-		op.i = int64(math.Abs(float64(intValue)))
+	decimal := intPart
+	if fracPart != "" {
+		decimal += "." + fracPart
 	}
-	op.v = len(fracPart)
-	if op.v > 0 {
-		fracValue, err := strconv.ParseInt(fracPart, 10, 64)
-		if err != nil {
-			return pluralOperands{}, "", false
+	op.n, _ = strconv.ParseFloat(decimal, 64)
+	op.i, _ = strconv.ParseFloat(intPart, 64)
+	if fracPart != "" {
+		op.f, _ = strconv.ParseFloat(fracPart, 64)
+		if trimmedFrac := strings.TrimRight(fracPart, "0"); trimmedFrac != "" {
+			op.t, _ = strconv.ParseFloat(trimmedFrac, 64)
 		}
-		op.f = fracValue
-		trimmedFrac := strings.TrimRight(fracPart, "0")
-		op.w = len(trimmedFrac)
-		if op.w > 0 {
-			tValue, err := strconv.ParseInt(trimmedFrac, 10, 64)
-			if err != nil {
-				return pluralOperands{}, "", false
-			}
-			op.t = tValue
-		}
-	} else {
-		op.f = 0
-		op.w = 0
-		op.t = 0
+		op.fraction, _ = strconv.ParseFloat("0."+fracPart, 64)
 	}
 
-	if sign < 0 {
-		literal = "-" + trimmed
+	literal := trimmed
+	if hasExponent {
+		literal = decimal
+	}
+	if negative {
+		literal = "-" + literal
 	}
 
 	return op, literal, true
@@ -1,6 +1,12 @@
 package i18n
 
-import "testing"
+import (
+	"errors"
+	"math/big"
+	"reflect"
+	"strconv"
+	"testing"
+)
 
 func TestSimpleTranslatorTranslate(t *testing.T) {
 	store := NewStaticStore(Translations{
@@ -62,7 +68,7 @@ func TestSimpleTranslatorTranslate(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			got, err := translator.Translate(tc.locale, tc.key, tc.args...)
 			if tc.wantErr != nil {
-				if err != tc.wantErr {
+				if !errors.Is(err, tc.wantErr) {
 					t.Fatalf("expected err %v, got %v", tc.wantErr, err)
 				}
 				return
@@ -266,7 +272,773 @@ func TestSimpleTranslatorFallbackMiss(t *testing.T) {
 	}
 
 	_, err = translator.Translate("es", "unknown")
-	if err != ErrMissingTranslation {
+	if !errors.Is(err, ErrMissingTranslation) {
 		t.Fatalf("expected ErrMissingTranslation, got %v", err)
 	}
 }
+
+func newNamedArgsStore() Store {
+	template := "Hello {name}, you have {count} new {kind}"
+	return NewStaticStore(Translations{
+		"en": {
+			Locale: Locale{Code: "en"},
+			Messages: map[string]Message{
+				"inbox.summary": {
+					MessageMetadata: MessageMetadata{ID: "inbox.summary", Locale: "en"},
+					Variants: map[PluralCategory]MessageVariant{
+						PluralOther: {Template: template, FormatArgs: extractFormatArgs(template)},
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestSimpleTranslatorNamedArgs(t *testing.T) {
+	translator, err := NewSimpleTranslator(newNamedArgsStore(), WithTranslatorDefaultLocale("en"))
+	if err != nil {
+		t.Fatalf("NewSimpleTranslator: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		args        []any
+		want        string
+		wantMissing []string
+	}{
+		{
+			name: "all args supplied",
+			args: []any{WithCount(3), WithArgs(map[string]any{"name": "Ana", "kind": "messages"})},
+			want: "Hello Ana, you have 3 new messages",
+		},
+		{
+			name: "single arg overrides map",
+			args: []any{WithCount(1), WithArgs(map[string]any{"name": "Ana", "kind": "x"}), WithArg("kind", "alerts")},
+			want: "Hello Ana, you have 1 new alerts",
+		},
+		{
+			name:        "missing args are preserved and reported",
+			args:        []any{WithArg("name", "Ana")},
+			want:        "Hello Ana, you have {count} new {kind}",
+			wantMissing: []string{"kind"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, metadata, err := translator.TranslateWithMetadata("en", "inbox.summary", tc.args...)
+			if err != nil {
+				t.Fatalf("TranslateWithMetadata: %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("Translate() = %q want %q", got, tc.want)
+			}
+
+			missing, _ := metadata[metadataArgsMissing].([]string)
+			if len(missing) != len(tc.wantMissing) {
+				t.Fatalf("missing args = %v want %v", missing, tc.wantMissing)
+			}
+			for i := range missing {
+				if missing[i] != tc.wantMissing[i] {
+					t.Fatalf("missing args = %v want %v", missing, tc.wantMissing)
+				}
+			}
+		})
+	}
+}
+
+func TestSimpleTranslatorStrictArgs(t *testing.T) {
+	translator, err := NewSimpleTranslator(newNamedArgsStore(),
+		WithTranslatorDefaultLocale("en"),
+		WithTranslatorStrictArgs(true),
+	)
+	if err != nil {
+		t.Fatalf("NewSimpleTranslator: %v", err)
+	}
+
+	_, err = translator.Translate("en", "inbox.summary", WithArg("name", "Ana"))
+	if !errors.Is(err, ErrMissingArgs) {
+		t.Fatalf("expected ErrMissingArgs, got %v", err)
+	}
+
+	var argsErr *MissingArgsError
+	if !errors.As(err, &argsErr) {
+		t.Fatalf("expected MissingArgsError, got %T", err)
+	}
+	if argsErr.Key != "inbox.summary" || len(argsErr.Args) != 1 || argsErr.Args[0] != "kind" {
+		t.Fatalf("unexpected error details: %#v", argsErr)
+	}
+
+	got, err := translator.Translate("en", "inbox.summary", WithCount(2), WithArgs(map[string]any{"name": "Ana", "kind": "tasks"}))
+	if err != nil {
+		t.Fatalf("Translate: %v", err)
+	}
+	if got != "Hello Ana, you have 2 new tasks" {
+		t.Fatalf("Translate() = %q", got)
+	}
+}
+
+func TestSimpleTranslatorTrailingMapArgs(t *testing.T) {
+	path := writeTempFile(t, t.TempDir(), "en.json", []byte(`{"en": {"hi": "Hello {name}", "mixed": "Hello %s, you are {role}"}}`))
+	store, err := NewStaticStoreFromLoader(NewFileLoader(path))
+	if err != nil {
+		t.Fatalf("NewStaticStoreFromLoader: %v", err)
+	}
+	translator, err := NewSimpleTranslator(store,
+		WithTranslatorDefaultLocale("en"),
+		WithTranslatorStrictArgs(true),
+	)
+	if err != nil {
+		t.Fatalf("NewSimpleTranslator: %v", err)
+	}
+
+	got, err := translator.Translate("en", "hi", map[string]any{"name": "Bob"})
+	if err != nil || got != "Hello Bob" {
+		t.Fatalf("Translate with map = %q, %v", got, err)
+	}
+	got, err = translator.Translate("en", "mixed", "Ana", map[string]string{"role": "admin"})
+	if err != nil || got != "Hello Ana, you are admin" {
+		t.Fatalf("Translate with positional and map args = %q, %v", got, err)
+	}
+	got, err = translator.Translate("en", "hi", map[string]any{"name": "Bob"}, WithArg("name", "Ana"))
+	if err != nil || got != "Hello Ana" {
+		t.Fatalf("options must win over map args, got %q, %v", got, err)
+	}
+	if _, err := translator.Translate("en", "hi", map[string]any{"other": "Bob"}); !errors.Is(err, ErrMissingArgs) {
+		t.Fatalf("expected ErrMissingArgs, got %v", err)
+	}
+}
+
+const ordinalRulesJSON = `{
+  "locales": {
+    "en": {
+      "cardinal": {
+        "one": [[{"operand": "i", "operator": "eq", "values": [1]}, {"operand": "v", "operator": "eq", "values": [0]}]]
+      },
+      "ordinal": {
+        "one": [[{"operand": "n", "mod": 10, "operator": "eq", "values": [1]}, {"operand": "n", "mod": 100, "operator": "neq", "values": [11]}]],
+        "two": [[{"operand": "n", "mod": 10, "operator": "eq", "values": [2]}, {"operand": "n", "mod": 100, "operator": "neq", "values": [12]}]],
+        "few": [[{"operand": "n", "mod": 10, "operator": "eq", "values": [3]}, {"operand": "n", "mod": 100, "operator": "neq", "values": [13]}]]
+      }
+    }
+  }
+}`
+
+func TestSimpleTranslatorOrdinalSelection(t *testing.T) {
+	dir := t.TempDir()
+	rulesPath := writeTempFile(t, dir, "rules.json", []byte(ordinalRulesJSON))
+	catalogPath := writeTempFile(t, dir, "en.json", []byte(`{
+  "en": {
+    "race.place": {"one": "{ordinal}st place", "two": "{ordinal}nd place", "few": "{ordinal}rd place", "other": "{ordinal}th place"}
+  }
+}`))
+
+	translations, err := NewFileLoader(catalogPath).WithPluralRuleFiles(rulesPath).Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if translations["en"].OrdinalRules == nil {
+		t.Fatal("expected ordinal rules on en catalog")
+	}
+
+	translator, err := NewSimpleTranslator(NewStaticStore(translations), WithTranslatorDefaultLocale("en"))
+	if err != nil {
+		t.Fatalf("NewSimpleTranslator: %v", err)
+	}
+
+	for ordinal, want := range map[int]string{
+		1:   "1st place",
+		2:   "2nd place",
+		3:   "3rd place",
+		4:   "4th place",
+		11:  "11th place",
+		12:  "12th place",
+		22:  "22nd place",
+		101: "101st place",
+	} {
+		got, err := translator.Translate("en", "race.place", WithOrdinal(ordinal))
+		if err != nil {
+			t.Fatalf("Translate(%d): %v", ordinal, err)
+		}
+		if got != want {
+			t.Fatalf("Translate(%d) = %q want %q", ordinal, got, want)
+		}
+	}
+
+	_, meta, err := translator.TranslateWithMetadata("en", "race.place", WithOrdinal(2), WithCount(1))
+	if err != nil {
+		t.Fatalf("TranslateWithMetadata: %v", err)
+	}
+	if meta[metadataPluralCategory] != PluralTwo || meta[metadataPluralOrdinal] != 2 {
+		t.Fatalf("unexpected metadata %v", meta)
+	}
+}
+
+func newRangeStore(t *testing.T) Store {
+	t.Helper()
+	dir := t.TempDir()
+	rulesPath := writeTempFile(t, dir, "rules.json", []byte(`{
+  "locales": {
+    "fr": {
+      "cardinal": {"one": [[{"operand": "i", "operator": "in", "values": [0, 1]}]]},
+      "ranges": [
+        {"start": "one", "end": "one", "result": "one"},
+        {"start": "one", "end": "other", "result": "other"},
+        {"start": "other", "end": "other", "result": "other"}
+      ]
+    }
+  }
+}`))
+	catalogPath := writeTempFile(t, dir, "fr.json", []byte(`{
+  "delivery.days": {"one": "{start}–{end} jour", "other": "{start}–{end} jours"}
+}`))
+
+	translations, err := NewFileLoader(catalogPath).WithSingleLocaleCatalogs().WithPluralRuleFiles(rulesPath).Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	return NewStaticStore(translations)
+}
+
+func TestSimpleTranslatorCountRange(t *testing.T) {
+	store := newRangeStore(t)
+	rules, _ := store.Rules("fr")
+	if len(rules.Ranges) != 3 {
+		t.Fatalf("expected range rules to load, got %v", rules.Ranges)
+	}
+
+	translator, err := NewSimpleTranslator(store, WithTranslatorDefaultLocale("fr"))
+	if err != nil {
+		t.Fatalf("NewSimpleTranslator: %v", err)
+	}
+
+	tests := []struct {
+		start, end any
+		want       string
+	}{
+		{start: 0, end: 1, want: "0–1 jour"},
+		{start: 1, end: 5, want: "1–5 jours"},
+		{start: 2, end: 5, want: "2–5 jours"},
+	}
+	for _, tc := range tests {
+		got, err := translator.Translate("fr", "delivery.days", WithCountRange(tc.start, tc.end))
+		if err != nil {
+			t.Fatalf("Translate(%v, %v): %v", tc.start, tc.end, err)
+		}
+		if got != tc.want {
+			t.Fatalf("Translate(%v, %v) = %q want %q", tc.start, tc.end, got, tc.want)
+		}
+	}
+
+	_, meta, err := translator.TranslateWithMetadata("fr", "delivery.days", WithCountRange(0, 1))
+	if err != nil {
+		t.Fatalf("TranslateWithMetadata: %v", err)
+	}
+	if meta[metadataPluralCategory] != PluralOne || meta[metadataPluralStart] != 0 || meta[metadataPluralEnd] != 1 {
+		t.Fatalf("unexpected metadata %v", meta)
+	}
+}
+
+func TestPluralRuleSetRangeCategory(t *testing.T) {
+	rules := &PluralRuleSet{Ranges: []PluralRangeRule{{Start: PluralFew, End: PluralOne, Result: PluralFew}}}
+
+	if got := rules.RangeCategory(PluralFew, PluralOne); got != PluralFew {
+		t.Fatalf("RangeCategory(few, one) = %s", got)
+	}
+	if got := rules.RangeCategory(PluralOne, PluralMany); got != PluralMany {
+		t.Fatalf("expected end category without a rule, got %s", got)
+	}
+	if got := (*PluralRuleSet)(nil).RangeCategory(PluralOne, ""); got != PluralOther {
+		t.Fatalf("nil rule set = %s", got)
+	}
+}
+
+func TestSimpleTranslatorBuiltinPluralRules(t *testing.T) {
+	store := NewStaticStore(Translations{
+		"ru": {
+			Locale: Locale{Code: "ru"},
+			Messages: map[string]Message{
+				"files": {
+					MessageMetadata: MessageMetadata{ID: "files", Locale: "ru"},
+					Variants: map[PluralCategory]MessageVariant{
+						PluralOne:   {Template: "{count} файл"},
+						PluralFew:   {Template: "{count} файла"},
+						PluralMany:  {Template: "{count} файлов"},
+						PluralOther: {Template: "{count} файла"},
+					},
+				},
+			},
+		},
+		"en": {
+			Locale: Locale{Code: "en"},
+			Messages: map[string]Message{
+				"place": {
+					MessageMetadata: MessageMetadata{ID: "place", Locale: "en"},
+					Variants: map[PluralCategory]MessageVariant{
+						PluralTwo:   {Template: "{ordinal}nd"},
+						PluralOther: {Template: "{ordinal}th"},
+					},
+				},
+			},
+		},
+	})
+
+	translator, err := NewSimpleTranslator(store, WithTranslatorDefaultLocale("en"))
+	if err != nil {
+		t.Fatalf("NewSimpleTranslator: %v", err)
+	}
+	for count, want := range map[int]string{1: "1 файл", 3: "3 файла", 5: "5 файлов", 21: "21 файл"} {
+		if got, _ := translator.Translate("ru", "files", WithCount(count)); got != want {
+			t.Fatalf("Translate(%d) = %q want %q", count, got, want)
+		}
+	}
+	if got, _ := translator.Translate("ru-RU", "files", WithCount(5)); got != "5 файлов" {
+		t.Fatalf("expected parent locale rules for ru-RU, got %q", got)
+	}
+	if got, _ := translator.Translate("en", "place", WithOrdinal(22)); got != "22nd" {
+		t.Fatalf("expected built-in ordinal rules, got %q", got)
+	}
+
+	disabled, err := NewSimpleTranslator(store, WithTranslatorDefaultLocale("en"), WithTranslatorBuiltinPluralRules(false))
+	if err != nil {
+		t.Fatalf("NewSimpleTranslator: %v", err)
+	}
+	if got, _ := disabled.Translate("ru", "files", WithCount(5)); got != "5 файла" {
+		t.Fatalf("expected other without built-in rules, got %q", got)
+	}
+}
+
+func TestSimpleTranslatorExactSelectorsAreDeterministic(t *testing.T) {
+	message := Message{
+		MessageMetadata: MessageMetadata{ID: "score", Locale: "en"},
+		Variants: map[PluralCategory]MessageVariant{
+			ExactPluralCategory("1.0"):  {Template: "one point zero"},
+			ExactPluralCategory("1"):    {Template: "exactly one"},
+			ExactPluralCategory("1.00"): {Template: "one point zero zero"},
+			PluralOther:                 {Template: "{count} points"},
+		},
+	}
+	store := NewStaticStore(Translations{"en": {Locale: Locale{Code: "en"}, Messages: map[string]Message{"score": message}}})
+	translator, err := NewSimpleTranslator(store, WithTranslatorDefaultLocale("en"))
+	if err != nil {
+		t.Fatalf("NewSimpleTranslator: %v", err)
+	}
+
+	for range 50 {
+		if got, _ := translator.Translate("en", "score", WithCount(1)); got != "exactly one" {
+			t.Fatalf("Translate = %q want the smallest matching selector", got)
+		}
+	}
+
+	formatter := NewICUFormatter()
+	got, err := formatter.FormatMessage(FormatInput{
+		Locale:   "en",
+		Template: "{n, plural, =1 {one} other {# others}}",
+		Named:    map[string]any{"n": "1.0000000000000001"},
+	})
+	if err != nil || got != "1.0000000000000001 others" {
+		t.Fatalf("ICU exact selector = %q, %v", got, err)
+	}
+}
+
+func TestSimpleTranslatorExactSelectors(t *testing.T) {
+	dir := t.TempDir()
+	jsonPath := writeTempFile(t, dir, "en.json", []byte(`{
+  "cart.items": {"=0": "Your cart is empty", "=1": "Just one item", "one": "{count} item", "other": "{count} items"},
+  "race.place": {"=1": "Winner", "one": "{ordinal}st", "other": "{ordinal}th"}
+}`))
+	yamlPath := writeTempFile(t, dir, "es.yaml", []byte(`cart.items:
+  "=0": Tu carrito está vacío
+  one: "{count} artículo"
+  other: "{count} artículos"
+`))
+
+	translations, err := NewFileLoader(jsonPath, yamlPath).WithSingleLocaleCatalogs().Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if _, ok := translations["es"].Messages["cart.items"].Variants[ExactPluralCategory("0")]; !ok {
+		t.Fatalf("expected =0 variant from YAML, got %v", translations["es"].Messages["cart.items"].Variants)
+	}
+
+	translator, err := NewSimpleTranslator(NewStaticStore(translations), WithTranslatorDefaultLocale("en"))
+	if err != nil {
+		t.Fatalf("NewSimpleTranslator: %v", err)
+	}
+
+	tests := []struct {
+		locale string
+		key    string
+		opt    TranslateOption
+		want   string
+	}{
+		{locale: "en", key: "cart.items", opt: WithCount(0), want: "Your cart is empty"},
+		{locale: "en", key: "cart.items", opt: WithCount(1), want: "Just one item"},
+		{locale: "en", key: "cart.items", opt: WithCount("1.0"), want: "Just one item"},
+		{locale: "en", key: "cart.items", opt: WithCount("10e-1"), want: "Just one item"},
+		{locale: "en", key: "cart.items", opt: WithCount(1.0000000001), want: "1.0000000001 items"},
+		{locale: "en", key: "cart.items", opt: WithCount("1.0000000000000001"), want: "1.0000000000000001 items"},
+		{locale: "en", key: "cart.items", opt: WithCount(-1), want: "-1 item"},
+		{locale: "en", key: "cart.items", opt: WithCount(2), want: "2 items"},
+		{locale: "es", key: "cart.items", opt: WithCount(0), want: "Tu carrito está vacío"},
+		{locale: "es", key: "cart.items", opt: WithCount(1), want: "1 artículo"},
+		{locale: "en", key: "race.place", opt: WithOrdinal(1), want: "Winner"},
+		{locale: "en", key: "race.place", opt: WithOrdinal(21), want: "21st"},
+	}
+	for _, tc := range tests {
+		got, err := translator.Translate(tc.locale, tc.key, tc.opt)
+		if err != nil {
+			t.Fatalf("Translate(%s, %s): %v", tc.locale, tc.key, err)
+		}
+		if got != tc.want {
+			t.Fatalf("Translate(%s, %s) = %q want %q", tc.locale, tc.key, got, tc.want)
+		}
+	}
+
+	_, meta, err := translator.TranslateWithMetadata("en", "cart.items", WithCount(0))
+	if err != nil {
+		t.Fatalf("TranslateWithMetadata: %v", err)
+	}
+	if meta[metadataPluralSelector] != ExactPluralCategory("0") || meta[metadataPluralCategory] != PluralOther {
+		t.Fatalf("unexpected metadata %v", meta)
+	}
+	if _, missing := meta[metadataPluralMissing]; missing {
+		t.Fatalf("exact match should not report a missing category: %v", meta)
+	}
+
+	_, meta, _ = translator.TranslateWithMetadata("en", "cart.items", WithCount(5))
+	if meta[metadataPluralSelector] != PluralOther {
+		t.Fatalf("selector = %v", meta[metadataPluralSelector])
+	}
+
+	if _, err := NewFileLoader(writeTempFile(t, dir, "fr.json", []byte(`{"fr": {"k": {"=x": "a", "other": "b"}}}`))).Load(); err == nil {
+		t.Fatal("expected error for invalid explicit selector")
+	}
+}
+
+func newSelectTranslations(t *testing.T) Translations {
+	t.Helper()
+	dir := t.TempDir()
+	jsonPath := writeTempFile(t, dir, "en.json", []byte(`{
+  "post.liked": {
+    "select": "gender",
+    "female": "She liked your post",
+    "male": "He liked your post",
+    "other": "They liked your post"
+  },
+  "photos.added": {
+    "select": "gender",
+    "female": {"one": "{name} added a photo to her album", "other": "{name} added {count} photos to her album"},
+    "other": {"=0": "{name} added no photos", "one": "{name} added a photo to their album", "other": "{name} added {count} photos to their album"}
+  }
+}`))
+	yamlPath := writeTempFile(t, dir, "fr.yaml", []byte(`post.liked:
+  select: gender
+  female: Elle a aimé votre publication
+  other: Il a aimé votre publication
+`))
+
+	translations, err := NewFileLoader(jsonPath, yamlPath).WithSingleLocaleCatalogs().Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	return translations
+}
+
+func TestSimpleTranslatorSelectVariants(t *testing.T) {
+	translations := newSelectTranslations(t)
+
+	message := translations["en"].Messages["photos.added"]
+	if message.Select == nil || message.Select.Arg != "gender" || len(message.Select.Cases) != 2 {
+		t.Fatalf("unexpected select %#v", message.Select)
+	}
+	if message.Content() != "{name} added {count} photos to their album" {
+		t.Fatalf("Content should expose the other case, got %q", message.Content())
+	}
+	if translations["fr"].Messages["post.liked"].Select == nil {
+		t.Fatal("expected select message from YAML")
+	}
+
+	translator, err := NewSimpleTranslator(NewStaticStore(translations), WithTranslatorDefaultLocale("en"))
+	if err != nil {
+		t.Fatalf("NewSimpleTranslator: %v", err)
+	}
+
+	tests := []struct {
+		locale string
+		key    string
+		args   []any
+		want   string
+	}{
+		{locale: "en", key: "post.liked", args: []any{WithSelect("gender", "female")}, want: "She liked your post"},
+		{locale: "en", key: "post.liked", args: []any{WithSelect("gender", "male")}, want: "He liked your post"},
+		{locale: "en", key: "post.liked", args: []any{WithSelect("gender", "nonbinary")}, want: "They liked your post"},
+		{locale: "en", key: "post.liked", want: "They liked your post"},
+		{locale: "en", key: "post.liked", args: []any{WithArg("gender", "male")}, want: "He liked your post"},
+		{locale: "fr", key: "post.liked", args: []any{WithSelect("gender", "female")}, want: "Elle a aimé votre publication"},
+		{locale: "fr", key: "post.liked", args: []any{WithSelect("gender", "male")}, want: "Il a aimé votre publication"},
+		{locale: "en", key: "photos.added", args: []any{WithSelect("gender", "female"), WithArg("name", "Ana"), WithCount(1)}, want: "Ana added a photo to her album"},
+		{locale: "en", key: "photos.added", args: []any{WithSelect("gender", "female"), WithArg("name", "Ana"), WithCount(3)}, want: "Ana added 3 photos to her album"},
+		{locale: "en", key: "photos.added", args: []any{WithSelect("gender", "male"), WithArg("name", "Sam"), WithCount(1)}, want: "Sam added a photo to their album"},
+		{locale: "en", key: "photos.added", args: []any{WithArg("name", "Sam"), WithCount(0)}, want: "Sam added no photos"},
+	}
+	for _, tc := range tests {
+		got, err := translator.Translate(tc.locale, tc.key, tc.args...)
+		if err != nil {
+			t.Fatalf("Translate(%s, %s): %v", tc.locale, tc.key, err)
+		}
+		if got != tc.want {
+			t.Fatalf("Translate(%s, %s, %v) = %q want %q", tc.locale, tc.key, tc.args, got, tc.want)
+		}
+	}
+
+	_, meta, err := translator.TranslateWithMetadata("en", "photos.added", WithSelect("gender", "female"), WithArg("name", "Ana"), WithCount(1))
+	if err != nil {
+		t.Fatalf("TranslateWithMetadata: %v", err)
+	}
+	if meta[metadataSelectArg] != "gender" || meta[metadataSelectValue] != "female" || meta[metadataSelectCase] != "female" {
+		t.Fatalf("unexpected select metadata %v", meta)
+	}
+	if meta[metadataPluralCategory] != PluralOne {
+		t.Fatalf("plural.category = %v", meta[metadataPluralCategory])
+	}
+	if _, missing := meta[metadataSelectMissing]; missing {
+		t.Fatalf("matched case should not report a fallback: %v", meta)
+	}
+
+	_, meta, _ = translator.TranslateWithMetadata("en", "post.liked", WithSelect("gender", "nonbinary"))
+	if meta[metadataSelectCase] != SelectOther {
+		t.Fatalf("select.case = %v", meta[metadataSelectCase])
+	}
+	missing, ok := meta[metadataSelectMissing].(map[string]any)
+	if !ok || missing["requested"] != "nonbinary" || missing["fallback"] != SelectOther {
+		t.Fatalf("unexpected select.missing %v", meta[metadataSelectMissing])
+	}
+}
+
+func TestFileLoaderRejectsInvalidSelectMessages(t *testing.T) {
+	dir := t.TempDir()
+	payloads := map[string]string{
+		"nested.json":   `{"en": {"k": {"select": "a", "x": {"select": "b", "other": "c"}, "other": "d"}}}`,
+		"bad-case.json": `{"en": {"k": {"select": "a", "x": {"few": "b", "one": "c"}, "other": "d"}}}`,
+	}
+	for name, payload := range payloads {
+		if _, err := NewFileLoader(writeTempFile(t, dir, name, []byte(payload))).Load(); err == nil {
+			t.Fatalf("%s: expected error", name)
+		}
+	}
+}
+
+func newLookupPolicyStore() Store {
+	return NewStaticStore(Translations{
+		"en":    newStringCatalog("en", map[string]string{"home.title": "Welcome", "cta.buy": "Buy now"}),
+		"es":    newStringCatalog("es", map[string]string{"home.title": "Bienvenido"}),
+		"es-MX": newStringCatalog("es-MX", map[string]string{}),
+		"pt":    newStringCatalog("pt", map[string]string{"cta.buy": "Comprar"}),
+	})
+}
+
+func TestSimpleTranslatorLookupPolicy(t *testing.T) {
+	resolver := NewStaticFallbackResolver()
+	resolver.Set("es-MX", "pt")
+
+	tests := []struct {
+		policy LookupPolicy
+		key    string
+		want   string
+		locale string
+		err    bool
+	}{
+		{policy: LookupImplicit, key: "home.title", want: "Bienvenido", locale: "es"},
+		{policy: LookupImplicit, key: "cta.buy", want: "Comprar", locale: "pt"},
+		{policy: LookupDeclared, key: "home.title", err: true},
+		{policy: LookupDeclared, key: "cta.buy", want: "Comprar", locale: "pt"},
+		{policy: LookupStrict, key: "home.title", err: true},
+		{policy: LookupStrict, key: "cta.buy", err: true},
+	}
+	for _, tc := range tests {
+		translator, err := NewSimpleTranslator(newLookupPolicyStore(),
+			WithTranslatorDefaultLocale("en"),
+			WithTranslatorFallbackResolver(resolver),
+			WithTranslatorLookupPolicy(tc.policy))
+		if err != nil {
+			t.Fatalf("NewSimpleTranslator: %v", err)
+		}
+
+		got, meta, err := translator.TranslateWithMetadata("es-MX", tc.key)
+		if tc.err {
+			if !errors.Is(err, ErrMissingTranslation) {
+				t.Fatalf("%s/%s: expected ErrMissingTranslation, got %q, %v", tc.policy, tc.key, got, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s/%s: %v", tc.policy, tc.key, err)
+		}
+		if got != tc.want || meta[metadataLocaleResolved] != tc.locale || meta[metadataLocaleFallback] != true {
+			t.Fatalf("%s/%s = %q %v", tc.policy, tc.key, got, meta)
+		}
+	}
+
+	translator, err := NewSimpleTranslator(newLookupPolicyStore(), WithTranslatorLookupPolicy(LookupStrict))
+	if err != nil {
+		t.Fatalf("NewSimpleTranslator: %v", err)
+	}
+	_, meta, err := translator.TranslateWithMetadata("en", "home.title")
+	if err != nil {
+		t.Fatalf("TranslateWithMetadata: %v", err)
+	}
+	if meta[metadataLocaleResolved] != "en" || meta[metadataLocaleFallback] != false {
+		t.Fatalf("unexpected locale metadata %v", meta)
+	}
+
+	if _, err := NewSimpleTranslator(nil, WithTranslatorLookupPolicy("loose")); err == nil {
+		t.Fatal("expected error for unknown lookup policy")
+	}
+}
+
+func TestSimpleTranslatorFormatsCount(t *testing.T) {
+	store := NewStaticStore(Translations{
+		"en": newStringCatalog("en", map[string]string{
+			"items":   "{count} items",
+			"fixed":   "{count, number, ::.00} km",
+			"whole":   "{count, number, integer} points",
+			"compact": "{count, number, compact} views",
+		}),
+		"es": newStringCatalog("es", map[string]string{
+			"items":   "{count} artículos",
+			"compact": "{count, number, compact} visitas",
+		}),
+		"de": newStringCatalog("de", map[string]string{
+			"compact": "{count, number, compact} Aufrufe",
+		}),
+	})
+
+	translator, err := NewSimpleTranslator(store,
+		WithTranslatorDefaultLocale("en"),
+		WithTranslatorFormatterRegistry(NewFormatterRegistry()))
+	if err != nil {
+		t.Fatalf("NewSimpleTranslator: %v", err)
+	}
+
+	huge, _ := new(big.Int).SetString("123456789012345678901234567", 10)
+	tests := []struct {
+		locale string
+		key    string
+		count  any
+		want   string
+	}{
+		{locale: "en", key: "items", count: 1000, want: "1,000 items"},
+		{locale: "es", key: "items", count: 1000, want: "1.000 artículos"},
+		{locale: "en", key: "items", count: "1234.50", want: "1,234.50 items"},
+		{locale: "en", key: "fixed", count: 3.5, want: "3.50 km"},
+		{locale: "en", key: "whole", count: 12.6, want: "13 points"},
+		{locale: "en", key: "compact", count: 1250, want: "1.3K views"},
+		{locale: "en", key: "compact", count: 15300, want: "15K views"},
+		{locale: "en", key: "compact", count: 2000000, want: "2M views"},
+		{locale: "en", key: "compact", count: 999999, want: "1M views"},
+		{locale: "en", key: "compact", count: 42, want: "42 views"},
+		{locale: "es", key: "compact", count: 1500, want: "1,5\u00a0mil visitas"},
+		{locale: "es", key: "compact", count: 2000000, want: "2\u00a0M visitas"},
+		{locale: "es", key: "compact", count: 25000000000, want: "25\u00a0mil\u00a0M visitas"},
+		{locale: "de", key: "compact", count: 1250, want: "1250 Aufrufe"},
+		{locale: "en", key: "items", count: "12345678901234567", want: "12,345,678,901,234,567 items"},
+		{locale: "en", key: "items", count: int64(-9007199254740993), want: "-9,007,199,254,740,993 items"},
+		{locale: "es", key: "items", count: huge, want: "123.456.789.012.345.678.901.234.567 artículos"},
+		{locale: "en", key: "fixed", count: "12345678901234567.125", want: "12,345,678,901,234,567.13 km"},
+		{locale: "en", key: "whole", count: "9007199254740993.5", want: "9,007,199,254,740,994 points"},
+		{locale: "en", key: "compact", count: "12345678901234567", want: "12,346T views"},
+	}
+	for _, tc := range tests {
+		got, err := translator.Translate(tc.locale, tc.key, WithCount(tc.count))
+		if err != nil {
+			t.Fatalf("%s/%s: %v", tc.locale, tc.key, err)
+		}
+		if got != tc.want {
+			t.Fatalf("%s/%s(%v) = %q want %q", tc.locale, tc.key, tc.count, got, tc.want)
+		}
+	}
+
+	plain, err := NewSimpleTranslator(store, WithTranslatorDefaultLocale("en"))
+	if err != nil {
+		t.Fatalf("NewSimpleTranslator: %v", err)
+	}
+	if got, _ := plain.Translate("en", "items", WithCount(1000)); got != "1000 items" {
+		t.Fatalf("without registry = %q", got)
+	}
+}
+
+func TestFormatDecimalKeepsDigits(t *testing.T) {
+	indian := &NumberSymbols{Decimal: ".", Group: ",", PrimaryGroup: 3, SecondaryGroup: 2}
+	arabic := &NumberSymbols{Decimal: "٫", Group: "٬", PrimaryGroup: 3, SecondaryGroup: 3, Zero: '٠'}
+
+	tests := []struct {
+		symbols  *NumberSymbols
+		literal  string
+		decimals int
+		want     string
+	}{
+		{literal: "12345678901234567", decimals: 0, want: "12345678901234567"},
+		{literal: "12345678901234567.995", decimals: 2, want: "12345678901234568.00"},
+		{literal: "-99999999999999999.5", decimals: 0, want: "-100000000000000000"},
+		{literal: "0.1000000000000000055511151231257827", decimals: -1, want: "0.1000000000000000055511151231257827"},
+		{symbols: indian, literal: "12345678901234567", decimals: 1, want: "12,34,56,78,90,12,34,567.0"},
+		{symbols: arabic, literal: "12345678901234567", decimals: 0, want: "١٢٬٣٤٥٬٦٧٨٬٩٠١٬٢٣٤٬٥٦٧"},
+		{symbols: indian, literal: "1.5", decimals: 0, want: "2"},
+	}
+	for _, tc := range tests {
+		value, _ := strconv.ParseFloat(tc.literal, 64)
+		if got := formatDecimal(FormatNumber, tc.symbols, "en", value, tc.literal, tc.decimals); got != tc.want {
+			t.Fatalf("formatDecimal(%s, %d) = %q want %q", tc.literal, tc.decimals, got, tc.want)
+		}
+	}
+}
+
+func TestNumberSymbolsFollowFormatNumber(t *testing.T) {
+	registry := NewFormatterRegistry()
+	for _, tc := range []struct {
+		locale   string
+		want     NumberSymbols
+		thousand string
+	}{
+		{locale: "en", want: NumberSymbols{Decimal: ".", Group: ",", PrimaryGroup: 3, SecondaryGroup: 3}, thousand: "0K"},
+		{locale: "es", want: NumberSymbols{Decimal: ",", Group: ".", PrimaryGroup: 3, SecondaryGroup: 3}, thousand: "0\u00a0mil"},
+		{locale: "de", want: NumberSymbols{Decimal: "."}},
+	} {
+		helper, ok := registry.Formatter("number_symbols", tc.locale)
+		if !ok {
+			t.Fatalf("number_symbols(%s) missing", tc.locale)
+		}
+		got := helper.(func(string) NumberSymbols)(tc.locale)
+		if thousand := got.CompactPatterns[3]; thousand != tc.thousand {
+			t.Fatalf("number_symbols(%s) compact 1000 = %q want %q", tc.locale, thousand, tc.thousand)
+		}
+		got.CompactPatterns = nil
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Fatalf("number_symbols(%s) = %+v want %+v", tc.locale, got, tc.want)
+		}
+	}
+}
+
+func TestSimpleTranslatorFormatsICUCount(t *testing.T) {
+	store := NewStaticStore(Translations{
+		"es": newStringCatalog("es", map[string]string{
+			"files": "{count, plural, one {# archivo} other {# archivos ({count, number, compact})}}",
+		}),
+	})
+
+	translator, err := NewSimpleTranslator(store,
+		WithTranslatorFormatter(NewICUFormatter()),
+		WithTranslatorFormatterRegistry(NewFormatterRegistry()))
+	if err != nil {
+		t.Fatalf("NewSimpleTranslator: %v", err)
+	}
+
+	got, err := translator.Translate("es", "files", WithCount(25000))
+	if err != nil {
+		t.Fatalf("Translate: %v", err)
+	}
+	if got != "25.000 archivos (25\u00a0mil)" {
+		t.Fatalf("Translate() = %q", got)
+	}
+}
@@ -1,9 +1,12 @@
 package i18n
 
+import "strings"
+
 type TranslationCatalog struct {
 	Locale        Locale
 	Messages      map[string]Message
 	CardinalRules *PluralRuleSet
+	OrdinalRules  *PluralRuleSet
 }
 
 type Translations map[string]*TranslationCatalog
@@ -33,11 +36,42 @@ const (
 	PluralOther PluralCategory = "other"
 )
 
+// ExactPluralCategory returns the explicit-value selector "=value" used for
+// variants that only match one number, e.g. "=0" for "No items".
+func ExactPluralCategory(value string) PluralCategory {
+	return PluralCategory("=" + value)
+}
+
+// IsExact reports whether the category is an explicit-value selector.
+func (c PluralCategory) IsExact() bool {
+	return strings.HasPrefix(string(c), "=")
+}
+
+// exactValue returns the canonical decimal an explicit-value selector
+// matches (see canonicalDecimal).
+func (c PluralCategory) exactValue() (string, bool) {
+	if !c.IsExact() {
+		return "", false
+	}
+	return canonicalDecimal(string(c[1:]))
+}
+
+// TranslationState tracks a message through the translation workflow.
+// The zero value means the state is unknown.
+type TranslationState string
+
+const (
+	StateNew        TranslationState = "new"
+	StateTranslated TranslationState = "translated"
+	StateReviewed   TranslationState = "reviewed"
+)
+
 type MessageMetadata struct {
 	ID          string
 	Domain      string
 	Locale      string
 	Description string
+	State       TranslationState
 }
 
 type MessageVariant struct {
@@ -51,6 +85,32 @@ type MessageVariant struct {
 type Message struct {
 	MessageMetadata
 	Variants map[PluralCategory]MessageVariant
+	// Select is set for messages that branch on an argument such as gender.
+	// Variants then holds the "other" case.
+	Select *MessageSelect
+}
+
+// SelectOther is the select case used when no case matches the argument.
+const SelectOther = "other"
+
+// MessageSelect holds the cases of a select message keyed by the value of
+// Arg ("female", "male", "other"). Each case carries its own plural variants.
+type MessageSelect struct {
+	Arg   string
+	Cases map[string]map[PluralCategory]MessageVariant
+}
+
+// Case returns the variants for value, falling back to the "other" case. The
+// returned name is the case that was used.
+func (s *MessageSelect) Case(value string) (map[PluralCategory]MessageVariant, string, bool) {
+	if s == nil || s.Cases == nil {
+		return nil, "", false
+	}
+	if variants, ok := s.Cases[value]; ok {
+		return variants, value, true
+	}
+	variants, ok := s.Cases[SelectOther]
+	return variants, SelectOther, ok
 }
 
 func (m Message) Variant(category PluralCategory) (MessageVariant, bool) {
@@ -86,13 +146,39 @@ func (m *Message) SetContent(content string) {
 
 func (m Message) Clone() Message {
 	out := Message{MessageMetadata: m.MessageMetadata}
-	if len(m.Variants) == 0 {
-		return out
+	out.Variants = cloneVariants(m.Variants)
+	if m.Select != nil {
+		out.Select = &MessageSelect{Arg: m.Select.Arg}
+		if m.Select.Cases != nil {
+			out.Select.Cases = make(map[string]map[PluralCategory]MessageVariant, len(m.Select.Cases))
+			for name, variants := range m.Select.Cases {
+				out.Select.Cases[name] = cloneVariants(variants)
+			}
+		}
 	}
+	return out
+}
+
+// variantSets returns the variants of every select case, or the message
+// variants for messages without a select.
+func (m Message) variantSets() []map[PluralCategory]MessageVariant {
+	if m.Select == nil || len(m.Select.Cases) == 0 {
+		return []map[PluralCategory]MessageVariant{m.Variants}
+	}
+	sets := make([]map[PluralCategory]MessageVariant, 0, len(m.Select.Cases))
+	for _, variants := range m.Select.Cases {
+		sets = append(sets, variants)
+	}
+	return sets
+}
 
-	out.Variants = make(map[PluralCategory]MessageVariant, len(m.Variants))
-	for category, variant := range m.Variants {
-		out.Variants[category] = variant.clone()
+func cloneVariants(variants map[PluralCategory]MessageVariant) map[PluralCategory]MessageVariant {
+	if len(variants) == 0 {
+		return nil
+	}
+	out := make(map[PluralCategory]MessageVariant, len(variants))
+	for category, variant := range variants {
+		out[category] = variant.clone()
 	}
 	return out
 }
@@ -129,9 +215,25 @@ type PluralCondition struct {
 	Ranges   []PluralRange
 }
 
+// PluralSamples holds the @integer and @decimal samples of a CLDR rule.
+// Entries are single values ("21") or ranges ("2~4", "0.0~1.5").
+type PluralSamples struct {
+	Integer []string
+	Decimal []string
+}
+
 type PluralRule struct {
 	Category PluralCategory
 	Groups   [][]PluralCondition
+	Samples  PluralSamples
+}
+
+// PluralRangeRule gives the category of a range whose start and end fall in
+// the given categories, mirroring CLDR pluralRanges
+type PluralRangeRule struct {
+	Start  PluralCategory
+	End    PluralCategory
+	Result PluralCategory
 }
 
 type PluralRuleSet struct {
@@ -139,6 +241,7 @@ type PluralRuleSet struct {
 	DisplayName string
 	Parent      string
 	Rules       []PluralRule
+	Ranges      []PluralRangeRule
 }
 
 func (set *PluralRuleSet) Categories() []PluralCategory {
@@ -162,6 +265,24 @@ func (set *PluralRuleSet) Categories() []PluralCategory {
 	return categories
 }
 
+// RangeCategory returns the category for a range from a start value in
+// category start to an end value in category end. Without a matching range
+// rule the end category is used, which is the CLDR default. No CLDR
+// pluralRanges data is bundled; Ranges only holds what a rule file declares.
+func (set *PluralRuleSet) RangeCategory(start, end PluralCategory) PluralCategory {
+	if set != nil {
+		for _, rule := range set.Ranges {
+			if rule.Start == start && rule.End == end {
+				return rule.Result
+			}
+		}
+	}
+	if end == "" {
+		return PluralOther
+	}
+	return end
+}
+
 // Clone returns a deep copy of the rule set
 func (set *PluralRuleSet) Clone() *PluralRuleSet {
 	if set == nil {
@@ -178,12 +299,19 @@ func (set *PluralRuleSet) Clone() *PluralRuleSet {
 			out.Rules[i] = clonePluralRule(rule)
 		}
 	}
+	if len(set.Ranges) > 0 {
+		out.Ranges = append([]PluralRangeRule(nil), set.Ranges...)
+	}
 	return out
 }
 
 func clonePluralRule(rule PluralRule) PluralRule {
+	samples := PluralSamples{
+		Integer: append([]string(nil), rule.Samples.Integer...),
+		Decimal: append([]string(nil), rule.Samples.Decimal...),
+	}
 	if len(rule.Groups) == 0 {
-		return PluralRule{Category: rule.Category}
+		return PluralRule{Category: rule.Category, Samples: samples}
 	}
 
 	groups := make([][]PluralCondition, len(rule.Groups))
@@ -202,6 +330,7 @@ func clonePluralRule(rule PluralRule) PluralRule {
 	return PluralRule{
 		Category: rule.Category,
 		Groups:   groups,
+		Samples:  samples,
 	}
 }
 
@@ -0,0 +1,404 @@
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// IssueSeverity grades a validation issue
+type IssueSeverity string
+
+const (
+	SeverityError   IssueSeverity = "error"
+	SeverityWarning IssueSeverity = "warning"
+)
+
+// IssueKind classifies validation issues
+type IssueKind string
+
+const (
+	IssueMissingKey            IssueKind = "missing_key"
+	IssueOrphanKey             IssueKind = "orphan_key"
+	IssueMissingPluralCategory IssueKind = "missing_plural_category"
+	IssueUnusedPluralCategory  IssueKind = "unused_plural_category"
+	IssueFormatArgsMismatch    IssueKind = "format_args_mismatch"
+	IssuePrintfMismatch        IssueKind = "printf_mismatch"
+	IssueCountMismatch         IssueKind = "count_mismatch"
+)
+
+var defaultIssueSeverities = map[IssueKind]IssueSeverity{
+	IssueMissingKey:            SeverityError,
+	IssueOrphanKey:             SeverityWarning,
+	IssueMissingPluralCategory: SeverityError,
+	IssueUnusedPluralCategory:  SeverityWarning,
+	IssueFormatArgsMismatch:    SeverityError,
+	IssuePrintfMismatch:        SeverityError,
+	IssueCountMismatch:         SeverityWarning,
+}
+
+// ValidationIssue describes a single catalog problem
+type ValidationIssue struct {
+	Kind     IssueKind      `json:"kind"`
+	Severity IssueSeverity  `json:"severity"`
+	Locale   string         `json:"locale"`
+	Key      string         `json:"key,omitempty"`
+	Category PluralCategory `json:"category,omitempty"`
+	Message  string         `json:"message"`
+}
+
+// ValidationReport aggregates the issues found by Validate
+type ValidationReport struct {
+	DefaultLocale string            `json:"default_locale"`
+	Locales       []string          `json:"locales"`
+	Issues        []ValidationIssue `json:"issues"`
+}
+
+// Count returns the number of issues with the given severity
+func (r ValidationReport) Count(severity IssueSeverity) int {
+	count := 0
+	for _, issue := range r.Issues {
+		if issue.Severity == severity {
+			count++
+		}
+	}
+	return count
+}
+
+// HasErrors reports whether any issue has error severity
+func (r ValidationReport) HasErrors() bool {
+	return r.Count(SeverityError) > 0
+}
+
+// ValidateOption configures Validate
+type ValidateOption func(*validateConfig)
+
+type validateConfig struct {
+	defaultLocale string
+	locales       []string
+	severities    map[IssueKind]IssueSeverity
+}
+
+// WithValidationDefaultLocale sets the reference locale other locales are
+// compared against. Defaults to "en" when present, otherwise the first locale.
+func WithValidationDefaultLocale(locale string) ValidateOption {
+	return func(cfg *validateConfig) {
+		cfg.defaultLocale = locale
+	}
+}
+
+// WithValidationLocales restricts validation to the given locales.
+func WithValidationLocales(locales ...string) ValidateOption {
+	return func(cfg *validateConfig) {
+		cfg.locales = append(cfg.locales, locales...)
+	}
+}
+
+// WithIssueSeverity overrides the severity reported for kind.
+func WithIssueSeverity(kind IssueKind, severity IssueSeverity) ValidateOption {
+	return func(cfg *validateConfig) {
+		cfg.severities[kind] = severity
+	}
+}
+
+// Validate checks catalogs for missing and orphan keys, plural categories that
+// do not match the locale's rules, and placeholder inconsistencies between
+// each locale and the default locale.
+func Validate(translations Translations, opts ...ValidateOption) ValidationReport {
+	cfg := validateConfig{severities: make(map[IssueKind]IssueSeverity, len(defaultIssueSeverities))}
+	for kind, severity := range defaultIssueSeverities {
+		cfg.severities[kind] = severity
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+
+	locales := cfg.locales
+	if len(locales) == 0 {
+		for locale, catalog := range translations {
+			if catalog != nil {
+				locales = append(locales, locale)
+			}
+		}
+	}
+	sort.Strings(locales)
+
+	report := ValidationReport{Locales: locales, DefaultLocale: cfg.defaultLocale, Issues: []ValidationIssue{}}
+	if report.DefaultLocale == "" {
+		if _, ok := translations["en"]; ok {
+			report.DefaultLocale = "en"
+		} else if len(locales) > 0 {
+			report.DefaultLocale = locales[0]
+		}
+	}
+
+	v := validator{cfg: cfg, report: &report}
+	reference := translations[report.DefaultLocale]
+
+	for _, locale := range locales {
+		catalog := translations[locale]
+		if catalog == nil {
+			continue
+		}
+
+		v.checkPlurals(locale, catalog)
+
+		if locale == report.DefaultLocale || reference == nil {
+			continue
+		}
+
+		v.compare(locale, reference, catalog)
+	}
+
+	sort.SliceStable(report.Issues, func(i, j int) bool {
+		a, b := report.Issues[i], report.Issues[j]
+		if a.Locale != b.Locale {
+			return a.Locale < b.Locale
+		}
+		if a.Key != b.Key {
+			return a.Key < b.Key
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Category < b.Category
+	})
+
+	return report
+}
+
+type validator struct {
+	cfg    validateConfig
+	report *ValidationReport
+}
+
+func (v validator) add(kind IssueKind, locale, key string, category PluralCategory, format string, args ...any) {
+	v.report.Issues = append(v.report.Issues, ValidationIssue{
+		Kind:     kind,
+		Severity: v.cfg.severities[kind],
+		Locale:   locale,
+		Key:      key,
+		Category: category,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// checkPlurals compares plural messages against the locale's cardinal rules.
+// Messages with a single variant are treated as non plural and explicit-value
+// selectors ("=0") are not checked.
+func (v validator) checkPlurals(locale string, catalog *TranslationCatalog) {
+	categories := catalog.CardinalRules.Categories()
+	if len(categories) == 0 {
+		return
+	}
+
+	required := make(map[PluralCategory]struct{}, len(categories))
+	for _, category := range categories {
+		required[category] = struct{}{}
+	}
+
+	for _, key := range sortedMessageKeys(catalog) {
+		message := catalog.Messages[key]
+		if len(message.Variants) < 2 {
+			continue
+		}
+
+		for _, category := range categories {
+			if _, ok := message.Variants[category]; !ok {
+				v.add(IssueMissingPluralCategory, locale, key, category, "plural category %q required by %s rules is missing", category, locale)
+			}
+		}
+
+		for _, category := range sortedVariantCategories(message) {
+			if category.IsExact() {
+				continue
+			}
+			if _, ok := required[category]; !ok {
+				v.add(IssueUnusedPluralCategory, locale, key, category, "plural category %q is not used by %s rules", category, locale)
+			}
+		}
+	}
+}
+
+func (v validator) compare(locale string, reference, catalog *TranslationCatalog) {
+	for _, key := range sortedMessageKeys(reference) {
+		refMessage := reference.Messages[key]
+		message, ok := catalog.Messages[key]
+		if !ok {
+			v.add(IssueMissingKey, locale, key, "", "key is missing (present in %s)", v.report.DefaultLocale)
+			continue
+		}
+
+		refArgs, args := messageFormatArgs(refMessage), messageFormatArgs(message)
+		if strings.Join(refArgs, ",") != strings.Join(args, ",") {
+			v.add(IssueFormatArgsMismatch, locale, key, "", "named args {%s} differ from %s {%s}", strings.Join(args, ", "), v.report.DefaultLocale, strings.Join(refArgs, ", "))
+		}
+
+		if reason := comparePrintfVerbs(refMessage.Content(), message.Content()); reason != "" {
+			v.add(IssuePrintfMismatch, locale, key, "", "%s", reason)
+		}
+
+		refCount, count := messageUsesCount(refMessage), messageUsesCount(message)
+		if refCount != count {
+			if refCount {
+				v.add(IssueCountMismatch, locale, key, "", "{count} is used in %s but not in %s", v.report.DefaultLocale, locale)
+			} else {
+				v.add(IssueCountMismatch, locale, key, "", "{count} is used in %s but not in %s", locale, v.report.DefaultLocale)
+			}
+		}
+	}
+
+	for _, key := range sortedMessageKeys(catalog) {
+		if _, ok := reference.Messages[key]; !ok {
+			v.add(IssueOrphanKey, locale, key, "", "key is not defined in %s", v.report.DefaultLocale)
+		}
+	}
+}
+
+func sortedMessageKeys(catalog *TranslationCatalog) []string {
+	if catalog == nil {
+		return nil
+	}
+	keys := make([]string, 0, len(catalog.Messages))
+	for key := range catalog.Messages {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedVariantCategories(message Message) []PluralCategory {
+	categories := make([]PluralCategory, 0, len(message.Variants))
+	for category := range message.Variants {
+		categories = append(categories, category)
+	}
+	sort.Slice(categories, func(i, j int) bool { return categories[i] < categories[j] })
+	return categories
+}
+
+// messageFormatArgs returns the sorted union of named args across variants
+// and select cases, recomputed from the template when FormatArgs was not
+// populated.
+func messageFormatArgs(message Message) []string {
+	seen := make(map[string]struct{})
+	for _, variants := range message.variantSets() {
+		for _, variant := range variants {
+			args := variant.FormatArgs
+			if args == nil {
+				args = extractFormatArgs(variant.Template)
+			}
+			for _, arg := range args {
+				seen[arg] = struct{}{}
+			}
+		}
+	}
+	out := make([]string, 0, len(seen))
+	for arg := range seen {
+		out = append(out, arg)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func messageUsesCount(message Message) bool {
+	for _, variants := range message.variantSets() {
+		for _, variant := range variants {
+			if variant.UsesCount || usesCountPlaceholder(variant.Template) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// comparePrintfVerbs reports a mismatch between the printf verbs of two
+// templates, honouring explicit argument indexes such as %[2]s. %v is treated
+// as compatible with any verb.
+func comparePrintfVerbs(reference, candidate string) string {
+	refVerbs := printfVerbs(reference)
+	verbs := printfVerbs(candidate)
+
+	if len(refVerbs) != len(verbs) {
+		return fmt.Sprintf("uses %d printf arguments, expected %d", len(verbs), len(refVerbs))
+	}
+
+	indexes := make([]int, 0, len(refVerbs))
+	for idx := range refVerbs {
+		indexes = append(indexes, idx)
+	}
+	sort.Ints(indexes)
+
+	for _, idx := range indexes {
+		want := refVerbs[idx]
+		got, ok := verbs[idx]
+		if !ok {
+			return fmt.Sprintf("argument %d is not used, expected %%%c", idx, want)
+		}
+		if want == got || want == 'v' || got == 'v' {
+			continue
+		}
+		return fmt.Sprintf("argument %d uses %%%c, expected %%%c", idx, got, want)
+	}
+
+	return ""
+}
+
+// printfVerbs maps 1-based argument indexes to the verb consuming them.
+func printfVerbs(template string) map[int]rune {
+	verbs := make(map[int]rune)
+	next := 1
+
+	for i := 0; i < len(template); i++ {
+		if template[i] != '%' {
+			continue
+		}
+		i++
+		if i >= len(template) {
+			break
+		}
+		if template[i] == '%' {
+			continue
+		}
+
+		for i < len(template) && strings.IndexByte("+-# 0", template[i]) >= 0 {
+			i++
+		}
+
+		for i < len(template) {
+			c := template[i]
+			switch {
+			case c == '[':
+				end := strings.IndexByte(template[i:], ']')
+				if end < 0 {
+					return verbs
+				}
+				if n, err := strconv.Atoi(template[i+1 : i+end]); err == nil {
+					next = n
+				}
+				i += end + 1
+				continue
+			case c == '*':
+				// width or precision consumes an argument
+				verbs[next] = '*'
+				next++
+				i++
+				continue
+			case c == '.' || (c >= '0' && c <= '9'):
+				i++
+				continue
+			}
+			break
+		}
+
+		if i >= len(template) {
+			break
+		}
+		verbs[next] = rune(template[i])
+		next++
+	}
+
+	return verbs
+}
@@ -0,0 +1,140 @@
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestValidateReportsCatalogIssues(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "messages.json")
+	content := `{
+  "en": {
+    "home.title": "Welcome",
+    "home.greeting": "Hello %s, you have %d messages",
+    "inbox.summary": "Hi {name}",
+    "cart.items": {"one": "{count} item", "other": "{count} items"},
+    "only.en": "English only"
+  },
+  "ru": {
+    "home.title": "Добро пожаловать",
+    "home.greeting": "Привет %s, у вас %s сообщений",
+    "inbox.summary": "Привет {user}",
+    "cart.items": {"=0": "Пусто", "one": "{count} товар", "few": "товара", "other": "{count} товаров", "two": "два"},
+    "only.ru": "Только ru"
+  }
+}`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write catalog: %v", err)
+	}
+
+	translations, err := NewFileLoader(path).
+		WithPluralRuleFiles(filepath.Join("testdata", "cldr_cardinal.json")).
+		Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	report := Validate(translations)
+	if report.DefaultLocale != "en" {
+		t.Fatalf("DefaultLocale = %q", report.DefaultLocale)
+	}
+
+	want := map[IssueKind][]string{
+		IssueMissingKey:            {"only.en"},
+		IssueOrphanKey:             {"only.ru"},
+		IssueMissingPluralCategory: {"cart.items:many"},
+		IssueUnusedPluralCategory:  {"cart.items:two"},
+		IssueFormatArgsMismatch:    {"inbox.summary"},
+		IssuePrintfMismatch:        {"home.greeting"},
+	}
+
+	got := make(map[IssueKind][]string)
+	for _, issue := range report.Issues {
+		if issue.Locale != "ru" {
+			t.Fatalf("unexpected issue for %s: %#v", issue.Locale, issue)
+		}
+		ref := issue.Key
+		if issue.Category != "" {
+			ref += ":" + string(issue.Category)
+		}
+		got[issue.Kind] = append(got[issue.Kind], ref)
+	}
+
+	for kind, refs := range want {
+		if len(got[kind]) != len(refs) {
+			t.Fatalf("%s issues = %v want %v (report %#v)", kind, got[kind], refs, report.Issues)
+		}
+		for i := range refs {
+			if got[kind][i] != refs[i] {
+				t.Fatalf("%s issues = %v want %v", kind, got[kind], refs)
+			}
+		}
+	}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected issue kinds: %v", got)
+	}
+
+	if !report.HasErrors() || report.Count(SeverityWarning) != 2 {
+		t.Fatalf("unexpected severity counts: errors=%d warnings=%d", report.Count(SeverityError), report.Count(SeverityWarning))
+	}
+}
+
+func TestValidateCountMismatchAndSeverityOverride(t *testing.T) {
+	translations := Translations{
+		"en": newStringCatalog("en", map[string]string{"files.count": "{count} files"}),
+		"es": newStringCatalog("es", map[string]string{"files.count": "Archivos"}),
+	}
+
+	report := Validate(translations, WithIssueSeverity(IssueCountMismatch, SeverityError))
+	if len(report.Issues) != 1 {
+		t.Fatalf("expected one issue, got %#v", report.Issues)
+	}
+	issue := report.Issues[0]
+	if issue.Kind != IssueCountMismatch || issue.Severity != SeverityError || issue.Locale != "es" {
+		t.Fatalf("unexpected issue: %#v", issue)
+	}
+}
+
+func TestValidateICUSelectMessages(t *testing.T) {
+	translations := Translations{
+		"en": newStringCatalog("en", map[string]string{
+			"feed.liked":  "{gender, select, female {She} male {He} other {They}} liked {post}",
+			"feed.shared": "{gender, select, female {She} other {They}} shared {post}",
+		}),
+		"es": newStringCatalog("es", map[string]string{
+			"feed.liked":  "A {gender, select, female {ella} male {él} other {ellos}} le gustó {post}",
+			"feed.shared": "{gender, select, female {Ella} other {Ellos}} compartió {item}",
+		}),
+	}
+
+	report := Validate(translations)
+	if len(report.Issues) != 1 {
+		t.Fatalf("expected one issue, got %#v", report.Issues)
+	}
+	if issue := report.Issues[0]; issue.Kind != IssueFormatArgsMismatch || issue.Key != "feed.shared" || issue.Locale != "es" {
+		t.Fatalf("unexpected issue: %#v", issue)
+	}
+}
+
+func TestComparePrintfVerbs(t *testing.T) {
+	tests := []struct {
+		reference string
+		candidate string
+		mismatch  bool
+	}{
+		{reference: "%s has %d", candidate: "%[2]d para %[1]s", mismatch: false},
+		{reference: "%s has %d", candidate: "%v tiene %d", mismatch: false},
+		{reference: "%s has %d", candidate: "%s tiene", mismatch: true},
+		{reference: "%.2f%%", candidate: "%.1f %%", mismatch: false},
+		{reference: "%5.2f", candidate: "%d", mismatch: true},
+	}
+
+	for _, tc := range tests {
+		reason := comparePrintfVerbs(tc.reference, tc.candidate)
+		if (reason != "") != tc.mismatch {
+			t.Fatalf("comparePrintfVerbs(%q, %q) = %q, mismatch=%v", tc.reference, tc.candidate, reason, tc.mismatch)
+		}
+	}
+}
@@ -0,0 +1,563 @@
+package i18n
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// XLIFFVersion selects the XLIFF dialect used by WriteXLIFF.
+type XLIFFVersion string
+
+const (
+	XLIFF12 XLIFFVersion = "1.2"
+	XLIFF20 XLIFFVersion = "2.0"
+)
+
+const (
+	xliff12Namespace = "urn:oasis:names:tc:xliff:document:1.2"
+	xliff20Namespace = "urn:oasis:names:tc:xliff:document:2.0"
+
+	// plural groups are tagged so loaders can rebuild Message variants
+	xliff12PluralRestype = "x-gettext-plurals"
+	xliff20PluralType    = "i18n:plural"
+
+	xliffNoteDescription = "description"
+	xliffNoteChecksum    = "checksum"
+
+	// xliffPluralSeparator joins a message key and plural category in unit ids.
+	xliffPluralSeparator = ":"
+)
+
+type xliff12Document struct {
+	XMLName xml.Name      `xml:"xliff"`
+	Xmlns   string        `xml:"xmlns,attr,omitempty"`
+	Version string        `xml:"version,attr"`
+	Files   []xliff12File `xml:"file"`
+}
+
+type xliff12File struct {
+	Original       string      `xml:"original,attr"`
+	SourceLanguage string      `xml:"source-language,attr"`
+	TargetLanguage string      `xml:"target-language,attr,omitempty"`
+	Datatype       string      `xml:"datatype,attr"`
+	Body           xliff12Body `xml:"body"`
+}
+
+type xliff12Body struct {
+	Units  []xliff12Unit  `xml:"trans-unit"`
+	Groups []xliff12Group `xml:"group"`
+}
+
+type xliff12Group struct {
+	ID      string         `xml:"id,attr"`
+	Resname string         `xml:"resname,attr,omitempty"`
+	Restype string         `xml:"restype,attr,omitempty"`
+	Notes   []xliff12Note  `xml:"note"`
+	Units   []xliff12Unit  `xml:"trans-unit"`
+	Groups  []xliff12Group `xml:"group"`
+}
+
+type xliff12Unit struct {
+	ID      string         `xml:"id,attr"`
+	Resname string         `xml:"resname,attr,omitempty"`
+	Source  string         `xml:"source"`
+	Target  *xliff12Target `xml:"target"`
+	Notes   []xliff12Note  `xml:"note"`
+}
+
+type xliff12Target struct {
+	State string `xml:"state,attr,omitempty"`
+	Text  string `xml:",chardata"`
+}
+
+type xliff12Note struct {
+	From string `xml:"from,attr,omitempty"`
+	Text string `xml:",chardata"`
+}
+
+type xliff20Document struct {
+	XMLName xml.Name      `xml:"xliff"`
+	Xmlns   string        `xml:"xmlns,attr,omitempty"`
+	Version string        `xml:"version,attr"`
+	SrcLang string        `xml:"srcLang,attr"`
+	TrgLang string        `xml:"trgLang,attr,omitempty"`
+	Files   []xliff20File `xml:"file"`
+}
+
+type xliff20File struct {
+	ID       string         `xml:"id,attr"`
+	Original string         `xml:"original,attr,omitempty"`
+	Units    []xliff20Unit  `xml:"unit"`
+	Groups   []xliff20Group `xml:"group"`
+}
+
+type xliff20Group struct {
+	ID     string         `xml:"id,attr"`
+	Name   string         `xml:"name,attr,omitempty"`
+	Type   string         `xml:"type,attr,omitempty"`
+	Notes  *xliff20Notes  `xml:"notes"`
+	Units  []xliff20Unit  `xml:"unit"`
+	Groups []xliff20Group `xml:"group"`
+}
+
+type xliff20Unit struct {
+	ID       string           `xml:"id,attr"`
+	Name     string           `xml:"name,attr,omitempty"`
+	Notes    *xliff20Notes    `xml:"notes"`
+	Segments []xliff20Segment `xml:"segment"`
+}
+
+type xliff20Notes struct {
+	Notes []xliff20Note `xml:"note"`
+}
+
+type xliff20Note struct {
+	Category string `xml:"category,attr,omitempty"`
+	Text     string `xml:",chardata"`
+}
+
+type xliff20Segment struct {
+	State  string  `xml:"state,attr,omitempty"`
+	Source string  `xml:"source"`
+	Target *string `xml:"target"`
+}
+
+// xliffUnit is the version-neutral form of a translation unit.
+type xliffUnit struct {
+	key         string
+	category    PluralCategory
+	source      string
+	target      string
+	hasTarget   bool
+	state       TranslationState
+	description string
+	checksum    string
+}
+
+// xliffDocument is the version-neutral form of an XLIFF file.
+type xliffDocument struct {
+	sourceLocale string
+	targetLocale string
+	units        []xliffUnit
+}
+
+// XLIFFLoader reads XLIFF 1.2 and 2.0 files exchanged with CAT tools. Target
+// text populates the target-language catalog, plural groups written by
+// WriteXLIFF are rebuilt into Message variants, notes become
+// MessageMetadata.Description and unit states map onto TranslationState.
+// Units without a target are skipped.
+type XLIFFLoader struct {
+	paths     []string
+	rulePaths []string
+	source    *TranslationCatalog
+}
+
+var _ Loader = &XLIFFLoader{}
+
+func NewXLIFFLoader(paths ...string) *XLIFFLoader {
+	return &XLIFFLoader{paths: append([]string(nil), paths...)}
+}
+
+func (l *XLIFFLoader) WithPluralRuleFiles(paths ...string) *XLIFFLoader {
+	if l == nil || len(paths) == 0 {
+		return l
+	}
+	l.rulePaths = append(l.rulePaths, paths...)
+	return l
+}
+
+// WithPluralRules satisfies the pluralRuleLoader contract used by config wiring.
+func (l *XLIFFLoader) WithPluralRules(paths ...string) Loader {
+	return l.WithPluralRuleFiles(paths...)
+}
+
+// WithSourceCatalog enables change detection: units whose checksum note no
+// longer matches the current source text are loaded with StateNew so the
+// next untranslated-only export sends them again.
+func (l *XLIFFLoader) WithSourceCatalog(catalog *TranslationCatalog) *XLIFFLoader {
+	if l == nil {
+		return l
+	}
+	l.source = catalog
+	return l
+}
+
+func (l *XLIFFLoader) watchedPaths() []string {
+	if l == nil {
+		return nil
+	}
+	paths := make([]string, 0, len(l.paths)+len(l.rulePaths))
+	paths = append(paths, l.paths...)
+	return append(paths, l.rulePaths...)
+}
+
+func (l *XLIFFLoader) Load() (Translations, error) {
+	if l == nil || len(l.paths) == 0 {
+		return nil, errors.New("i18n: no loader paths configured")
+	}
+
+	rules, err := loadPluralRuleFiles(nil, l.rulePaths)
+	if err != nil {
+		return nil, err
+	}
+
+	buckets := make(map[string]map[string]Message)
+	for _, path := range l.paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("i18n: read %s: %w", path, err)
+		}
+
+		doc, err := parseXLIFF(data)
+		if err != nil {
+			return nil, fmt.Errorf("i18n: decode %s: %w", path, err)
+		}
+
+		locale := normalizeLocale(doc.targetLocale)
+		if locale == "" {
+			return nil, fmt.Errorf("i18n: %s has no target language", path)
+		}
+
+		messages, err := l.buildMessages(locale, path, doc.units)
+		if err != nil {
+			return nil, fmt.Errorf("i18n: %s: %w", path, err)
+		}
+		mergeMessageBuckets(buckets, map[string]map[string]Message{locale: messages})
+	}
+
+	return buildCatalogs(buckets, rules), nil
+}
+
+func (l *XLIFFLoader) buildMessages(locale, source string, units []xliffUnit) (map[string]Message, error) {
+	type pending struct {
+		variants    map[PluralCategory]string
+		state       TranslationState
+		description string
+	}
+
+	order := make([]string, 0, len(units))
+	grouped := make(map[string]*pending, len(units))
+
+	for _, unit := range units {
+		if !unit.hasTarget || unit.target == "" {
+			continue
+		}
+
+		entry, ok := grouped[unit.key]
+		if !ok {
+			entry = &pending{variants: make(map[PluralCategory]string)}
+			grouped[unit.key] = entry
+			order = append(order, unit.key)
+		}
+
+		category := unit.category
+		if category == "" {
+			category = PluralOther
+		}
+		entry.variants[category] = unit.target
+
+		state := unit.state
+		if l.stale(unit) {
+			state = StateNew
+		}
+		entry.state = lowerState(entry.state, state, len(entry.variants) == 1)
+		if entry.description == "" {
+			entry.description = unit.description
+		}
+	}
+
+	messages := make(map[string]Message, len(order))
+	for _, key := range order {
+		entry := grouped[key]
+		message, err := buildMessageFromVariants(locale, key, entry.variants, source)
+		if err != nil {
+			return nil, err
+		}
+		message.Description = entry.description
+		message.State = entry.state
+		messages[key] = message
+	}
+
+	return messages, nil
+}
+
+// stale reports whether the source text a unit was translated from has
+// changed since export.
+func (l *XLIFFLoader) stale(unit xliffUnit) bool {
+	if l.source == nil || unit.checksum == "" {
+		return false
+	}
+	message, ok := l.source.Messages[unit.key]
+	if !ok {
+		return false
+	}
+	category := unit.category
+	if category == "" {
+		category = PluralOther
+	}
+	variant, ok := message.Variant(category)
+	if !ok {
+		return false
+	}
+	return variantChecksum(variant) != unit.checksum
+}
+
+// lowerState keeps the least advanced state across a message's variants.
+func lowerState(current, next TranslationState, first bool) TranslationState {
+	if first {
+		return next
+	}
+	if stateRank(next) < stateRank(current) {
+		return next
+	}
+	return current
+}
+
+func stateRank(state TranslationState) int {
+	switch state {
+	case StateNew:
+		return 0
+	case StateTranslated:
+		return 2
+	case StateReviewed:
+		return 3
+	default:
+		return 1
+	}
+}
+
+func variantChecksum(variant MessageVariant) string {
+	if variant.Checksum != "" {
+		return variant.Checksum
+	}
+	return checksum(variant.Template)
+}
+
+func parseXLIFF(data []byte) (*xliffDocument, error) {
+	var probe struct {
+		Version string `xml:"version,attr"`
+	}
+	if err := xml.Unmarshal(data, &probe); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case strings.HasPrefix(probe.Version, "2."):
+		var doc xliff20Document
+		if err := xml.Unmarshal(data, &doc); err != nil {
+			return nil, err
+		}
+		return doc.normalize(), nil
+	case strings.HasPrefix(probe.Version, "1."):
+		var doc xliff12Document
+		if err := xml.Unmarshal(data, &doc); err != nil {
+			return nil, err
+		}
+		return doc.normalize()
+	default:
+		return nil, fmt.Errorf("unsupported XLIFF version %q", probe.Version)
+	}
+}
+
+func (d *xliff12Document) normalize() (*xliffDocument, error) {
+	out := &xliffDocument{}
+	for _, file := range d.Files {
+		if out.targetLocale != "" && file.TargetLanguage != "" && !strings.EqualFold(out.targetLocale, file.TargetLanguage) {
+			return nil, fmt.Errorf("multiple target languages (%s, %s)", out.targetLocale, file.TargetLanguage)
+		}
+		if file.TargetLanguage != "" {
+			out.targetLocale = file.TargetLanguage
+		}
+		if out.sourceLocale == "" {
+			out.sourceLocale = file.SourceLanguage
+		}
+
+		for _, unit := range file.Body.Units {
+			out.units = append(out.units, unit.normalize(xliffKey(unit.Resname, unit.ID), "", ""))
+		}
+		for _, group := range file.Body.Groups {
+			out.units = append(out.units, group.normalize()...)
+		}
+	}
+	return out, nil
+}
+
+func (g xliff12Group) normalize() []xliffUnit {
+	var units []xliffUnit
+	if g.Restype != xliff12PluralRestype {
+		for _, unit := range g.Units {
+			units = append(units, unit.normalize(xliffKey(unit.Resname, unit.ID), "", ""))
+		}
+		for _, group := range g.Groups {
+			units = append(units, group.normalize()...)
+		}
+		return units
+	}
+
+	key := xliffKey(g.Resname, g.ID)
+	description, _ := xliff12Notes(g.Notes)
+	for _, unit := range g.Units {
+		units = append(units, unit.normalize(key, xliffPluralCategory(key, unit.Resname, unit.ID), description))
+	}
+	return units
+}
+
+func (u xliff12Unit) normalize(key string, category PluralCategory, description string) xliffUnit {
+	unitDescription, sum := xliff12Notes(u.Notes)
+	if unitDescription != "" {
+		description = unitDescription
+	}
+
+	out := xliffUnit{
+		key:         key,
+		category:    category,
+		source:      u.Source,
+		description: description,
+		checksum:    sum,
+	}
+	if u.Target != nil {
+		out.hasTarget = true
+		out.target = u.Target.Text
+		out.state = xliff12State(u.Target.State)
+	}
+	return out
+}
+
+func xliff12Notes(notes []xliff12Note) (description, sum string) {
+	var lines []string
+	for _, note := range notes {
+		if note.From == xliffNoteChecksum {
+			sum = strings.TrimSpace(note.Text)
+			continue
+		}
+		lines = append(lines, note.Text)
+	}
+	return strings.Join(lines, "\n"), sum
+}
+
+// xliff12State maps XLIFF 1.2 target states onto TranslationState.
+func xliff12State(state string) TranslationState {
+	switch state {
+	case "":
+		return ""
+	case "new", "needs-translation", "needs-adaptation", "needs-l10n":
+		return StateNew
+	case "signed-off", "final":
+		return StateReviewed
+	default:
+		return StateTranslated
+	}
+}
+
+func (d *xliff20Document) normalize() *xliffDocument {
+	out := &xliffDocument{sourceLocale: d.SrcLang, targetLocale: d.TrgLang}
+	for _, file := range d.Files {
+		for _, unit := range file.Units {
+			out.units = append(out.units, unit.normalize(xliffKey(unit.Name, unit.ID), "", ""))
+		}
+		for _, group := range file.Groups {
+			out.units = append(out.units, group.normalize()...)
+		}
+	}
+	return out
+}
+
+func (g xliff20Group) normalize() []xliffUnit {
+	var units []xliffUnit
+	if g.Type != xliff20PluralType {
+		for _, unit := range g.Units {
+			units = append(units, unit.normalize(xliffKey(unit.Name, unit.ID), "", ""))
+		}
+		for _, group := range g.Groups {
+			units = append(units, group.normalize()...)
+		}
+		return units
+	}
+
+	key := xliffKey(g.Name, g.ID)
+	description, _ := g.Notes.split()
+	for _, unit := range g.Units {
+		units = append(units, unit.normalize(key, xliffPluralCategory(key, unit.Name, unit.ID), description))
+	}
+	return units
+}
+
+func (u xliff20Unit) normalize(key string, category PluralCategory, description string) xliffUnit {
+	unitDescription, sum := u.Notes.split()
+	if unitDescription != "" {
+		description = unitDescription
+	}
+
+	out := xliffUnit{
+		key:         key,
+		category:    category,
+		description: description,
+		checksum:    sum,
+	}
+
+	var source, target strings.Builder
+	for _, segment := range u.Segments {
+		source.WriteString(segment.Source)
+		if segment.Target != nil {
+			out.hasTarget = true
+			target.WriteString(*segment.Target)
+		}
+		if state := xliff20State(segment.State); out.state == "" || stateRank(state) < stateRank(out.state) {
+			out.state = state
+		}
+	}
+	out.source = source.String()
+	out.target = target.String()
+	return out
+}
+
+func (n *xliff20Notes) split() (description, sum string) {
+	if n == nil {
+		return "", ""
+	}
+	var lines []string
+	for _, note := range n.Notes {
+		if note.Category == xliffNoteChecksum {
+			sum = strings.TrimSpace(note.Text)
+			continue
+		}
+		lines = append(lines, note.Text)
+	}
+	return strings.Join(lines, "\n"), sum
+}
+
+// xliff20State maps XLIFF 2.0 segment states onto TranslationState.
+func xliff20State(state string) TranslationState {
+	switch state {
+	case "":
+		return ""
+	case "initial":
+		return StateNew
+	case "reviewed", "final":
+		return StateReviewed
+	default:
+		return StateTranslated
+	}
+}
+
+func xliffKey(name, id string) string {
+	if name != "" {
+		return name
+	}
+	return id
+}
+
+// xliffPluralCategory reads the category from the unit name, falling back to
+// the id suffix written by WriteXLIFF ("key:one").
+func xliffPluralCategory(key, name, id string) PluralCategory {
+	if name != "" && name != key {
+		return PluralCategory(name)
+	}
+	if idx := strings.LastIndex(id, xliffPluralSeparator); idx >= 0 {
+		return PluralCategory(id[idx+1:])
+	}
+	return PluralOther
+}
@@ -0,0 +1,195 @@
+package i18n
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newXLIFFTranslations(t *testing.T) Translations {
+	t.Helper()
+
+	rules, err := loadPluralRuleFiles(nil, []string{filepath.Join("testdata", "cldr_cardinal.json")})
+	if err != nil {
+		t.Fatalf("load rules: %v", err)
+	}
+
+	build := func(locale, key string, variants map[PluralCategory]string) Message {
+		message, err := buildMessageFromVariants(locale, key, variants, "test")
+		if err != nil {
+			t.Fatalf("build %s/%s: %v", locale, key, err)
+		}
+		return message
+	}
+
+	title := build("en", "home.title", map[PluralCategory]string{PluralOther: "Welcome"})
+	title.Description = "Landing page heading"
+
+	ruTitle := build("ru", "home.title", map[PluralCategory]string{PluralOther: "Добро пожаловать"})
+	ruTitle.State = StateReviewed
+
+	ruItems := build("ru", "cart.items", map[PluralCategory]string{
+		PluralOne:   "{count} товар",
+		PluralFew:   "{count} товара",
+		PluralMany:  "{count} товаров",
+		PluralOther: "{count} товара",
+	})
+
+	return Translations{
+		"en": {
+			Locale: Locale{Code: "en"},
+			Messages: map[string]Message{
+				"home.title": title,
+				"cart.items": build("en", "cart.items", map[PluralCategory]string{
+					PluralOne:   "{count} item",
+					PluralOther: "{count} items",
+				}),
+				"home.logout": build("en", "home.logout", map[PluralCategory]string{PluralOther: "Log out"}),
+			},
+		},
+		"ru": {
+			Locale:        Locale{Code: "ru"},
+			CardinalRules: rules.cardinal["ru"],
+			Messages: map[string]Message{
+				"home.title": ruTitle,
+				"cart.items": ruItems,
+			},
+		},
+	}
+}
+
+func TestXLIFFRoundTrip(t *testing.T) {
+	for _, version := range []XLIFFVersion{XLIFF12, XLIFF20} {
+		t.Run(string(version), func(t *testing.T) {
+			translations := newXLIFFTranslations(t)
+
+			var buf bytes.Buffer
+			if err := WriteXLIFF(&buf, translations, "en", "ru", WithXLIFFVersion(version)); err != nil {
+				t.Fatalf("WriteXLIFF: %v", err)
+			}
+			if !strings.Contains(buf.String(), checksum("Welcome")) {
+				t.Fatalf("expected source checksum note:\n%s", buf.String())
+			}
+
+			path := writeTempFile(t, t.TempDir(), "ru.xlf", buf.Bytes())
+			loaded, err := NewXLIFFLoader(path).
+				WithPluralRuleFiles(filepath.Join("testdata", "cldr_cardinal.json")).
+				Load()
+			if err != nil {
+				t.Fatalf("Load: %v\n%s", err, buf.String())
+			}
+
+			catalog := loaded["ru"]
+			if catalog == nil || catalog.CardinalRules == nil {
+				t.Fatalf("expected ru catalog with rules, got %#v", loaded)
+			}
+			if _, ok := catalog.Messages["home.logout"]; ok {
+				t.Fatalf("untranslated unit should not be loaded")
+			}
+
+			title := catalog.Messages["home.title"]
+			if title.Content() != "Добро пожаловать" || title.State != StateReviewed || title.Description != "Landing page heading" {
+				t.Fatalf("unexpected home.title %#v", title)
+			}
+
+			items := catalog.Messages["cart.items"]
+			if items.State != StateTranslated {
+				t.Fatalf("cart.items state = %q", items.State)
+			}
+			for category, variant := range translations["ru"].Messages["cart.items"].Variants {
+				if items.Variants[category].Template != variant.Template {
+					t.Fatalf("cart.items[%s] = %q want %q", category, items.Variants[category].Template, variant.Template)
+				}
+			}
+		})
+	}
+}
+
+func TestWriteXLIFFUntranslatedOnly(t *testing.T) {
+	translations := newXLIFFTranslations(t)
+	delete(translations["ru"].Messages["cart.items"].Variants, PluralMany)
+
+	var buf bytes.Buffer
+	if err := WriteXLIFF(&buf, translations, "en", "ru", WithXLIFFVersion(XLIFF20), WithXLIFFUntranslatedOnly()); err != nil {
+		t.Fatalf("WriteXLIFF: %v", err)
+	}
+
+	output := buf.String()
+	if strings.Contains(output, `id="home.title"`) {
+		t.Fatalf("translated unit should be filtered:\n%s", output)
+	}
+	for _, want := range []string{
+		`<unit id="home.logout" name="home.logout">`,
+		`<group id="cart.items" name="cart.items" type="i18n:plural">`,
+		`<unit id="cart.items:many" name="many">`,
+		`<segment state="initial">`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("expected %q in output:\n%s", want, output)
+		}
+	}
+}
+
+func TestXLIFFLoaderDetectsStaleSource(t *testing.T) {
+	translations := newXLIFFTranslations(t)
+
+	var buf bytes.Buffer
+	if err := WriteXLIFF(&buf, translations, "en", "ru"); err != nil {
+		t.Fatalf("WriteXLIFF: %v", err)
+	}
+	path := writeTempFile(t, t.TempDir(), "ru.xlf", buf.Bytes())
+
+	source := translations["en"]
+	title := source.Messages["home.title"]
+	title.SetVariant(PluralOther, buildVariant("Welcome back", "test"))
+	source.Messages["home.title"] = title
+
+	loaded, err := NewXLIFFLoader(path).WithSourceCatalog(source).Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if state := loaded["ru"].Messages["home.title"].State; state != StateNew {
+		t.Fatalf("stale unit state = %q want %q", state, StateNew)
+	}
+	if state := loaded["ru"].Messages["cart.items"].State; state != StateTranslated {
+		t.Fatalf("unchanged unit state = %q want %q", state, StateTranslated)
+	}
+}
+
+func TestXLIFFLoaderReadsVendorFile(t *testing.T) {
+	path := writeTempFile(t, t.TempDir(), "vendor.xlf", []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<xliff xmlns="urn:oasis:names:tc:xliff:document:1.2" version="1.2">
+  <file original="app" source-language="en" target-language="es-MX" datatype="plaintext">
+    <body>
+      <group id="nav">
+        <trans-unit id="nav.home">
+          <source>Home</source>
+          <target state="final">Inicio</target>
+          <note>Top navigation</note>
+        </trans-unit>
+        <trans-unit id="nav.help">
+          <source>Help</source>
+          <target state="needs-translation"></target>
+        </trans-unit>
+      </group>
+    </body>
+  </file>
+</xliff>
+`))
+
+	loaded, err := NewXLIFFLoader(path).Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	catalog := loaded["es-MX"]
+	if catalog == nil || len(catalog.Messages) != 1 {
+		t.Fatalf("unexpected catalogs %#v", loaded)
+	}
+	home := catalog.Messages["nav.home"]
+	if home.Content() != "Inicio" || home.State != StateReviewed || home.Description != "Top navigation" {
+		t.Fatalf("unexpected nav.home %#v", home)
+	}
+}
@@ -0,0 +1,327 @@
+package i18n
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// XLIFFOption configures WriteXLIFF
+type XLIFFOption func(*xliffWriter)
+
+type xliffWriter struct {
+	version          XLIFFVersion
+	original         string
+	untranslatedOnly bool
+}
+
+// WithXLIFFVersion selects XLIFF 1.2 or 2.0 output (default 1.2).
+func WithXLIFFVersion(version XLIFFVersion) XLIFFOption {
+	return func(w *xliffWriter) {
+		if version != "" {
+			w.version = version
+		}
+	}
+}
+
+// WithXLIFFOriginal sets the file "original" attribute (default "messages").
+func WithXLIFFOriginal(name string) XLIFFOption {
+	return func(w *xliffWriter) {
+		if name != "" {
+			w.original = name
+		}
+	}
+}
+
+// WithXLIFFUntranslatedOnly limits the export to units in StateNew, which
+// includes keys missing from the target catalog. Plural groups are exported
+// whole when any of their forms is untranslated.
+func WithXLIFFUntranslatedOnly() XLIFFOption {
+	return func(w *xliffWriter) {
+		w.untranslatedOnly = true
+	}
+}
+
+// xliffExportUnit is a unit ready to be rendered in either dialect.
+type xliffExportUnit struct {
+	id       string
+	name     string
+	source   string
+	target   string
+	state    TranslationState
+	checksum string
+}
+
+// xliffExportMessage is a source message with one unit per exported form.
+type xliffExportMessage struct {
+	key         string
+	description string
+	plural      bool
+	units       []xliffExportUnit
+}
+
+// WriteXLIFF exports the keys of the source locale as an XLIFF document
+// targeting targetLocale. Descriptions are written as notes, every unit
+// carries the source variant checksum for change detection and plural
+// messages are exported as grouped units, one per target plural category.
+// Keys missing from the target catalog are exported in StateNew.
+func WriteXLIFF(w io.Writer, translations Translations, sourceLocale, targetLocale string, opts ...XLIFFOption) error {
+	writer := &xliffWriter{version: XLIFF12, original: "messages"}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(writer)
+		}
+	}
+
+	source := translations[sourceLocale]
+	if source == nil {
+		return fmt.Errorf("i18n: source locale %q not found", sourceLocale)
+	}
+	if targetLocale == "" {
+		return fmt.Errorf("i18n: target locale is required")
+	}
+
+	messages := writer.messages(source, translations[targetLocale])
+
+	var doc any
+	switch writer.version {
+	case XLIFF12:
+		doc = writer.document12(sourceLocale, targetLocale, messages)
+	case XLIFF20:
+		doc = writer.document20(sourceLocale, targetLocale, messages)
+	default:
+		return fmt.Errorf("i18n: unsupported XLIFF version %q", writer.version)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func (w *xliffWriter) messages(source, target *TranslationCatalog) []xliffExportMessage {
+	keys := make([]string, 0, len(source.Messages))
+	for key := range source.Messages {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	out := make([]xliffExportMessage, 0, len(keys))
+	for _, key := range keys {
+		sourceMessage := source.Messages[key]
+
+		var targetMessage Message
+		var hasTarget bool
+		if target != nil {
+			targetMessage, hasTarget = target.Messages[key]
+		}
+
+		message := xliffExportMessage{
+			key:         key,
+			description: sourceMessage.Description,
+			plural:      len(sourceMessage.Variants) > 1 || (hasTarget && len(targetMessage.Variants) > 1),
+		}
+		if message.description == "" && hasTarget {
+			message.description = targetMessage.Description
+		}
+
+		categories := []PluralCategory{PluralOther}
+		if message.plural {
+			categories = xliffCategories(sourceMessage, targetMessage, target)
+		}
+
+		pending := false
+		for _, category := range categories {
+			unit := xliffExportUnit{id: key, name: key}
+			if message.plural {
+				unit.id = key + xliffPluralSeparator + string(category)
+				unit.name = string(category)
+			}
+
+			if variant, ok := sourceMessage.Variant(category); ok {
+				unit.source = variant.Template
+				unit.checksum = variantChecksum(variant)
+			}
+
+			unit.state = StateNew
+			if hasTarget {
+				if variant, ok := targetMessage.Variants[category]; ok && variant.Template != "" {
+					unit.target = variant.Template
+					unit.state = targetMessage.State
+					if unit.state == "" {
+						unit.state = StateTranslated
+					}
+				}
+			}
+			if unit.state == StateNew {
+				pending = true
+			}
+			message.units = append(message.units, unit)
+		}
+
+		if w.untranslatedOnly && !pending {
+			continue
+		}
+		out = append(out, message)
+	}
+
+	return out
+}
+
+// xliffCategories lists the forms a plural message needs in the target
+// locale: its rule categories when known, otherwise the union of the
+// source and target variants. Explicit-value selectors ("=0") come first.
+func xliffCategories(source, target Message, catalog *TranslationCatalog) []PluralCategory {
+	seen := make(map[PluralCategory]struct{})
+	var exact, categories []PluralCategory
+	for _, variants := range []map[PluralCategory]MessageVariant{source.Variants, target.Variants} {
+		for category := range variants {
+			if _, ok := seen[category]; ok {
+				continue
+			}
+			seen[category] = struct{}{}
+			if category.IsExact() {
+				exact = append(exact, category)
+				continue
+			}
+			categories = append(categories, category)
+		}
+	}
+	sort.Slice(exact, func(i, j int) bool { return exact[i] < exact[j] })
+
+	if catalog != nil {
+		if ruleCategories := catalog.CardinalRules.Categories(); len(ruleCategories) > 0 {
+			return append(exact, ruleCategories...)
+		}
+	}
+
+	sort.Slice(categories, func(i, j int) bool {
+		return pluralCategoryOrder(categories[i]) < pluralCategoryOrder(categories[j])
+	})
+	return append(exact, categories...)
+}
+
+func (w *xliffWriter) document12(sourceLocale, targetLocale string, messages []xliffExportMessage) *xliff12Document {
+	file := xliff12File{
+		Original:       w.original,
+		SourceLanguage: sourceLocale,
+		TargetLanguage: targetLocale,
+		Datatype:       "plaintext",
+	}
+
+	for _, message := range messages {
+		var notes []xliff12Note
+		if message.description != "" {
+			notes = append(notes, xliff12Note{From: xliffNoteDescription, Text: message.description})
+		}
+
+		if !message.plural {
+			file.Body.Units = append(file.Body.Units, xliff12ExportUnit(message.units[0], notes))
+			continue
+		}
+
+		group := xliff12Group{ID: message.key, Resname: message.key, Restype: xliff12PluralRestype, Notes: notes}
+		for _, unit := range message.units {
+			group.Units = append(group.Units, xliff12ExportUnit(unit, nil))
+		}
+		file.Body.Groups = append(file.Body.Groups, group)
+	}
+
+	return &xliff12Document{
+		Xmlns:   xliff12Namespace,
+		Version: string(XLIFF12),
+		Files:   []xliff12File{file},
+	}
+}
+
+func xliff12ExportUnit(unit xliffExportUnit, notes []xliff12Note) xliff12Unit {
+	out := xliff12Unit{ID: unit.id, Resname: unit.name, Source: unit.source, Notes: notes}
+	if unit.checksum != "" {
+		out.Notes = append(out.Notes, xliff12Note{From: xliffNoteChecksum, Text: unit.checksum})
+	}
+	if unit.target != "" {
+		out.Target = &xliff12Target{State: xliff12StateName(unit.state), Text: unit.target}
+	}
+	return out
+}
+
+func xliff12StateName(state TranslationState) string {
+	switch state {
+	case StateNew:
+		return "new"
+	case StateReviewed:
+		return "signed-off"
+	default:
+		return "translated"
+	}
+}
+
+func (w *xliffWriter) document20(sourceLocale, targetLocale string, messages []xliffExportMessage) *xliff20Document {
+	file := xliff20File{ID: "f1", Original: w.original}
+
+	for _, message := range messages {
+		var notes []xliff20Note
+		if message.description != "" {
+			notes = append(notes, xliff20Note{Category: xliffNoteDescription, Text: message.description})
+		}
+
+		if !message.plural {
+			file.Units = append(file.Units, xliff20ExportUnit(message.units[0], notes))
+			continue
+		}
+
+		group := xliff20Group{ID: message.key, Name: message.key, Type: xliff20PluralType}
+		if len(notes) > 0 {
+			group.Notes = &xliff20Notes{Notes: notes}
+		}
+		for _, unit := range message.units {
+			group.Units = append(group.Units, xliff20ExportUnit(unit, nil))
+		}
+		file.Groups = append(file.Groups, group)
+	}
+
+	return &xliff20Document{
+		Xmlns:   xliff20Namespace,
+		Version: string(XLIFF20),
+		SrcLang: sourceLocale,
+		TrgLang: targetLocale,
+		Files:   []xliff20File{file},
+	}
+}
+
+func xliff20ExportUnit(unit xliffExportUnit, notes []xliff20Note) xliff20Unit {
+	if unit.checksum != "" {
+		notes = append(notes, xliff20Note{Category: xliffNoteChecksum, Text: unit.checksum})
+	}
+
+	out := xliff20Unit{ID: unit.id, Name: unit.name}
+	if len(notes) > 0 {
+		out.Notes = &xliff20Notes{Notes: notes}
+	}
+
+	segment := xliff20Segment{State: xliff20StateName(unit.state), Source: unit.source}
+	if unit.target != "" {
+		target := unit.target
+		segment.Target = &target
+	}
+	out.Segments = []xliff20Segment{segment}
+	return out
+}
+
+func xliff20StateName(state TranslationState) string {
+	switch state {
+	case StateNew:
+		return "initial"
+	case StateReviewed:
+		return "reviewed"
+	default:
+		return "translated"
+	}
+}